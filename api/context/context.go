@@ -215,12 +215,6 @@ func (ctx *Context) WithDutyContext(c dutyCtx.Context) *Context {
 	return ctx
 }
 
-func (ctx *Context) WithKubeconfig(input types.EnvVar) (Context, error) {
-	c, err := ctx.Context.WithKubeconfig(input)
-	ctx.Context = *c
-	return *ctx, err
-}
-
 func (ctx *Context) WithCheck(check external.Check) *Context {
 	env := make(map[string]any)
 
@@ -0,0 +1,66 @@
+package context
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	v1 "github.com/flanksource/canary-checker/api/v1"
+	dutyKubernetes "github.com/flanksource/duty/kubernetes"
+	"github.com/flanksource/duty/types"
+	gocache "github.com/patrickmn/go-cache"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// kubernetesClients caches the clients of remote clusters across check runs, keyed by the hash of the kubeconfig.
+// Clients are recreated once they expire, so that rotated credentials are picked up.
+var kubernetesClients = gocache.New(time.Hour, 10*time.Minute)
+
+type kubernetesClient struct {
+	client kubernetes.Interface
+	config *rest.Config
+}
+
+func kubeconfigKey(kubeconfig string) string {
+	hash := sha256.Sum256([]byte(kubeconfig))
+	return hex.EncodeToString(hash[:])
+}
+
+// WithKubeconfig returns a copy of the context whose kubernetes client connects to the cluster of the kubeconfig
+func (ctx *Context) WithKubeconfig(input types.EnvVar) (Context, error) {
+	if ctx.GetNamespace() == "" {
+		return *ctx, ctx.Oops().Errorf("namespace is required")
+	}
+
+	val, err := ctx.GetEnvValueFromCache(input, ctx.GetNamespace())
+	if err != nil {
+		return *ctx, ctx.Oops().Wrap(err)
+	}
+
+	key := kubeconfigKey(val)
+	var kc *kubernetesClient
+	if cached, ok := kubernetesClients.Get(key); ok {
+		kc = cached.(*kubernetesClient)
+	} else {
+		client, config, err := dutyKubernetes.NewClientFromPathOrConfig(ctx.Logger, val)
+		if err != nil {
+			return *ctx, ctx.Oops().Wrap(err)
+		}
+		kc = &kubernetesClient{client: client, config: config}
+		kubernetesClients.SetDefault(key, kc)
+	}
+
+	c := *ctx
+	c.Context = ctx.Context.WithKubernetes(kc.client, kc.config)
+	return c, nil
+}
+
+// WithKubernetesConnection returns a copy of the context that targets the cluster of the connection,
+// the context is returned as is when the connection has no kubeconfig
+func (ctx *Context) WithKubernetesConnection(conn v1.KubernetesConnection) (Context, error) {
+	if conn.Kubeconfig == nil {
+		return *ctx, nil
+	}
+	return ctx.WithKubeconfig(*conn.Kubeconfig)
+}
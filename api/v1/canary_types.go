@@ -59,11 +59,19 @@ type CanarySpec struct {
 	Postgres           []PostgresCheck           `yaml:"postgres,omitempty" json:"postgres,omitempty"`
 	Mssql              []MssqlCheck              `yaml:"mssql,omitempty" json:"mssql,omitempty"`
 	Mysql              []MysqlCheck              `yaml:"mysql,omitempty" json:"mysql,omitempty"`
+	SQLite             []SQLiteCheck             `yaml:"sqlite,omitempty" json:"sqlite,omitempty"`
+	Clickhouse         []ClickhouseCheck         `yaml:"clickhouse,omitempty" json:"clickhouse,omitempty"`
+	Oracle             []OracleCheck             `yaml:"oracle,omitempty" json:"oracle,omitempty"`
 	Restic             []ResticCheck             `yaml:"restic,omitempty" json:"restic,omitempty"`
+	Kopia              []KopiaCheck              `yaml:"kopia,omitempty" json:"kopia,omitempty"`
+	Borg               []BorgCheck               `yaml:"borg,omitempty" json:"borg,omitempty"`
+	Velero             []VeleroCheck             `yaml:"velero,omitempty" json:"velero,omitempty"`
 	Jmeter             []JmeterCheck             `yaml:"jmeter,omitempty" json:"jmeter,omitempty"`
 	Junit              []JunitCheck              `yaml:"junit,omitempty" json:"junit,omitempty"`
+	K6                 []K6Check                 `yaml:"k6,omitempty" json:"k6,omitempty"`
 	Helm               []HelmCheck               `yaml:"helm,omitempty" json:"helm,omitempty"`
 	Namespace          []NamespaceCheck          `yaml:"namespace,omitempty" json:"namespace,omitempty"`
+	NetworkMatrix      []NetworkMatrixCheck      `yaml:"networkMatrix,omitempty" json:"networkMatrix,omitempty"`
 	Redis              []RedisCheck              `yaml:"redis,omitempty" json:"redis,omitempty"`
 	Prometheus         []PrometheusCheck         `yaml:"prometheus,omitempty" json:"prometheus,omitempty"`
 	MongoDB            []MongoDBCheck            `yaml:"mongodb,omitempty" json:"mongodb,omitempty"`
@@ -93,6 +101,11 @@ type CanarySpec struct {
 	Severity   string     `yaml:"severity,omitempty" json:"severity,omitempty"`
 	Owner      string     `yaml:"owner,omitempty" json:"owner,omitempty"`
 	ResultMode ResultMode `yaml:"resultMode,omitempty" json:"resultMode,omitempty"`
+	// TemplateRef renders the spec from a CanaryTemplate, checks defined in this spec are added to the
+	// checks of the template and the other fields override the template
+	TemplateRef *CanaryTemplateRef `yaml:"templateRef,omitempty" json:"templateRef,omitempty"`
+	// Values of the parameters of the template
+	Values map[string]string `yaml:"values,omitempty" json:"values,omitempty"`
 }
 
 func (spec CanarySpec) GetAllChecks() []external.Check {
@@ -136,12 +149,30 @@ func (spec CanarySpec) GetAllChecks() []external.Check {
 	for _, check := range spec.Mssql {
 		checks = append(checks, check)
 	}
+	for _, check := range spec.SQLite {
+		checks = append(checks, check)
+	}
+	for _, check := range spec.Clickhouse {
+		checks = append(checks, check)
+	}
+	for _, check := range spec.Oracle {
+		checks = append(checks, check)
+	}
 	for _, check := range spec.Redis {
 		checks = append(checks, check)
 	}
 	for _, check := range spec.Restic {
 		checks = append(checks, check)
 	}
+	for _, check := range spec.Kopia {
+		checks = append(checks, check)
+	}
+	for _, check := range spec.Borg {
+		checks = append(checks, check)
+	}
+	for _, check := range spec.Velero {
+		checks = append(checks, check)
+	}
 	for _, check := range spec.ICMP {
 		checks = append(checks, check)
 	}
@@ -151,12 +182,18 @@ func (spec CanarySpec) GetAllChecks() []external.Check {
 	for _, check := range spec.Namespace {
 		checks = append(checks, check)
 	}
+	for _, check := range spec.NetworkMatrix {
+		checks = append(checks, check)
+	}
 	for _, check := range spec.Jmeter {
 		checks = append(checks, check)
 	}
 	for _, check := range spec.Junit {
 		checks = append(checks, check)
 	}
+	for _, check := range spec.K6 {
+		checks = append(checks, check)
+	}
 	for _, check := range spec.Prometheus {
 		checks = append(checks, check)
 	}
@@ -259,21 +296,45 @@ func (spec CanarySpec) KeepOnly(names ...string) CanarySpec {
 	spec.Mysql = lo.Filter(spec.Mysql, func(c MysqlCheck, _ int) bool {
 		return lo.Contains(names, c.GetName())
 	})
+	spec.SQLite = lo.Filter(spec.SQLite, func(c SQLiteCheck, _ int) bool {
+		return lo.Contains(names, c.GetName())
+	})
+	spec.Clickhouse = lo.Filter(spec.Clickhouse, func(c ClickhouseCheck, _ int) bool {
+		return lo.Contains(names, c.GetName())
+	})
+	spec.Oracle = lo.Filter(spec.Oracle, func(c OracleCheck, _ int) bool {
+		return lo.Contains(names, c.GetName())
+	})
 	spec.Restic = lo.Filter(spec.Restic, func(c ResticCheck, _ int) bool {
 		return lo.Contains(names, c.GetName())
 	})
+	spec.Kopia = lo.Filter(spec.Kopia, func(c KopiaCheck, _ int) bool {
+		return lo.Contains(names, c.GetName())
+	})
+	spec.Borg = lo.Filter(spec.Borg, func(c BorgCheck, _ int) bool {
+		return lo.Contains(names, c.GetName())
+	})
+	spec.Velero = lo.Filter(spec.Velero, func(c VeleroCheck, _ int) bool {
+		return lo.Contains(names, c.GetName())
+	})
 	spec.Jmeter = lo.Filter(spec.Jmeter, func(c JmeterCheck, _ int) bool {
 		return lo.Contains(names, c.GetName())
 	})
 	spec.Junit = lo.Filter(spec.Junit, func(c JunitCheck, _ int) bool {
 		return lo.Contains(names, c.GetName())
 	})
+	spec.K6 = lo.Filter(spec.K6, func(c K6Check, _ int) bool {
+		return lo.Contains(names, c.GetName())
+	})
 	spec.Helm = lo.Filter(spec.Helm, func(c HelmCheck, _ int) bool {
 		return lo.Contains(names, c.GetName())
 	})
 	spec.Namespace = lo.Filter(spec.Namespace, func(c NamespaceCheck, _ int) bool {
 		return lo.Contains(names, c.GetName())
 	})
+	spec.NetworkMatrix = lo.Filter(spec.NetworkMatrix, func(c NetworkMatrixCheck, _ int) bool {
+		return lo.Contains(names, c.GetName())
+	})
 	spec.Redis = lo.Filter(spec.Redis, func(c RedisCheck, _ int) bool {
 		return lo.Contains(names, c.GetName())
 	})
@@ -414,6 +475,11 @@ type CanaryStatus struct {
 	// Replicas keep track of the number of replicas
 	Replicas int    `json:"replicas,omitempty"`
 	Selector string `json:"selector,omitempty"` // for autoscaling
+	// Conditions of the canary: Ready, Healthy, Degraded, Suspended and Invalid
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []Condition `json:"conditions,omitempty"`
 }
 
 func (c Canary) GetCheckID(checkName string) string {
@@ -441,6 +507,9 @@ type CheckStatus struct {
 // +kubebuilder:printcolumn:name="Replicas",type=integer,priority=1,JSONPath=`.spec.replicas`
 // +kubebuilder:printcolumn:name="Interval",type=string,JSONPath=`.spec.interval`
 // +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.status`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Healthy",type=string,JSONPath=`.status.conditions[?(@.type=="Healthy")].status`
+// +kubebuilder:printcolumn:name="Suspended",type=string,priority=1,JSONPath=`.status.conditions[?(@.type=="Suspended")].status`
 // +kubebuilder:printcolumn:name="Last Check",type=date,JSONPath=`.status.lastCheck`
 // +kubebuilder:printcolumn:name="Uptime 1H",type=string,JSONPath=`.status.uptime1h`
 // +kubebuilder:printcolumn:name="Latency 1H",type=string,JSONPath=`.status.latency1h`
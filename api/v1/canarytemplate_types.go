@@ -0,0 +1,87 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CanaryTemplateParameter is a value that a canary referencing the template can set
+type CanaryTemplateParameter struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	// Default is used when the canary does not set the parameter
+	Default string `json:"default,omitempty" yaml:"default,omitempty"`
+	// Required parameters must be set by the canary when there is no default
+	Required bool `json:"required,omitempty" yaml:"required,omitempty"`
+}
+
+// CanaryTemplateSpec defines a parameterised canary spec
+type CanaryTemplateSpec struct {
+	Parameters []CanaryTemplateParameter `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	// Template is a canary spec, parameters are substituted using go templates with [[ ]] delimiters
+	// so that the templates of the checks are left as is, e.g. url: "https://[[ .host ]]/health"
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Type=object
+	Template json.RawMessage `json:"template" yaml:"template"`
+}
+
+// CanaryTemplateRef references the CanaryTemplate a canary is rendered from
+type CanaryTemplateRef struct {
+	Name string `json:"name" yaml:"name"`
+	// Namespace of the template, defaults to the namespace of the canary
+	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+}
+
+func (ref CanaryTemplateRef) GetNamespace(canaryNamespace string) string {
+	if ref.Namespace != "" {
+		return ref.Namespace
+	}
+	return canaryNamespace
+}
+
+// +kubebuilder:object:root=true
+
+// CanaryTemplate is a reusable canary spec that canaries reference with templateRef and values
+type CanaryTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CanaryTemplateSpec `json:"spec,omitempty"`
+}
+
+// Values returns the parameters of the template with the values set by the canary
+func (t CanaryTemplate) Values(values map[string]string) (map[string]any, error) {
+	params := make(map[string]any)
+	for _, p := range t.Spec.Parameters {
+		value, ok := values[p.Name]
+		if !ok {
+			if p.Required && p.Default == "" {
+				return nil, fmt.Errorf("parameter %s is required by template %s/%s", p.Name, t.Namespace, t.Name)
+			}
+			value = p.Default
+		}
+		params[p.Name] = value
+	}
+	for name := range values {
+		if _, ok := params[name]; !ok {
+			return nil, fmt.Errorf("template %s/%s has no parameter %s", t.Namespace, t.Name, name)
+		}
+	}
+	return params, nil
+}
+
+// +kubebuilder:object:root=true
+
+// CanaryTemplateList contains a list of CanaryTemplate
+type CanaryTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CanaryTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CanaryTemplate{}, &CanaryTemplateList{})
+}
@@ -19,6 +19,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	k8sTypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 // List of additional check label keys that should be included in the check metrics.
@@ -137,6 +138,15 @@ func (c HTTPCheck) GetType() string {
 	return "http"
 }
 
+func (c HTTPCheck) Validate(path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	//nolint:staticcheck
+	if c.Endpoint != "" && c.URL != "" {
+		errs = append(errs, field.Forbidden(path.Child("endpoint"), "cannot specify both endpoint and url"))
+	}
+	return errs
+}
+
 func (c HTTPCheck) GetMethod() string {
 	if c.Method != "" {
 		return c.Method
@@ -258,6 +268,91 @@ func (c ResticCheck) GetType() string {
 	return "restic"
 }
 
+// BackupRepository are the freshness and integrity thresholds shared by all backup repository checks
+type BackupRepository struct {
+	// MaxAge of the newest snapshot
+	MaxAge Duration `yaml:"maxAge,omitempty" json:"maxAge,omitempty"`
+	// MinCount is the minimum number of snapshots expected in the repository
+	MinCount int `yaml:"minCount,omitempty" json:"minCount,omitempty"`
+	// MaxSizeChange is the maximum percentage by which the size of the newest snapshot may differ from the previous one
+	MaxSizeChange int `yaml:"maxSizeChange,omitempty" json:"maxSizeChange,omitempty"`
+	// CheckIntegrity when enabled will verify the integrity of the repository
+	CheckIntegrity bool `yaml:"checkIntegrity,omitempty" json:"checkIntegrity,omitempty"`
+}
+
+type KopiaCheck struct {
+	Description      `yaml:",inline" json:",inline"`
+	Templatable      `yaml:",inline" json:",inline"`
+	Relatable        `yaml:",inline" json:",inline"`
+	BackupRepository `yaml:",inline" json:",inline"`
+	// Repository is the storage type and flags passed to `kopia repository connect` e.g. `filesystem --path=/backups` or `s3 --bucket=backups`
+	Repository string `yaml:"repository" json:"repository"`
+	// Password for the kopia repository
+	Password types.EnvVar `yaml:"password,omitempty" json:"password,omitempty"`
+	// AccessKey access key id for s3 compatible repositories
+	AccessKey types.EnvVar `yaml:"accessKey,omitempty" json:"accessKey,omitempty"`
+	// SecretKey secret access key for s3 compatible repositories
+	SecretKey types.EnvVar `yaml:"secretKey,omitempty" json:"secretKey,omitempty"`
+}
+
+func (c KopiaCheck) GetEndpoint() string {
+	return c.Repository
+}
+
+func (c KopiaCheck) GetType() string {
+	return "kopia"
+}
+
+type BorgCheck struct {
+	Description      `yaml:",inline" json:",inline"`
+	Templatable      `yaml:",inline" json:",inline"`
+	Relatable        `yaml:",inline" json:",inline"`
+	BackupRepository `yaml:",inline" json:",inline"`
+	// Repository is the borg repository e.g. `/backups/borg` or `ssh://user@host/./repo`
+	Repository string `yaml:"repository" json:"repository"`
+	// Passphrase for the borg repository
+	Passphrase types.EnvVar `yaml:"passphrase,omitempty" json:"passphrase,omitempty"`
+	// SSHKey is the private key used to access remote repositories
+	SSHKey types.EnvVar `yaml:"sshKey,omitempty" json:"sshKey,omitempty"`
+}
+
+func (c BorgCheck) GetEndpoint() string {
+	return c.Repository
+}
+
+func (c BorgCheck) GetType() string {
+	return "borg"
+}
+
+type VeleroCheck struct {
+	Description          `yaml:",inline" json:",inline"`
+	Templatable          `yaml:",inline" json:",inline"`
+	Relatable            `yaml:",inline" json:",inline"`
+	KubernetesConnection `yaml:",inline" json:",inline"`
+	BackupRepository     `yaml:",inline" json:",inline"`
+	// VeleroNamespace is the namespace velero is installed in, defaults to velero
+	VeleroNamespace string `yaml:"veleroNamespace,omitempty" json:"veleroNamespace,omitempty"`
+	// Schedule limits the check to backups created by the given schedule, the schedule must also be enabled
+	Schedule string `yaml:"schedule,omitempty" json:"schedule,omitempty"`
+	// Selector is a label selector to filter backups by
+	Selector string `yaml:"selector,omitempty" json:"selector,omitempty"`
+}
+
+func (c VeleroCheck) GetVeleroNamespace() string {
+	if c.VeleroNamespace == "" {
+		return "velero"
+	}
+	return c.VeleroNamespace
+}
+
+func (c VeleroCheck) GetEndpoint() string {
+	return c.GetVeleroNamespace() + "/" + c.Schedule
+}
+
+func (c VeleroCheck) GetType() string {
+	return "velero"
+}
+
 type JmeterCheck struct {
 	Description `yaml:",inline" json:",inline"`
 	Relatable   `yaml:",inline" json:",inline"`
@@ -283,6 +378,42 @@ func (c JmeterCheck) GetType() string {
 	return "jmeter"
 }
 
+type K6Check struct {
+	Description `yaml:",inline" json:",inline"`
+	Templatable `yaml:",inline" json:",inline"`
+	Relatable   `yaml:",inline" json:",inline"`
+	// Script is an inline k6 script
+	Script string `yaml:"script,omitempty" json:"script,omitempty"`
+	// ScriptFrom defines the ConfigMap or Secret reference to get the k6 script
+	ScriptFrom *types.EnvVarSource `yaml:"scriptFrom,omitempty" json:"scriptFrom,omitempty"`
+	// Path to the script inside the checkout
+	Path string `yaml:"path,omitempty" json:"path,omitempty"`
+	// Checkout details the git repository that contains the script
+	Checkout *connection.GitConnection `yaml:"checkout,omitempty" json:"checkout,omitempty"`
+	// Args are additional arguments passed to `k6 run` e.g. `["--vus", "10"]`
+	Args []string `yaml:"args,omitempty" json:"args,omitempty"`
+	// EnvVars are the environment variables that are accessible to the script via __ENV
+	EnvVars []types.EnvVar `yaml:"env,omitempty" json:"env,omitempty"`
+}
+
+func (c K6Check) GetEndpoint() string {
+	if c.Checkout != nil {
+		return c.Checkout.URL + "/" + c.Path
+	}
+	return c.Path
+}
+
+func (c K6Check) GetType() string {
+	return "k6"
+}
+
+func (c K6Check) Validate(path *field.Path) field.ErrorList {
+	if c.Script == "" && c.ScriptFrom == nil && c.Path == "" {
+		return field.ErrorList{field.Required(path.Child("script"), "one of script, scriptFrom or path is required")}
+	}
+	return nil
+}
+
 type DockerPullCheck struct {
 	Description    `yaml:",inline" json:",inline"`
 	Relatable      `yaml:",inline" json:",inline"`
@@ -350,11 +481,60 @@ func (c ContainerdPushCheck) GetType() string {
 
 type RedisCheck struct {
 	Description `yaml:",inline" json:",inline"`
+	Templatable `yaml:",inline" json:",inline"`
 	Relatable   `yaml:",inline" json:",inline"`
 	Connection  `yaml:",inline" json:",inline"`
 	// Deprecated: Use url instead
 	Addr string `yaml:"addr,omitempty" json:"addr,omitempty" template:"true"`
 	DB   *int   `yaml:"db,omitempty" json:"db,omitempty"`
+	// Sentinel connects to the master through the given sentinels and checks every replica
+	Sentinel *RedisSentinel `yaml:"sentinel,omitempty" json:"sentinel,omitempty"`
+	// Cluster connects to a redis cluster and checks every shard
+	Cluster *RedisCluster `yaml:"cluster,omitempty" json:"cluster,omitempty"`
+	// Commands to run after connecting, replies are available to templates under `results.commands`
+	Commands []RedisCommand `yaml:"commands,omitempty" json:"commands,omitempty"`
+	// MaxReplicationLag fails the check if any replica has not heard from its master for longer, e.g. 30s
+	MaxReplicationLag Duration `yaml:"maxReplicationLag,omitempty" json:"maxReplicationLag,omitempty"`
+}
+
+type RedisSentinel struct {
+	// MasterName as configured in the sentinels
+	MasterName string `yaml:"masterName" json:"masterName"`
+	// Addrs of the sentinels in host:port format
+	Addrs []string `yaml:"addrs" json:"addrs"`
+	// Password used to authenticate against the sentinels
+	Password types.EnvVar `yaml:"password,omitempty" json:"password,omitempty"`
+}
+
+type RedisCluster struct {
+	// Addrs is a seed list of cluster nodes in host:port format, defaults to the connection url
+	Addrs []string `yaml:"addrs,omitempty" json:"addrs,omitempty"`
+}
+
+type RedisCommand struct {
+	// Name used to lookup the reply in templates, defaults to the command itself
+	Name string `yaml:"name,omitempty" json:"name,omitempty"`
+	// Command and its arguments, e.g. ["INFO", "memory"]
+	Command []string `yaml:"command" json:"command"`
+	// Expected reply, the check fails if the reply does not match
+	Expect string `yaml:"expect,omitempty" json:"expect,omitempty"`
+}
+
+func (c RedisCommand) GetName() string {
+	if c.Name != "" {
+		return c.Name
+	}
+	return strings.Join(c.Command, " ")
+}
+
+func (c RedisCheck) GetMode() string {
+	if c.Sentinel != nil {
+		return "sentinel"
+	}
+	if c.Cluster != nil {
+		return "cluster"
+	}
+	return "standalone"
 }
 
 func (c RedisCheck) GetType() string {
@@ -452,7 +632,74 @@ func (m MysqlCheck) GetType() string {
 	return "mysql"
 }
 
+type SQLiteCheck struct {
+	SQLCheck `yaml:",inline" json:",inline"`
+}
+
+func (c SQLiteCheck) GetCheck() external.Check {
+	return c
+}
+
+func (c SQLiteCheck) GetSQLCheck() SQLCheck {
+	return c.SQLCheck
+}
+
+func (c SQLiteCheck) GetDriver() string {
+	return "sqlite"
+}
+
+func (c SQLiteCheck) GetType() string {
+	return "sqlite"
+}
+
+type ClickhouseCheck struct {
+	SQLCheck `yaml:",inline" json:",inline"`
+}
+
+func (c ClickhouseCheck) GetCheck() external.Check {
+	return c
+}
+
+func (c ClickhouseCheck) GetSQLCheck() SQLCheck {
+	return c.SQLCheck
+}
+
+func (c ClickhouseCheck) GetDriver() string {
+	return "clickhouse"
+}
+
+func (c ClickhouseCheck) GetType() string {
+	return "clickhouse"
+}
+
+type OracleCheck struct {
+	SQLCheck `yaml:",inline" json:",inline"`
+}
+
+func (c OracleCheck) GetCheck() external.Check {
+	return c
+}
+
+func (c OracleCheck) GetSQLCheck() SQLCheck {
+	if c.Query == "" {
+		// oracle requires a FROM clause
+		c.Query = "SELECT 1 FROM DUAL"
+	}
+	return c.SQLCheck
+}
+
+func (c OracleCheck) GetDriver() string {
+	return "oracle"
+}
+
+func (c OracleCheck) GetType() string {
+	return "oracle"
+}
+
 /*
+MongoDB check connects to a MongoDB server and optionally runs a find filter, aggregation pipeline or a built-in query (replicaSetStatus),
+exposing the returned documents as `results.rows` to test, display and transform templates.
+
 [include:datasources/mongo_pass.yaml]
 */
 type Mongo struct {
@@ -561,6 +808,7 @@ func (c AlertManagerCheck) GetType() string {
 type PodCheck struct {
 	Description          `yaml:",inline" json:",inline"`
 	Relatable            `yaml:",inline" json:",inline"`
+	KubernetesConnection `yaml:",inline" json:",inline"`
 	Namespace            string `yaml:"namespace" json:"namespace,omitempty" template:"true"`
 	Spec                 string `yaml:"spec" json:"spec,omitempty"`
 	ScheduleTimeout      int64  `yaml:"scheduleTimeout,omitempty" json:"scheduleTimeout,omitempty"`
@@ -593,13 +841,131 @@ func (c PodCheck) GetType() string {
 	return "pod"
 }
 
+const (
+	NetworkMatrixTCP  = "tcp"
+	NetworkMatrixHTTP = "http"
+	NetworkMatrixDNS  = "dns"
+)
+
+type NetworkMatrixCheck struct {
+	Description          `yaml:",inline" json:",inline"`
+	Templatable          `yaml:",inline" json:",inline"`
+	Relatable            `yaml:",inline" json:",inline"`
+	KubernetesConnection `yaml:",inline" json:",inline"`
+	// Namespaces to launch a probe pod in
+	Namespaces []string `yaml:"namespaces" json:"namespaces"`
+	// Nodes to launch the probe pods on, a probe pod is launched in every namespace on every node.
+	// Probe pods can be scheduled on any node when empty
+	Nodes []string `yaml:"nodes,omitempty" json:"nodes,omitempty"`
+	// Services to test from every probe pod, the DNS name of the service is resolved before connecting to it
+	Services []NetworkMatrixService `yaml:"services,omitempty" json:"services,omitempty"`
+	// Protocols to test between probe pods: tcp and http, defaults to both
+	Protocols []string `yaml:"protocols,omitempty" json:"protocols,omitempty"`
+	// Expected reachability, the first matching rule is used and edges without a matching rule are expected to be allowed
+	Expected []NetworkMatrixRule `yaml:"expected,omitempty" json:"expected,omitempty"`
+	// Image of the probe pods, it must include sh, httpd, nc, wget and nslookup. Defaults to busybox
+	Image string `yaml:"image,omitempty" json:"image,omitempty"`
+	// Port the probe pods listen on, defaults to 8080
+	Port          int    `yaml:"port,omitempty" json:"port,omitempty"`
+	PriorityClass string `yaml:"priorityClass,omitempty" json:"priorityClass,omitempty"`
+	// ScheduleTimeout is how long to wait for the probe pods to start, defaults to 2m
+	ScheduleTimeout Duration `yaml:"scheduleTimeout,omitempty" json:"scheduleTimeout,omitempty"`
+	// Timeout of each connection, defaults to 5s
+	Timeout Duration `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+}
+
+func (c NetworkMatrixCheck) GetType() string {
+	return "networkMatrix"
+}
+
+func (c NetworkMatrixCheck) GetEndpoint() string {
+	return strings.Join(c.Namespaces, ",")
+}
+
+func (c NetworkMatrixCheck) GetPort() int {
+	if c.Port == 0 {
+		return 8080
+	}
+	return c.Port
+}
+
+func (c NetworkMatrixCheck) GetProtocols() []string {
+	if len(c.Protocols) == 0 {
+		return []string{NetworkMatrixTCP, NetworkMatrixHTTP}
+	}
+	return c.Protocols
+}
+
+func (c NetworkMatrixCheck) Validate(path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if len(c.Namespaces) == 0 {
+		errs = append(errs, field.Required(path.Child("namespaces"), "at least one namespace is required"))
+	}
+	supported := []string{NetworkMatrixTCP, NetworkMatrixHTTP}
+	for i, protocol := range c.Protocols {
+		if !lo.Contains(supported, protocol) {
+			errs = append(errs, field.NotSupported(path.Child("protocols").Index(i), protocol, supported))
+		}
+	}
+	for i, svc := range c.Services {
+		if svc.Protocol != "" && !lo.Contains(supported, svc.Protocol) {
+			errs = append(errs, field.NotSupported(path.Child("services").Index(i).Child("protocol"), svc.Protocol, supported))
+		}
+	}
+	errs = append(errs, c.ScheduleTimeout.Validate(path.Child("scheduleTimeout"))...)
+	errs = append(errs, c.Timeout.Validate(path.Child("timeout"))...)
+	return errs
+}
+
+type NetworkMatrixService struct {
+	Name      string `yaml:"name" json:"name"`
+	Namespace string `yaml:"namespace" json:"namespace"`
+	Port      int    `yaml:"port" json:"port"`
+	// Protocol to connect to the service with: tcp (default) or http
+	Protocol string `yaml:"protocol,omitempty" json:"protocol,omitempty"`
+	// Path of the http request
+	Path string `yaml:"path,omitempty" json:"path,omitempty"`
+}
+
+func (s NetworkMatrixService) String() string {
+	return s.Namespace + "/" + s.Name
+}
+
+type NetworkMatrixRule struct {
+	// From is the namespace of the source probe pod, globs are supported
+	From string `yaml:"from" json:"from"`
+	// To is the namespace of the target probe pod or a service as namespace/name, globs are supported
+	To string `yaml:"to" json:"to"`
+	// Protocol the rule applies to: tcp, http or dns. Applies to all protocols when empty
+	Protocol string `yaml:"protocol,omitempty" json:"protocol,omitempty"`
+	// Allow is true if the connection is expected to succeed
+	Allow bool `yaml:"allow" json:"allow"`
+}
+
 type LDAPCheck struct {
 	Description   `yaml:",inline" json:",inline"`
+	Templatable   `yaml:",inline" json:",inline"`
 	Relatable     `yaml:",inline" json:",inline"`
 	Connection    `yaml:",inline" json:",inline"`
 	BindDN        string `yaml:"bindDN" json:"bindDN"`
 	UserSearch    string `yaml:"userSearch,omitempty" json:"userSearch,omitempty"`
 	SkipTLSVerify bool   `yaml:"skipTLSVerify,omitempty" json:"skipTLSVerify,omitempty"`
+	// StartTLS upgrades a plain ldap:// connection to TLS before binding
+	StartTLS bool `yaml:"startTLS,omitempty" json:"startTLS,omitempty"`
+	// Scope of the search, one of: base, one, sub (default)
+	Scope string `yaml:"scope,omitempty" json:"scope,omitempty"`
+	// Attributes to return for each entry, defaults to all attributes
+	Attributes []string `yaml:"attributes,omitempty" json:"attributes,omitempty"`
+	// PageSize enables a paged search with the given page size
+	PageSize uint32 `yaml:"pageSize,omitempty" json:"pageSize,omitempty"`
+	// UserBind binds as the entry returned by the search to test the user's credentials,
+	// the search must return exactly one entry
+	UserBind *LDAPUserBind `yaml:"userBind,omitempty" json:"userBind,omitempty"`
+}
+
+type LDAPUserBind struct {
+	// Password of the user returned by the search
+	Password types.EnvVar `yaml:"password" json:"password"`
 }
 
 func (c LDAPCheck) GetType() string {
@@ -609,6 +975,7 @@ func (c LDAPCheck) GetType() string {
 type NamespaceCheck struct {
 	Description          `yaml:",inline" json:",inline"`
 	Relatable            `yaml:",inline" json:",inline"`
+	KubernetesConnection `yaml:",inline" json:",inline"`
 	NamespaceNamePrefix  string            `yaml:"namespaceNamePrefix,omitempty" json:"namespaceNamePrefix,omitempty"`
 	NamespaceLabels      map[string]string `yaml:"namespaceLabels,omitempty" json:"namespaceLabels,omitempty"`
 	NamespaceAnnotations map[string]string `yaml:"namespaceAnnotations,omitempty" json:"namespaceAnnotations,omitempty"`
@@ -641,6 +1008,9 @@ func (c NamespaceCheck) GetType() string {
 	return "namespace"
 }
 
+// DNSQueryTypes are the record types supported by the dns check
+var DNSQueryTypes = []string{"A", "CNAME", "SRV", "MX", "PTR", "TXT", "NS"}
+
 type DNSCheck struct {
 	Description     `yaml:",inline" json:",inline"`
 	Relatable       `yaml:",inline" json:",inline"`
@@ -670,6 +1040,13 @@ func (c DNSCheck) GetType() string {
 	return "dns"
 }
 
+func (c DNSCheck) Validate(path *field.Path) field.ErrorList {
+	if c.QueryType != "" && !lo.Contains(DNSQueryTypes, strings.ToUpper(c.QueryType)) {
+		return field.ErrorList{field.NotSupported(path.Child("querytype"), c.QueryType, DNSQueryTypes)}
+	}
+	return nil
+}
+
 type HelmCheck struct {
 	Description `yaml:",inline" json:",inline"`
 	Relatable   `yaml:",inline" json:",inline"`
@@ -687,17 +1064,25 @@ func (c HelmCheck) GetType() string {
 	return "helm"
 }
 
+// JunitFormats are the test report formats supported by the junit check
+var JunitFormats = []string{"junit", "tap", "trx", "cucumber", "gotest"}
+
 type JunitCheck struct {
-	Description `yaml:",inline" json:",inline"`
-	TestResults string `yaml:"testResults" json:"testResults"`
-	Templatable `yaml:",inline" json:",inline"`
-	Relatable   `yaml:",inline" json:",inline"`
+	Description          `yaml:",inline" json:",inline"`
+	TestResults          string `yaml:"testResults,omitempty" json:"testResults,omitempty"`
+	Templatable          `yaml:",inline" json:",inline"`
+	Relatable            `yaml:",inline" json:",inline"`
+	KubernetesConnection `yaml:",inline" json:",inline"`
 	// Timeout in minutes to wait for specified container to finish its job. Defaults to 5 minutes
 	Timeout int `yaml:"timeout,omitempty" json:"timeout,omitempty"`
 	// +kubebuilder:validation:Schemaless
 	// +kubebuilder:pruning:PreserveUnknownFields
 	// +kubebuilder:validation:Type=object
-	Spec json.RawMessage `yaml:"spec" json:"spec"`
+	Spec json.RawMessage `yaml:"spec,omitempty" json:"spec,omitempty"`
+	// Source reads existing reports from a folder or object storage instead of running a pod
+	Source *JunitSource `yaml:"source,omitempty" json:"source,omitempty"`
+	// Formats of the reports to read in addition to JUnit XML, any of: tap, trx, cucumber or gotest
+	Formats []string `yaml:"formats,omitempty" json:"formats,omitempty"`
 	// Artifacts configure the artifacts generated by the check
 	Artifacts []Artifact `yaml:"artifacts,omitempty" json:"artifacts,omitempty"`
 }
@@ -717,6 +1102,25 @@ func (c JunitCheck) GetEndpoint() string {
 	return c.TestResults
 }
 
+type JunitSource struct {
+	// Path to the folder or object storage containing the reports, e.g. `s3://<bucket-name>/<prefix>`, `gcs://<bucket-name>/<prefix>`, `/path/to/reports`.
+	// Globs such as `/path/to/reports/**/*.xml` are supported
+	Path string `yaml:"path" json:"path"`
+	// Filter the reports to read, defaults to the file extensions of the enabled formats e.g. `.xml`
+	Filter FolderFilter `yaml:"filter,omitempty" json:"filter,omitempty"`
+	// Limit is the number of newest reports to read, defaults to 1
+	Limit                     int `yaml:"limit,omitempty" json:"limit,omitempty"`
+	*connection.S3Connection  `yaml:"awsConnection,omitempty" json:"awsConnection,omitempty"`
+	*connection.GCSConnection `yaml:"gcpConnection,omitempty" json:"gcpConnection,omitempty"`
+}
+
+func (s JunitSource) GetLimit() int {
+	if s.Limit > 0 {
+		return s.Limit
+	}
+	return 1
+}
+
 func (c JunitCheck) GetTimeout() int {
 	if c.Timeout != 0 {
 		return c.Timeout
@@ -728,6 +1132,16 @@ func (c JunitCheck) GetType() string {
 	return "junit"
 }
 
+func (c JunitCheck) Validate(path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	for i, format := range c.Formats {
+		if !lo.Contains(JunitFormats, format) {
+			errs = append(errs, field.NotSupported(path.Child("formats").Index(i), format, JunitFormats))
+		}
+	}
+	return errs
+}
+
 /*
 [include:datasources/prometheus.yaml]
 */
@@ -750,15 +1164,54 @@ func (c PrometheusCheck) GetType() string {
 	return "prometheus"
 }
 
+const MongoDBReplicaSetStatusQuery = "replicaSetStatus"
+
 type MongoDBCheck struct {
 	Description `yaml:",inline" json:",inline"`
+	Templatable `yaml:",inline" json:",inline"`
 	Connection  `yaml:",inline" json:",inline"`
+	// Database to run the query against
+	Database string `yaml:"database,omitempty" json:"database,omitempty"`
+	// Collection to run the find filter or aggregation pipeline against
+	Collection string `yaml:"collection,omitempty" json:"collection,omitempty"`
+	// Filter is an extended JSON find filter, e.g. {"status": "active"}
+	Filter string `yaml:"filter,omitempty" json:"filter,omitempty"`
+	// Pipeline is an extended JSON array of aggregation stages, mutually exclusive with filter
+	Pipeline string `yaml:"pipeline,omitempty" json:"pipeline,omitempty"`
+	// Query runs a built-in query instead of a filter or pipeline, one of: replicaSetStatus
+	Query string `yaml:"query,omitempty" json:"query,omitempty"`
+	// Limit the number of documents returned by a find filter
+	Limit int64 `yaml:"limit,omitempty" json:"limit,omitempty"`
+	// Number of documents to check for
+	Result int `yaml:"results,omitempty" json:"results,omitempty"`
+}
+
+// HasQuery returns true if the check runs a query in addition to pinging the server
+func (c MongoDBCheck) HasQuery() bool {
+	return c.Query != "" || c.Collection != "" || c.Database != "" || c.Filter != "" || c.Pipeline != ""
 }
 
 func (c MongoDBCheck) GetType() string {
 	return "mongodb"
 }
 
+func (c MongoDBCheck) Validate(path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if c.Query != "" && c.Query != MongoDBReplicaSetStatusQuery {
+		errs = append(errs, field.NotSupported(path.Child("query"), c.Query, []string{MongoDBReplicaSetStatusQuery}))
+	}
+	if c.Query == "" && c.Collection != "" && c.Database == "" {
+		errs = append(errs, field.Required(path.Child("database"), "database is required when querying a collection"))
+	}
+	if c.Query == "" && c.Collection == "" && c.HasQuery() {
+		errs = append(errs, field.Required(path.Child("collection"), "collection is required when database, filter or pipeline is set"))
+	}
+	if c.Filter != "" && c.Pipeline != "" {
+		errs = append(errs, field.Forbidden(path.Child("pipeline"), "filter and pipeline are mutually exclusive"))
+	}
+	return errs
+}
+
 // Git executes a SQL style query against a github repo using https://github.com/askgitdev/askgit
 type Git struct {
 	GitHubCheck `yaml:",inline" json:",inline"`
@@ -770,26 +1223,78 @@ type GitHubCheck struct {
 	Relatable      `yaml:",inline" json:",inline"`
 	ConnectionName string `yaml:"connection,omitempty" json:"connection,omitempty"`
 	// Query to be executed. Please see https://github.com/askgitdev/askgit for more details regarding syntax
-	Query       string       `yaml:"query" json:"query"`
+	Query       string       `yaml:"query,omitempty" json:"query,omitempty"`
 	GithubToken types.EnvVar `yaml:"githubToken,omitempty" json:"githubToken,omitempty"`
+	// Actions checks the latest GitHub Actions workflow runs using the GitHub API instead of running a query
+	Actions *CIPipelineCheck `yaml:"actions,omitempty" json:"actions,omitempty"`
+	// GitLab checks the latest GitLab pipelines using the GitLab API instead of running a query
+	GitLab *CIPipelineCheck `yaml:"gitlab,omitempty" json:"gitlab,omitempty"`
+	// GitLabToken is the personal or project access token used for GitLab
+	GitLabToken types.EnvVar `yaml:"gitlabToken,omitempty" json:"gitlabToken,omitempty"`
 }
 
 func (c GitHubCheck) GetType() string {
 	return "github"
 }
 
+func (c GitHubCheck) Validate(path *field.Path) field.ErrorList {
+	if c.Actions != nil && c.GitLab != nil {
+		return field.ErrorList{field.Forbidden(path.Child("gitlab"), "actions and gitlab are mutually exclusive")}
+	}
+	if c.Actions != nil && len(c.Actions.Variables) > 0 {
+		return field.ErrorList{field.Invalid(path.Child("actions", "variables"), c.Actions.Variables, "variables are only supported on gitlab")}
+	}
+	return nil
+}
+
 func (c GitHubCheck) GetEndpoint() string {
+	if c.Actions != nil {
+		return c.Actions.Project
+	}
+	if c.GitLab != nil {
+		return c.GitLab.Project
+	}
 	return strings.ReplaceAll(c.Query, " ", "-")
 }
 
+type CIPipelineCheck struct {
+	// URL of the API, defaults to https://api.github.com for GitHub and https://gitlab.com for GitLab
+	URL string `yaml:"url,omitempty" json:"url,omitempty"`
+	// Project is the `owner/repo` on GitHub, or the path or ID of the project on GitLab
+	Project string `yaml:"project" json:"project"`
+	// Pipeline is the name or path of the workflow on GitHub, or the name of the pipeline on GitLab.
+	// Regular expressions are supported, all pipelines are checked when empty
+	Pipeline string `yaml:"pipeline,omitempty" json:"pipeline,omitempty"`
+	// Branches to check the latest run of, defaults to every branch with a recent run
+	Branches []string `yaml:"branches,omitempty" json:"branches,omitempty"`
+	// Variables the pipeline must have been run with, only supported on GitLab
+	Variables map[string]string `yaml:"variables,omitempty" json:"variables,omitempty"`
+	// MaxDuration of the latest run
+	MaxDuration Duration `yaml:"maxDuration,omitempty" json:"maxDuration,omitempty"`
+	// MaxAge of the latest run, older runs are reported as stale
+	MaxAge Duration `yaml:"maxAge,omitempty" json:"maxAge,omitempty"`
+}
+
 type GitProtocolCheck struct {
 	Description `yaml:",inline" json:",inline"`
 	Templatable `yaml:",inline" json:",inline"`
 	Relatable   `yaml:",inline" json:",inline"`
-	FileName    string       `yaml:"filename,omitempty" json:"filename,omitempty"`
-	Repository  string       `yaml:"repository" json:"repository"`
-	Username    types.EnvVar `yaml:"username" json:"username"`
-	Password    types.EnvVar `yaml:"password" json:"password"`
+	FileName    string `yaml:"filename,omitempty" json:"filename,omitempty"`
+	// Repository to check, e.g. `https://github.com/org/repo.git`, `git@github.com:org/repo.git` or `file:///path/to/repo`
+	Repository string       `yaml:"repository" json:"repository"`
+	Username   types.EnvVar `yaml:"username,omitempty" json:"username,omitempty"`
+	// Password for HTTP basic auth, or the passphrase of the private key for SSH
+	Password types.EnvVar `yaml:"password,omitempty" json:"password,omitempty"`
+	// PrivateKey used to authenticate SSH repositories, the username defaults to git
+	PrivateKey types.EnvVar `yaml:"privateKey,omitempty" json:"privateKey,omitempty"`
+	// KnownHosts in the `known_hosts` format used to verify the SSH host key, defaults to ~/.ssh/known_hosts
+	KnownHosts types.EnvVar `yaml:"knownHosts,omitempty" json:"knownHosts,omitempty"`
+	// ReadOnly checks that the branch exists and fetches its last commit instead of pushing a change
+	ReadOnly bool `yaml:"readOnly,omitempty" json:"readOnly,omitempty"`
+	// Branch to check in readOnly mode, defaults to the default branch of the repository
+	Branch string `yaml:"branch,omitempty" json:"branch,omitempty"`
+	// MaxAge of the last commit on the branch in readOnly mode
+	MaxAge Duration `yaml:"maxAge,omitempty" json:"maxAge,omitempty"`
 }
 
 func (c GitProtocolCheck) GetType() string {
@@ -805,6 +1310,46 @@ type CatalogCheck struct {
 	Templatable `yaml:",inline" json:",inline"`
 	Relatable   `yaml:",inline" json:",inline"`
 	Selector    types.ResourceSelectors `yaml:"selector" json:"selector"`
+	// Changes fetches the recent changes of each config item into `changes`.
+	// The check is invalid if the selector matches more than 100 (checks.catalog.maxItems) config items when changes or relationships are fetched
+	Changes *CatalogChanges `yaml:"changes,omitempty" json:"changes,omitempty"`
+	// Relationships fetches the related config items of each config item into `relationships`
+	Relationships *CatalogRelationships `yaml:"relationships,omitempty" json:"relationships,omitempty"`
+	// Cluster limits the selected config items to those tagged with the cluster, and is added as a `cluster` label to results and metrics
+	Cluster string `yaml:"cluster,omitempty" json:"cluster,omitempty"`
+}
+
+type CatalogChanges struct {
+	// Types of changes to include e.g. diff or HealthChanged, prefix with ! to exclude a type
+	Types []string `yaml:"types,omitempty" json:"types,omitempty"`
+	// Severity is the minimum severity of the changes to include
+	Severity string `yaml:"severity,omitempty" json:"severity,omitempty"`
+	Source   string `yaml:"source,omitempty" json:"source,omitempty"`
+	Summary  string `yaml:"summary,omitempty" json:"summary,omitempty"`
+	// Since is how far back to look for changes, defaults to 2 days
+	Since Duration `yaml:"since,omitempty" json:"since,omitempty"`
+	// Recursive includes the changes of related config items: upstream, downstream, all or none (default)
+	Recursive string `yaml:"recursive,omitempty" json:"recursive,omitempty"`
+	// Depth of the related config items to include when recursive
+	Depth int `yaml:"depth,omitempty" json:"depth,omitempty"`
+	// Limit is the maximum number of changes per config item, defaults to 50
+	Limit int `yaml:"limit,omitempty" json:"limit,omitempty"`
+}
+
+type CatalogRelationships struct {
+	// Direction of the relationships: incoming, outgoing or all (default)
+	Direction string `yaml:"direction,omitempty" json:"direction,omitempty"`
+	// Type of relationships: hard, soft or both (default)
+	Type string `yaml:"type,omitempty" json:"type,omitempty"`
+	// Depth of the relationships to traverse, defaults to 5
+	Depth *int `yaml:"depth,omitempty" json:"depth,omitempty"`
+	// Types of the related config items to include e.g. Kubernetes::HorizontalPodAutoscaler
+	Types          []string `yaml:"types,omitempty" json:"types,omitempty"`
+	IncludeDeleted bool     `yaml:"includeDeleted,omitempty" json:"includeDeleted,omitempty"`
+}
+
+func (c CatalogCheck) GetCluster() string {
+	return c.Cluster
 }
 
 func (c CatalogCheck) GetType() string {
@@ -949,9 +1494,10 @@ func (t *KubernetesResourceCheckWaitFor) GetInterval() (time.Duration, error) {
 }
 
 type KubernetesResourceCheck struct {
-	Description `yaml:",inline" json:",inline"`
-	Templatable `yaml:",inline" json:",inline"`
-	Relatable   `yaml:",inline" json:",inline"`
+	Description          `yaml:",inline" json:",inline"`
+	Templatable          `yaml:",inline" json:",inline"`
+	Relatable            `yaml:",inline" json:",inline"`
+	KubernetesConnection `yaml:",inline" json:",inline"`
 	// StaticResources are kubernetes resources that are created & only
 	// cleared when the canary is deleted
 	// +kubebuilder:validation:Schemaless
@@ -974,8 +1520,10 @@ type KubernetesResourceCheck struct {
 	// Ensure that the resources are deleted before creating them.
 	ClearResources bool `json:"clearResources,omitempty"`
 
-	// Kubeconfig is the kubeconfig or the path to the kubeconfig file.
-	Kubeconfig *types.EnvVar `yaml:"kubeconfig,omitempty" json:"kubeconfig,omitempty"`
+	// DryRun submits the resources with a server-side dry-run instead of creating them.
+	// Admission denials, warnings and mutations of every resource are recorded in `dryRun`,
+	// along with the drift from the live resource if it already exists. WaitFor and Checks are skipped.
+	DryRun bool `json:"dryRun,omitempty"`
 
 	WaitFor KubernetesResourceCheckWaitFor `json:"waitFor,omitempty"`
 }
@@ -1077,6 +1625,27 @@ func (c KubernetesResourceCheck) GetEndpoint() string {
 	return c.Name
 }
 
+func (c KubernetesResourceCheck) Validate(path *field.Path) field.ErrorList {
+	if !c.DryRun {
+		return nil
+	}
+	var errs field.ErrorList
+	if len(c.Checks) > 0 {
+		errs = append(errs, field.Forbidden(path.Child("checks"), "checks cannot run against dry-run resources"))
+	}
+	for i, r := range c.StaticResources {
+		if r.GetName() == "" {
+			errs = append(errs, field.Required(path.Child("staticResources").Index(i).Child("metadata", "name"), "a name is required to dry-run a resource"))
+		}
+	}
+	for i, r := range c.Resources {
+		if r.GetName() == "" {
+			errs = append(errs, field.Required(path.Child("resources").Index(i).Child("metadata", "name"), "a name is required to dry-run a resource"))
+		}
+	}
+	return errs
+}
+
 type ResourceSelector struct {
 	Name          string `yaml:"name,omitempty" json:"name,omitempty"`
 	LabelSelector string `json:"labelSelector,omitempty" yaml:"labelSelector,omitempty"`
@@ -1084,13 +1653,12 @@ type ResourceSelector struct {
 }
 
 type KubernetesCheck struct {
-	Description `yaml:",inline" json:",inline"`
-	Templatable `yaml:",inline" json:",inline"`
-	Relatable   `yaml:",inline" json:",inline"`
-	Namespace   ResourceSelector `yaml:"namespaceSelector,omitempty" json:"namespaceSelector,omitempty"`
-	Resource    ResourceSelector `yaml:"resource,omitempty" json:"resource,omitempty"`
-	// KubeConfig is the kubeconfig or the path to the kubeconfig file.
-	KubeConfig *types.EnvVar `yaml:"kubeconfig,omitempty" json:"kubeconfig,omitempty"`
+	Description          `yaml:",inline" json:",inline"`
+	Templatable          `yaml:",inline" json:",inline"`
+	Relatable            `yaml:",inline" json:",inline"`
+	KubernetesConnection `yaml:",inline" json:",inline"`
+	Namespace            ResourceSelector `yaml:"namespaceSelector,omitempty" json:"namespaceSelector,omitempty"`
+	Resource             ResourceSelector `yaml:"resource,omitempty" json:"resource,omitempty"`
 	// Ignore the specified resources from the fetched resources. Can be a glob pattern.
 	Ignore []string `yaml:"ignore,omitempty" json:"ignore,omitempty"`
 	Kind   string   `yaml:"kind" json:"kind"`
@@ -1100,6 +1668,14 @@ type KubernetesCheck struct {
 
 	// Fail the check if any resources are not ready
 	Ready bool `yaml:"ready,omitempty" json:"ready,omitempty"`
+
+	// PerResource creates a check for every matched resource, with the recent warning events of unhealthy resources.
+	// Checks of resources that no longer exist are removed according to the transformDeleteStrategy
+	PerResource bool `yaml:"perResource,omitempty" json:"perResource,omitempty"`
+
+	// Watch the resources instead of listing them on every run. Watches are shared by checks with the same
+	// kind and selectors, and record the health transitions between runs in `transitions`
+	Watch bool `yaml:"watch,omitempty" json:"watch,omitempty"`
 }
 
 func (c KubernetesCheck) GetType() string {
@@ -1149,6 +1725,8 @@ type FolderCheck struct {
 	*connection.GCSConnection  `yaml:"gcpConnection,omitempty" json:"gcpConnection,omitempty"`
 	*connection.SMBConnection  `yaml:"smbConnection,omitempty" json:"smbConnection,omitempty"`
 	*connection.SFTPConnection `yaml:"sftpConnection,omitempty" json:"sftpConnection,omitempty"`
+	// Content reads and parses the newest files, making them available as `results.content`
+	Content *FolderContent `yaml:"content,omitempty" json:"content,omitempty"`
 }
 
 func (c FolderCheck) GetType() string {
@@ -1206,6 +1784,60 @@ type ExecCheck struct {
 	Checkout *connection.GitConnection `yaml:"checkout,omitempty" json:"checkout,omitempty"`
 	// Artifacts configure the artifacts generated by the check
 	Artifacts []shell.Artifact `yaml:"artifacts,omitempty" json:"artifacts,omitempty"`
+	// Mode is where the script is run, either `local` (default) on the canary-checker host or in an ephemeral `pod`
+	// +kubebuilder:validation:Enum=local;pod
+	Mode string `yaml:"mode,omitempty" json:"mode,omitempty"`
+	// Limits restrict the resources and privileges available to the script.
+	// Locally, cpu and memory require a writable cgroup v2 hierarchy, runAsUser requires root and setpriv,
+	// and noNetwork and readOnlyRoot require bwrap, limits that cannot be enforced are skipped with a warning
+	Limits *ExecLimits `yaml:"limits,omitempty" json:"limits,omitempty"`
+	// Pod configures the pod the script runs in when the mode is `pod`
+	Pod *ExecPod `yaml:"pod,omitempty" json:"pod,omitempty"`
+}
+
+const (
+	ExecModeLocal = "local"
+	ExecModePod   = "pod"
+)
+
+func (c ExecCheck) GetMode() string {
+	if c.Mode == "" {
+		return ExecModeLocal
+	}
+	return c.Mode
+}
+
+type ExecLimits struct {
+	// CPU limit e.g. `500m`, enforced locally with a cgroup v2 cpu.max
+	CPU string `yaml:"cpu,omitempty" json:"cpu,omitempty"`
+	// Memory limit e.g. `256Mi`, enforced locally with a cgroup v2 memory.max
+	Memory string `yaml:"memory,omitempty" json:"memory,omitempty"`
+	// Timeout is the maximum wall-clock time the script can run for before it is killed
+	Timeout Duration `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+	// NoNetwork runs the script in a network namespace without any interfaces, only supported locally
+	NoNetwork bool `yaml:"noNetwork,omitempty" json:"noNetwork,omitempty"`
+	// ReadOnlyRoot mounts the root filesystem read-only with a writable scratch dir at /tmp
+	ReadOnlyRoot bool `yaml:"readOnlyRoot,omitempty" json:"readOnlyRoot,omitempty"`
+	// RunAsUser is the uid the script runs as with all capabilities dropped, defaults to 65534 (nobody) when readOnlyRoot is set
+	RunAsUser *int64 `yaml:"runAsUser,omitempty" json:"runAsUser,omitempty"`
+}
+
+func (l ExecLimits) GetRunAsUser() *int64 {
+	if l.RunAsUser == nil && l.ReadOnlyRoot {
+		nobody := int64(65534)
+		return &nobody
+	}
+	return l.RunAsUser
+}
+
+type ExecPod struct {
+	// Image to run the script in, defaults to ubuntu
+	Image string `yaml:"image,omitempty" json:"image,omitempty"`
+	// ServiceAccount the pod runs as, it must be allowed by the checks.exec.pod.serviceAccounts property of the operator.
+	// The pod is always created in the namespace of the canary
+	ServiceAccount string `yaml:"serviceAccount,omitempty" json:"serviceAccount,omitempty"`
+	// NodeSelector to schedule the pod with
+	NodeSelector map[string]string `yaml:"nodeSelector,omitempty" json:"nodeSelector,omitempty"`
 }
 
 func (c ExecCheck) GetType() string {
@@ -1265,8 +1897,9 @@ type DatabaseBackupCheck struct {
 	Description `yaml:",inline" json:",inline"`
 	Templatable `yaml:",inline" json:",inline"`
 	Relatable   `yaml:",inline" json:",inline"`
-	GCP         *GCPDatabase `yaml:"gcp,omitempty" json:"gcp,omitempty"`
-	MaxAge      Duration     `yaml:"maxAge,omitempty" json:"maxAge,omitempty"`
+	GCP         *GCPDatabase      `yaml:"gcp,omitempty" json:"gcp,omitempty"`
+	Postgres    *PostgresDatabase `yaml:"postgres,omitempty" json:"postgres,omitempty"`
+	MaxAge      Duration          `yaml:"maxAge,omitempty" json:"maxAge,omitempty"`
 }
 
 type GCPDatabase struct {
@@ -1275,6 +1908,25 @@ type GCPDatabase struct {
 	*connection.GCPConnection `yaml:"gcpConnection,omitempty" json:"gcpConnection,omitempty"`
 }
 
+// PostgresDatabase checks WAL archiving, replication and base backups of a self-managed postgres server
+type PostgresDatabase struct {
+	Connection `yaml:",inline" json:",inline"`
+	// MaxReplicationLag is the maximum replay lag of a standby, defaults to maxAge
+	MaxReplicationLag Duration `yaml:"maxReplicationLag,omitempty" json:"maxReplicationLag,omitempty"`
+	// MaxSlotLag is the maximum amount of WAL retained by a replication slot e.g. 1GB
+	MaxSlotLag Size `yaml:"maxSlotLag,omitempty" json:"maxSlotLag,omitempty"`
+	// BaseBackup is the location of base backups, the newest of which must be younger than maxAge
+	BaseBackup *PostgresBaseBackup `yaml:"baseBackup,omitempty" json:"baseBackup,omitempty"`
+}
+
+type PostgresBaseBackup struct {
+	// Path to the folder containing base backups e.g. `/backups`, `s3://<bucket-name>/<prefix>`
+	Path                     string       `yaml:"path" json:"path"`
+	Recursive                bool         `yaml:"recursive,omitempty" json:"recursive,omitempty"`
+	Filter                   FolderFilter `yaml:"filter,omitempty" json:"filter,omitempty"`
+	*connection.S3Connection `yaml:"awsConnection,omitempty" json:"awsConnection,omitempty"`
+}
+
 func (c DatabaseBackupCheck) GetType() string {
 	return "databasebackupcheck"
 }
@@ -1341,7 +1993,10 @@ type Pod struct {
 The LDAP check will:
 
 * bind using provided user/password to the ldap host. Supports ldap/ldaps protocols.
-* search an object type in the provided bind DN.s
+* search an object type in the provided bind DN.
+* optionally bind as the user returned by the search to test its credentials.
+
+The entries returned by the search are available to test, display and transform templates as `results.entries`.
 
 [include:datasources/ldap_pass.yaml]
 */
@@ -1360,6 +2015,16 @@ type Namespace struct {
 	NamespaceCheck `yaml:",inline" json:"inline"`
 }
 
+/*
+The NetworkMatrix check launches probe pods in each namespace and tests the TCP, HTTP and DNS reachability
+between them and to services, comparing it to the expected allow/deny matrix of the NetworkPolicies
+
+[include:k8s/network_matrix_pass.yaml]
+*/
+type NetworkMatrix struct {
+	NetworkMatrixCheck `yaml:",inline" json:",inline"`
+}
+
 /*
 This test will check ICMP packet loss and duration.
 
@@ -1387,6 +2052,33 @@ type MsSQL struct {
 	MssqlCheck `yaml:",inline" json:"inline"`
 }
 
+/*
+This check will try to open a SQLite database file, run a query against it and verify the results.
+
+[include:datasources/sqlite_pass.yaml]
+*/
+type SQLite struct {
+	SQLiteCheck `yaml:",inline" json:"inline"`
+}
+
+/*
+This check will try to connect to a specified ClickHouse database, run a query against it and verify the results.
+
+[include:datasources/clickhouse_pass.yaml]
+*/
+type Clickhouse struct {
+	ClickhouseCheck `yaml:",inline" json:"inline"`
+}
+
+/*
+This check will try to connect to a specified Oracle database, run a query against it and verify the results.
+
+[include:datasources/oracle_pass.yaml]
+*/
+type Oracle struct {
+	OracleCheck `yaml:",inline" json:"inline"`
+}
+
 /*
 [include:datasources/helm_pass.yaml]
 */
@@ -1403,6 +2095,7 @@ type SrvReply struct {
 
 /*
 This check will try to connect to a specified Redis instance, run a ping against it and verify the pong response.
+Sentinel and cluster deployments are supported, in which case every replica or shard is checked and its role and replication lag reported.
 
 [include:datasources/redis_pass.yaml]
 
@@ -1424,6 +2117,33 @@ type Restic struct {
 	ResticCheck `yaml:",inline" json:"inline"`
 }
 
+/*
+Kopia check connects to a kopia repository and checks the freshness, count and size of its snapshots
+
+[include:backup/kopia_pass.yaml]
+*/
+type Kopia struct {
+	KopiaCheck `yaml:",inline" json:",inline"`
+}
+
+/*
+Borg check lists the archives in a borg repository and checks their freshness, count and size
+
+[include:backup/borg_pass.yaml]
+*/
+type Borg struct {
+	BorgCheck `yaml:",inline" json:",inline"`
+}
+
+/*
+Velero check reads velero Backup and Schedule resources and fails on stale or partially failed backups
+
+[include:backup/velero_pass.yaml]
+*/
+type Velero struct {
+	VeleroCheck `yaml:",inline" json:",inline"`
+}
+
 /*
 Jmeter check will run jmeter cli against the supplied host
 [include:k8s/jmeter_pass.yaml]
@@ -1433,9 +2153,22 @@ type Jmeter struct {
 }
 
 /*
-Junit check will wait for the given pod to be completed than parses all the xml files present in the defined testResults directory
+K6 check runs a k6 script and fails when any of the k6 thresholds fail
+[include:external/k6.yaml]
+*/
+type K6 struct {
+	K6Check `yaml:",inline" json:",inline"`
+}
+
+/*
+Junit check will wait for the given pod to be completed than parses all the test reports present in the defined testResults directory.
+JUnit XML, TAP, TRX, Cucumber JSON and `go test -json` reports are supported
 
 [include:k8s/junit_pass.yaml]
+
+Alternatively the newest reports can be read from a folder, S3 or GCS bucket using `source`
+
+[include:datasources/junit_s3_pass.yaml]
 */
 type Junit struct {
 	JunitCheck `yaml:",inline" json:",inline"`
@@ -1479,10 +2212,12 @@ The folder check lists files in a folder (local or SMB/CIFS) or object storage p
 * `maxSize` -
 * `minCount` -
 * `maxCount` -
+* `content` - The newest files are read and parsed as JSON, YAML, CSV or lines, and checked against a sidecar `.sha256` or `.md5` checksum
 
 [include:quarantine/smb_pass.yaml]
 [include:datasources/s3_bucket_pass.yaml]
 [include:datasources/folder_pass.yaml]
+[include:datasources/folder_content_pass.yaml]
 */
 type Folder struct {
 	FolderCheck `yaml:",inline" json:",inline"`
@@ -1492,6 +2227,9 @@ type Folder struct {
 Exec Check executes a command or scrtipt file on the target host.
 On Linux/MacOS uses bash and on Windows uses powershell.
 [include:minimal/exec_pass.yaml]
+
+Scripts can be run with cgroup limits, a timeout and without network access locally, or in an ephemeral pod.
+[include:minimal/exec_limits_pass.yaml]
 */
 type Exec struct {
 	ExecCheck `yaml:",inline" json:",inline"`
@@ -1572,8 +2310,10 @@ var AllChecks = []external.Check{
 	AwsConfigCheck{},
 	AwsConfigRuleCheck{},
 	AzureDevopsCheck{},
+	BorgCheck{},
 	CloudWatchCheck{},
 	CatalogCheck{},
+	ClickhouseCheck{},
 	ContainerdPullCheck{},
 	ContainerdPushCheck{},
 	DatabaseBackupCheck{},
@@ -1591,19 +2331,25 @@ var AllChecks = []external.Check{
 	ICMPCheck{},
 	JmeterCheck{},
 	JunitCheck{},
+	K6Check{},
+	KopiaCheck{},
 	Kubernetes{},
 	LDAPCheck{},
 	MongoDBCheck{},
 	MssqlCheck{},
 	MysqlCheck{},
 	NamespaceCheck{},
+	NetworkMatrixCheck{},
 	OpenSearchCheck{},
+	OracleCheck{},
 	PodCheck{},
 	PostgresCheck{},
 	PrometheusCheck{},
 	RedisCheck{},
 	ResticCheck{},
 	S3Check{},
+	SQLiteCheck{},
 	TCPCheck{},
+	VeleroCheck{},
 	WebhookCheck{},
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/fs"
 	"net/url"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
@@ -16,6 +17,7 @@ import (
 	"github.com/flanksource/duty/types"
 	"github.com/flanksource/gomplate/v3"
 	"github.com/timberio/go-datemath"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 type Duration string
@@ -34,6 +36,17 @@ func (d Duration) GetDuration() (*time.Duration, error) {
 	return &_d, err
 }
 
+// Validate returns an error if the duration is set and cannot be parsed
+func (d Duration) Validate(path *field.Path) field.ErrorList {
+	if d == "" {
+		return nil
+	}
+	if _, err := time.ParseDuration(string(d)); err != nil {
+		return field.ErrorList{field.Invalid(path, string(d), err.Error())}
+	}
+	return nil
+}
+
 type Size string
 
 func (s Size) String() string {
@@ -202,6 +215,59 @@ type FolderTest struct {
 	TotalSize Size `yaml:"totalSize,omitempty" json:"totalSize,omitempty"`
 }
 
+const (
+	FolderContentJSON  = "json"
+	FolderContentYAML  = "yaml"
+	FolderContentCSV   = "csv"
+	FolderContentLines = "lines"
+)
+
+type FolderContent struct {
+	// Limit is the number of newest files to read, defaults to 1
+	Limit int `yaml:"limit,omitempty" json:"limit,omitempty"`
+	// MaxSize is the largest file that will be read, defaults to 10MB
+	MaxSize Size `yaml:"maxSize,omitempty" json:"maxSize,omitempty"`
+	// Format the files are parsed as, detected from the file extension when empty
+	// +kubebuilder:validation:Enum=json;yaml;csv;lines
+	Format string `yaml:"format,omitempty" json:"format,omitempty"`
+	// VerifyChecksum compares the checksum of each file with a sidecar file of the same name ending in `.sha256` or `.md5`
+	VerifyChecksum bool `yaml:"verifyChecksum,omitempty" json:"verifyChecksum,omitempty"`
+}
+
+func (c FolderContent) GetLimit() int {
+	if c.Limit <= 0 {
+		return 1
+	}
+	return c.Limit
+}
+
+func (c FolderContent) GetMaxSize() (int64, error) {
+	if c.MaxSize == "" {
+		return 10 * 1024 * 1024, nil
+	}
+	size, err := c.MaxSize.Value()
+	if err != nil {
+		return 0, fmt.Errorf("invalid maxSize %s: %w", c.MaxSize, err)
+	}
+	return *size, nil
+}
+
+// GetFormat returns the format of a file, using the extension if no format is specified
+func (c FolderContent) GetFormat(name string) string {
+	if c.Format != "" {
+		return c.Format
+	}
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json":
+		return FolderContentJSON
+	case ".yaml", ".yml":
+		return FolderContentYAML
+	case ".csv":
+		return FolderContentCSV
+	}
+	return FolderContentLines
+}
+
 func (f FolderTest) GetMinAge() (*time.Duration, error) {
 	if f.MinAge == "" {
 		return nil, nil
@@ -412,6 +478,29 @@ func (d Description) GetTransformDeleteStrategy() string {
 	return d.TransformDeleteStrategy
 }
 
+// ClusterLabel is added to the results and metrics of checks that target a cluster
+const ClusterLabel = "cluster"
+
+// KubernetesConnection targets the cluster of a kubeconfig instead of the cluster canary-checker runs in
+type KubernetesConnection struct {
+	// Cluster is the name of the target cluster, added as a `cluster` label to results and metrics
+	Cluster string `yaml:"cluster,omitempty" json:"cluster,omitempty"`
+	// Kubeconfig is the kubeconfig or the path to the kubeconfig file.
+	Kubeconfig *types.EnvVar `yaml:"kubeconfig,omitempty" json:"kubeconfig,omitempty"`
+}
+
+func (c KubernetesConnection) GetCluster() string {
+	return c.Cluster
+}
+
+// GetCluster returns the name of the cluster targeted by the check, or an empty string
+func GetCluster(check external.Check) string {
+	if c, ok := check.(interface{ GetCluster() string }); ok {
+		return c.GetCluster()
+	}
+	return ""
+}
+
 type Connection struct {
 	// Connection name e.g. connection://http/google
 	Connection string `yaml:"connection,omitempty" json:"connection,omitempty"`
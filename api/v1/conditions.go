@@ -40,3 +40,66 @@ type Condition struct {
 	// +required
 	Message string `json:"message" protobuf:"bytes,6,opt,name=message"`
 }
+
+// Condition types of canaries and topologies
+const (
+	// ConditionReady is true when the resource has been persisted and its checks are scheduled
+	ConditionReady = "Ready"
+	// ConditionHealthy is true when all the checks of the last run passed
+	ConditionHealthy = "Healthy"
+	// ConditionDegraded is true when some, but not all, of the checks of the last run failed
+	ConditionDegraded = "Degraded"
+	// ConditionSuspended is true when the canary is not scheduled because it has 0 replicas or is annotated with suspend
+	ConditionSuspended = "Suspended"
+	// ConditionInvalid is true when the spec or one of the checks is invalid
+	ConditionInvalid = "Invalid"
+)
+
+// NewCondition returns a condition of the given type that is True when status is true
+func NewCondition(conditionType string, status bool, reason, message string) Condition {
+	condition := Condition{Type: conditionType, Status: ConditionStatusFalse, Reason: reason, Message: message}
+	if status {
+		condition.Status = ConditionStatusTrue
+	}
+	return condition
+}
+
+// FindCondition returns the condition of the given type or nil if it is not set
+func FindCondition(conditions []Condition, conditionType string) *Condition {
+	for i := range conditions {
+		if conditions[i].Type == conditionType {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
+
+// SetCondition adds the condition or updates the existing condition of the same type,
+// the last transition time is only changed when the status changes
+func SetCondition(conditions *[]Condition, condition Condition) {
+	existing := FindCondition(*conditions, condition.Type)
+	if existing == nil {
+		if condition.LastTransitionTime.IsZero() {
+			condition.LastTransitionTime = metav1.Now()
+		}
+		*conditions = append(*conditions, condition)
+		return
+	}
+
+	if existing.Status != condition.Status {
+		existing.Status = condition.Status
+		existing.LastTransitionTime = condition.LastTransitionTime
+		if existing.LastTransitionTime.IsZero() {
+			existing.LastTransitionTime = metav1.Now()
+		}
+	}
+	existing.Reason = condition.Reason
+	existing.Message = condition.Message
+	existing.ObservedGeneration = condition.ObservedGeneration
+}
+
+// IsConditionTrue returns true when the condition of the given type is set and True
+func IsConditionTrue(conditions []Condition, conditionType string) bool {
+	condition := FindCondition(conditions, conditionType)
+	return condition != nil && condition.Status == ConditionStatusTrue
+}
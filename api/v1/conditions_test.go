@@ -0,0 +1,31 @@
+package v1
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSetCondition(t *testing.T) {
+	var conditions []Condition
+	SetCondition(&conditions, NewCondition(ConditionHealthy, true, "ChecksPassed", ""))
+	transitioned := FindCondition(conditions, ConditionHealthy).LastTransitionTime
+	if transitioned.IsZero() {
+		t.Fatal("expected the transition time to be set")
+	}
+
+	// the transition time is kept when only the reason changes
+	condition := NewCondition(ConditionHealthy, true, "Other", "")
+	condition.LastTransitionTime = metav1.NewTime(time.Now().Add(time.Hour))
+	SetCondition(&conditions, condition)
+	if len(conditions) != 1 || conditions[0].Reason != "Other" || !conditions[0].LastTransitionTime.Equal(&transitioned) {
+		t.Errorf("unexpected conditions: %+v", conditions)
+	}
+
+	SetCondition(&conditions, condition)
+	SetCondition(&conditions, NewCondition(ConditionHealthy, false, "ChecksFailed", ""))
+	if IsConditionTrue(conditions, ConditionHealthy) || conditions[0].LastTransitionTime.Equal(&transitioned) {
+		t.Errorf("expected the condition to transition: %+v", conditions)
+	}
+}
@@ -13,6 +13,11 @@ import (
 
 // +kubebuilder:object:root=true
 
+// +kubebuilder:printcolumn:name="Schedule",type=string,JSONPath=`.spec.schedule`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Invalid",type=string,priority=1,JSONPath=`.status.conditions[?(@.type=="Invalid")].status`
+// +kubebuilder:printcolumn:name="Reason",type=string,priority=1,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 // +kubebuilder:subresource:status
 type Topology struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -88,6 +93,11 @@ type TopologyStatus struct {
 	// +optional
 	ObservedGeneration int64  `json:"observedGeneration,omitempty" protobuf:"varint,3,opt,name=observedGeneration"`
 	Status             string `json:"status,omitempty"`
+	// Conditions of the topology: Ready and Invalid
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []Condition `json:"conditions,omitempty"`
 }
 
 func (t Topology) GetPersistedID() string {
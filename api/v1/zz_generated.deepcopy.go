@@ -284,6 +284,58 @@ func (in *AzureDevopsCheck) DeepCopy() *AzureDevopsCheck {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupRepository) DeepCopyInto(out *BackupRepository) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupRepository.
+func (in *BackupRepository) DeepCopy() *BackupRepository {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupRepository)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Borg) DeepCopyInto(out *Borg) {
+	*out = *in
+	in.BorgCheck.DeepCopyInto(&out.BorgCheck)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Borg.
+func (in *Borg) DeepCopy() *Borg {
+	if in == nil {
+		return nil
+	}
+	out := new(Borg)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BorgCheck) DeepCopyInto(out *BorgCheck) {
+	*out = *in
+	in.Description.DeepCopyInto(&out.Description)
+	out.Templatable = in.Templatable
+	in.Relatable.DeepCopyInto(&out.Relatable)
+	out.BackupRepository = in.BackupRepository
+	in.Passphrase.DeepCopyInto(&out.Passphrase)
+	in.SSHKey.DeepCopyInto(&out.SSHKey)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BorgCheck.
+func (in *BorgCheck) DeepCopy() *BorgCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(BorgCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Bucket) DeepCopyInto(out *Bucket) {
 	*out = *in
@@ -299,6 +351,33 @@ func (in *Bucket) DeepCopy() *Bucket {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CIPipelineCheck) DeepCopyInto(out *CIPipelineCheck) {
+	*out = *in
+	if in.Branches != nil {
+		in, out := &in.Branches, &out.Branches
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Variables != nil {
+		in, out := &in.Variables, &out.Variables
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CIPipelineCheck.
+func (in *CIPipelineCheck) DeepCopy() *CIPipelineCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(CIPipelineCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Canary) DeepCopyInto(out *Canary) {
 	*out = *in
@@ -471,6 +550,27 @@ func (in *CanarySpec) DeepCopyInto(out *CanarySpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.SQLite != nil {
+		in, out := &in.SQLite, &out.SQLite
+		*out = make([]SQLiteCheck, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Clickhouse != nil {
+		in, out := &in.Clickhouse, &out.Clickhouse
+		*out = make([]ClickhouseCheck, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Oracle != nil {
+		in, out := &in.Oracle, &out.Oracle
+		*out = make([]OracleCheck, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Restic != nil {
 		in, out := &in.Restic, &out.Restic
 		*out = make([]ResticCheck, len(*in))
@@ -478,6 +578,27 @@ func (in *CanarySpec) DeepCopyInto(out *CanarySpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Kopia != nil {
+		in, out := &in.Kopia, &out.Kopia
+		*out = make([]KopiaCheck, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Borg != nil {
+		in, out := &in.Borg, &out.Borg
+		*out = make([]BorgCheck, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Velero != nil {
+		in, out := &in.Velero, &out.Velero
+		*out = make([]VeleroCheck, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Jmeter != nil {
 		in, out := &in.Jmeter, &out.Jmeter
 		*out = make([]JmeterCheck, len(*in))
@@ -492,6 +613,13 @@ func (in *CanarySpec) DeepCopyInto(out *CanarySpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.K6 != nil {
+		in, out := &in.K6, &out.K6
+		*out = make([]K6Check, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Helm != nil {
 		in, out := &in.Helm, &out.Helm
 		*out = make([]HelmCheck, len(*in))
@@ -506,6 +634,13 @@ func (in *CanarySpec) DeepCopyInto(out *CanarySpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.NetworkMatrix != nil {
+		in, out := &in.NetworkMatrix, &out.NetworkMatrix
+		*out = make([]NetworkMatrixCheck, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Redis != nil {
 		in, out := &in.Redis, &out.Redis
 		*out = make([]RedisCheck, len(*in))
@@ -644,6 +779,18 @@ func (in *CanarySpec) DeepCopyInto(out *CanarySpec) {
 		*out = new(WebhookCheck)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TemplateRef != nil {
+		in, out := &in.TemplateRef, &out.TemplateRef
+		*out = new(CanaryTemplateRef)
+		**out = **in
+	}
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanarySpec.
@@ -710,6 +857,13 @@ func (in *CanaryStatus) DeepCopyInto(out *CanaryStatus) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryStatus.
@@ -722,6 +876,139 @@ func (in *CanaryStatus) DeepCopy() *CanaryStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryTemplate) DeepCopyInto(out *CanaryTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryTemplate.
+func (in *CanaryTemplate) DeepCopy() *CanaryTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CanaryTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryTemplateList) DeepCopyInto(out *CanaryTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CanaryTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryTemplateList.
+func (in *CanaryTemplateList) DeepCopy() *CanaryTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CanaryTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryTemplateParameter) DeepCopyInto(out *CanaryTemplateParameter) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryTemplateParameter.
+func (in *CanaryTemplateParameter) DeepCopy() *CanaryTemplateParameter {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryTemplateParameter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryTemplateRef) DeepCopyInto(out *CanaryTemplateRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryTemplateRef.
+func (in *CanaryTemplateRef) DeepCopy() *CanaryTemplateRef {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryTemplateRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryTemplateSpec) DeepCopyInto(out *CanaryTemplateSpec) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make([]CanaryTemplateParameter, len(*in))
+		copy(*out, *in)
+	}
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		*out = make(json.RawMessage, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryTemplateSpec.
+func (in *CanaryTemplateSpec) DeepCopy() *CanaryTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CatalogChanges) DeepCopyInto(out *CatalogChanges) {
+	*out = *in
+	if in.Types != nil {
+		in, out := &in.Types, &out.Types
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CatalogChanges.
+func (in *CatalogChanges) DeepCopy() *CatalogChanges {
+	if in == nil {
+		return nil
+	}
+	out := new(CatalogChanges)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CatalogCheck) DeepCopyInto(out *CatalogCheck) {
 	*out = *in
@@ -735,6 +1022,16 @@ func (in *CatalogCheck) DeepCopyInto(out *CatalogCheck) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Changes != nil {
+		in, out := &in.Changes, &out.Changes
+		*out = new(CatalogChanges)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Relationships != nil {
+		in, out := &in.Relationships, &out.Relationships
+		*out = new(CatalogRelationships)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CatalogCheck.
@@ -747,6 +1044,31 @@ func (in *CatalogCheck) DeepCopy() *CatalogCheck {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CatalogRelationships) DeepCopyInto(out *CatalogRelationships) {
+	*out = *in
+	if in.Depth != nil {
+		in, out := &in.Depth, &out.Depth
+		*out = new(int)
+		**out = **in
+	}
+	if in.Types != nil {
+		in, out := &in.Types, &out.Types
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CatalogRelationships.
+func (in *CatalogRelationships) DeepCopy() *CatalogRelationships {
+	if in == nil {
+		return nil
+	}
+	out := new(CatalogRelationships)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Check) DeepCopyInto(out *Check) {
 	*out = *in
@@ -831,6 +1153,38 @@ func (in *CheckStatus) DeepCopy() *CheckStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Clickhouse) DeepCopyInto(out *Clickhouse) {
+	*out = *in
+	in.ClickhouseCheck.DeepCopyInto(&out.ClickhouseCheck)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Clickhouse.
+func (in *Clickhouse) DeepCopy() *Clickhouse {
+	if in == nil {
+		return nil
+	}
+	out := new(Clickhouse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClickhouseCheck) DeepCopyInto(out *ClickhouseCheck) {
+	*out = *in
+	in.SQLCheck.DeepCopyInto(&out.SQLCheck)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClickhouseCheck.
+func (in *ClickhouseCheck) DeepCopy() *ClickhouseCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(ClickhouseCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CloudWatch) DeepCopyInto(out *CloudWatch) {
 	*out = *in
@@ -1412,6 +1766,11 @@ func (in *DatabaseBackupCheck) DeepCopyInto(out *DatabaseBackupCheck) {
 		*out = new(GCPDatabase)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Postgres != nil {
+		in, out := &in.Postgres, &out.Postgres
+		*out = new(PostgresDatabase)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseBackupCheck.
@@ -1644,11 +2003,21 @@ func (in *ExecCheck) DeepCopyInto(out *ExecCheck) {
 		*out = make([]shell.Artifact, len(*in))
 		copy(*out, *in)
 	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecCheck.
-func (in *ExecCheck) DeepCopy() *ExecCheck {
-	if in == nil {
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = new(ExecLimits)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Pod != nil {
+		in, out := &in.Pod, &out.Pod
+		*out = new(ExecPod)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecCheck.
+func (in *ExecCheck) DeepCopy() *ExecCheck {
+	if in == nil {
 		return nil
 	}
 	out := new(ExecCheck)
@@ -1686,6 +2055,48 @@ func (in *ExecConnections) DeepCopy() *ExecConnections {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecLimits) DeepCopyInto(out *ExecLimits) {
+	*out = *in
+	if in.RunAsUser != nil {
+		in, out := &in.RunAsUser, &out.RunAsUser
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecLimits.
+func (in *ExecLimits) DeepCopy() *ExecLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecPod) DeepCopyInto(out *ExecPod) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecPod.
+func (in *ExecPod) DeepCopy() *ExecPod {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecPod)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Folder) DeepCopyInto(out *Folder) {
 	*out = *in
@@ -1730,6 +2141,11 @@ func (in *FolderCheck) DeepCopyInto(out *FolderCheck) {
 		*out = new(connection.SFTPConnection)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Content != nil {
+		in, out := &in.Content, &out.Content
+		*out = new(FolderContent)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FolderCheck.
@@ -1742,6 +2158,21 @@ func (in *FolderCheck) DeepCopy() *FolderCheck {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FolderContent) DeepCopyInto(out *FolderContent) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FolderContent.
+func (in *FolderContent) DeepCopy() *FolderContent {
+	if in == nil {
+		return nil
+	}
+	out := new(FolderContent)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FolderFilter) DeepCopyInto(out *FolderFilter) {
 	*out = *in
@@ -1898,6 +2329,17 @@ func (in *GitHubCheck) DeepCopyInto(out *GitHubCheck) {
 	out.Templatable = in.Templatable
 	in.Relatable.DeepCopyInto(&out.Relatable)
 	in.GithubToken.DeepCopyInto(&out.GithubToken)
+	if in.Actions != nil {
+		in, out := &in.Actions, &out.Actions
+		*out = new(CIPipelineCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GitLab != nil {
+		in, out := &in.GitLab, &out.GitLab
+		*out = new(CIPipelineCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	in.GitLabToken.DeepCopyInto(&out.GitLabToken)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitHubCheck.
@@ -1918,6 +2360,8 @@ func (in *GitProtocolCheck) DeepCopyInto(out *GitProtocolCheck) {
 	in.Relatable.DeepCopyInto(&out.Relatable)
 	in.Username.DeepCopyInto(&out.Username)
 	in.Password.DeepCopyInto(&out.Password)
+	in.PrivateKey.DeepCopyInto(&out.PrivateKey)
+	in.KnownHosts.DeepCopyInto(&out.KnownHosts)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitProtocolCheck.
@@ -2151,11 +2595,22 @@ func (in *JunitCheck) DeepCopyInto(out *JunitCheck) {
 	in.Description.DeepCopyInto(&out.Description)
 	out.Templatable = in.Templatable
 	in.Relatable.DeepCopyInto(&out.Relatable)
+	in.KubernetesConnection.DeepCopyInto(&out.KubernetesConnection)
 	if in.Spec != nil {
 		in, out := &in.Spec, &out.Spec
 		*out = make(json.RawMessage, len(*in))
 		copy(*out, *in)
 	}
+	if in.Source != nil {
+		in, out := &in.Source, &out.Source
+		*out = new(JunitSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Formats != nil {
+		in, out := &in.Formats, &out.Formats
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Artifacts != nil {
 		in, out := &in.Artifacts, &out.Artifacts
 		*out = make([]Artifact, len(*in))
@@ -2173,6 +2628,126 @@ func (in *JunitCheck) DeepCopy() *JunitCheck {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JunitSource) DeepCopyInto(out *JunitSource) {
+	*out = *in
+	out.Filter = in.Filter
+	if in.S3Connection != nil {
+		in, out := &in.S3Connection, &out.S3Connection
+		*out = new(connection.S3Connection)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GCSConnection != nil {
+		in, out := &in.GCSConnection, &out.GCSConnection
+		*out = new(connection.GCSConnection)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JunitSource.
+func (in *JunitSource) DeepCopy() *JunitSource {
+	if in == nil {
+		return nil
+	}
+	out := new(JunitSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *K6) DeepCopyInto(out *K6) {
+	*out = *in
+	in.K6Check.DeepCopyInto(&out.K6Check)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new K6.
+func (in *K6) DeepCopy() *K6 {
+	if in == nil {
+		return nil
+	}
+	out := new(K6)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *K6Check) DeepCopyInto(out *K6Check) {
+	*out = *in
+	in.Description.DeepCopyInto(&out.Description)
+	out.Templatable = in.Templatable
+	in.Relatable.DeepCopyInto(&out.Relatable)
+	if in.ScriptFrom != nil {
+		in, out := &in.ScriptFrom, &out.ScriptFrom
+		*out = new(types.EnvVarSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Checkout != nil {
+		in, out := &in.Checkout, &out.Checkout
+		*out = new(connection.GitConnection)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EnvVars != nil {
+		in, out := &in.EnvVars, &out.EnvVars
+		*out = make([]types.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new K6Check.
+func (in *K6Check) DeepCopy() *K6Check {
+	if in == nil {
+		return nil
+	}
+	out := new(K6Check)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Kopia) DeepCopyInto(out *Kopia) {
+	*out = *in
+	in.KopiaCheck.DeepCopyInto(&out.KopiaCheck)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Kopia.
+func (in *Kopia) DeepCopy() *Kopia {
+	if in == nil {
+		return nil
+	}
+	out := new(Kopia)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KopiaCheck) DeepCopyInto(out *KopiaCheck) {
+	*out = *in
+	in.Description.DeepCopyInto(&out.Description)
+	out.Templatable = in.Templatable
+	in.Relatable.DeepCopyInto(&out.Relatable)
+	out.BackupRepository = in.BackupRepository
+	in.Password.DeepCopyInto(&out.Password)
+	in.AccessKey.DeepCopyInto(&out.AccessKey)
+	in.SecretKey.DeepCopyInto(&out.SecretKey)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KopiaCheck.
+func (in *KopiaCheck) DeepCopy() *KopiaCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(KopiaCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Kubernetes) DeepCopyInto(out *Kubernetes) {
 	*out = *in
@@ -2195,13 +2770,9 @@ func (in *KubernetesCheck) DeepCopyInto(out *KubernetesCheck) {
 	in.Description.DeepCopyInto(&out.Description)
 	out.Templatable = in.Templatable
 	in.Relatable.DeepCopyInto(&out.Relatable)
+	in.KubernetesConnection.DeepCopyInto(&out.KubernetesConnection)
 	out.Namespace = in.Namespace
 	out.Resource = in.Resource
-	if in.KubeConfig != nil {
-		in, out := &in.KubeConfig, &out.KubeConfig
-		*out = new(types.EnvVar)
-		(*in).DeepCopyInto(*out)
-	}
 	if in.Ignore != nil {
 		in, out := &in.Ignore, &out.Ignore
 		*out = make([]string, len(*in))
@@ -2219,12 +2790,33 @@ func (in *KubernetesCheck) DeepCopy() *KubernetesCheck {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesConnection) DeepCopyInto(out *KubernetesConnection) {
+	*out = *in
+	if in.Kubeconfig != nil {
+		in, out := &in.Kubeconfig, &out.Kubeconfig
+		*out = new(types.EnvVar)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesConnection.
+func (in *KubernetesConnection) DeepCopy() *KubernetesConnection {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesConnection)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubernetesResourceCheck) DeepCopyInto(out *KubernetesResourceCheck) {
 	*out = *in
 	in.Description.DeepCopyInto(&out.Description)
 	out.Templatable = in.Templatable
 	in.Relatable.DeepCopyInto(&out.Relatable)
+	in.KubernetesConnection.DeepCopyInto(&out.KubernetesConnection)
 	if in.StaticResources != nil {
 		in, out := &in.StaticResources, &out.StaticResources
 		*out = make([]unstructured.Unstructured, len(*in))
@@ -2247,11 +2839,6 @@ func (in *KubernetesResourceCheck) DeepCopyInto(out *KubernetesResourceCheck) {
 		}
 	}
 	in.CheckRetries.DeepCopyInto(&out.CheckRetries)
-	if in.Kubeconfig != nil {
-		in, out := &in.Kubeconfig, &out.Kubeconfig
-		*out = new(types.EnvVar)
-		(*in).DeepCopyInto(*out)
-	}
 	in.WaitFor.DeepCopyInto(&out.WaitFor)
 }
 
@@ -2356,8 +2943,19 @@ func (in *LDAP) DeepCopy() *LDAP {
 func (in *LDAPCheck) DeepCopyInto(out *LDAPCheck) {
 	*out = *in
 	in.Description.DeepCopyInto(&out.Description)
+	out.Templatable = in.Templatable
 	in.Relatable.DeepCopyInto(&out.Relatable)
 	in.Connection.DeepCopyInto(&out.Connection)
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.UserBind != nil {
+		in, out := &in.UserBind, &out.UserBind
+		*out = new(LDAPUserBind)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LDAPCheck.
@@ -2370,6 +2968,22 @@ func (in *LDAPCheck) DeepCopy() *LDAPCheck {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LDAPUserBind) DeepCopyInto(out *LDAPUserBind) {
+	*out = *in
+	in.Password.DeepCopyInto(&out.Password)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LDAPUserBind.
+func (in *LDAPUserBind) DeepCopy() *LDAPUserBind {
+	if in == nil {
+		return nil
+	}
+	out := new(LDAPUserBind)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in Labels) DeepCopyInto(out *Labels) {
 	{
@@ -2411,6 +3025,7 @@ func (in *Mongo) DeepCopy() *Mongo {
 func (in *MongoDBCheck) DeepCopyInto(out *MongoDBCheck) {
 	*out = *in
 	in.Description.DeepCopyInto(&out.Description)
+	out.Templatable = in.Templatable
 	in.Connection.DeepCopyInto(&out.Connection)
 }
 
@@ -2493,6 +3108,7 @@ func (in *NamespaceCheck) DeepCopyInto(out *NamespaceCheck) {
 	*out = *in
 	in.Description.DeepCopyInto(&out.Description)
 	in.Relatable.DeepCopyInto(&out.Relatable)
+	in.KubernetesConnection.DeepCopyInto(&out.KubernetesConnection)
 	if in.NamespaceLabels != nil {
 		in, out := &in.NamespaceLabels, &out.NamespaceLabels
 		*out = make(map[string]string, len(*in))
@@ -2540,6 +3156,96 @@ func (in *NamespaceSelector) DeepCopy() *NamespaceSelector {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkMatrix) DeepCopyInto(out *NetworkMatrix) {
+	*out = *in
+	in.NetworkMatrixCheck.DeepCopyInto(&out.NetworkMatrixCheck)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkMatrix.
+func (in *NetworkMatrix) DeepCopy() *NetworkMatrix {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkMatrix)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkMatrixCheck) DeepCopyInto(out *NetworkMatrixCheck) {
+	*out = *in
+	in.Description.DeepCopyInto(&out.Description)
+	out.Templatable = in.Templatable
+	in.Relatable.DeepCopyInto(&out.Relatable)
+	in.KubernetesConnection.DeepCopyInto(&out.KubernetesConnection)
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = make([]NetworkMatrixService, len(*in))
+		copy(*out, *in)
+	}
+	if in.Protocols != nil {
+		in, out := &in.Protocols, &out.Protocols
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Expected != nil {
+		in, out := &in.Expected, &out.Expected
+		*out = make([]NetworkMatrixRule, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkMatrixCheck.
+func (in *NetworkMatrixCheck) DeepCopy() *NetworkMatrixCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkMatrixCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkMatrixRule) DeepCopyInto(out *NetworkMatrixRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkMatrixRule.
+func (in *NetworkMatrixRule) DeepCopy() *NetworkMatrixRule {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkMatrixRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkMatrixService) DeepCopyInto(out *NetworkMatrixService) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkMatrixService.
+func (in *NetworkMatrixService) DeepCopy() *NetworkMatrixService {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkMatrixService)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Oauth2Config) DeepCopyInto(out *Oauth2Config) {
 	*out = *in
@@ -2586,6 +3292,38 @@ func (in *OpenSearchCheck) DeepCopy() *OpenSearchCheck {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Oracle) DeepCopyInto(out *Oracle) {
+	*out = *in
+	in.OracleCheck.DeepCopyInto(&out.OracleCheck)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Oracle.
+func (in *Oracle) DeepCopy() *Oracle {
+	if in == nil {
+		return nil
+	}
+	out := new(Oracle)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OracleCheck) DeepCopyInto(out *OracleCheck) {
+	*out = *in
+	in.SQLCheck.DeepCopyInto(&out.SQLCheck)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OracleCheck.
+func (in *OracleCheck) DeepCopy() *OracleCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(OracleCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ParentLookup) DeepCopyInto(out *ParentLookup) {
 	*out = *in
@@ -2622,6 +3360,7 @@ func (in *PodCheck) DeepCopyInto(out *PodCheck) {
 	*out = *in
 	in.Description.DeepCopyInto(&out.Description)
 	in.Relatable.DeepCopyInto(&out.Relatable)
+	in.KubernetesConnection.DeepCopyInto(&out.KubernetesConnection)
 	if in.ExpectedHTTPStatuses != nil {
 		in, out := &in.ExpectedHTTPStatuses, &out.ExpectedHTTPStatuses
 		*out = make([]int, len(*in))
@@ -2655,6 +3394,27 @@ func (in *Postgres) DeepCopy() *Postgres {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresBaseBackup) DeepCopyInto(out *PostgresBaseBackup) {
+	*out = *in
+	out.Filter = in.Filter
+	if in.S3Connection != nil {
+		in, out := &in.S3Connection, &out.S3Connection
+		*out = new(connection.S3Connection)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresBaseBackup.
+func (in *PostgresBaseBackup) DeepCopy() *PostgresBaseBackup {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresBaseBackup)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PostgresCheck) DeepCopyInto(out *PostgresCheck) {
 	*out = *in
@@ -2671,6 +3431,27 @@ func (in *PostgresCheck) DeepCopy() *PostgresCheck {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresDatabase) DeepCopyInto(out *PostgresDatabase) {
+	*out = *in
+	in.Connection.DeepCopyInto(&out.Connection)
+	if in.BaseBackup != nil {
+		in, out := &in.BaseBackup, &out.BaseBackup
+		*out = new(PostgresBaseBackup)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresDatabase.
+func (in *PostgresDatabase) DeepCopy() *PostgresDatabase {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresDatabase)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Prometheus) DeepCopyInto(out *Prometheus) {
 	*out = *in
@@ -2797,6 +3578,7 @@ func (in *Redis) DeepCopy() *Redis {
 func (in *RedisCheck) DeepCopyInto(out *RedisCheck) {
 	*out = *in
 	in.Description.DeepCopyInto(&out.Description)
+	out.Templatable = in.Templatable
 	in.Relatable.DeepCopyInto(&out.Relatable)
 	in.Connection.DeepCopyInto(&out.Connection)
 	if in.DB != nil {
@@ -2804,6 +3586,23 @@ func (in *RedisCheck) DeepCopyInto(out *RedisCheck) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.Sentinel != nil {
+		in, out := &in.Sentinel, &out.Sentinel
+		*out = new(RedisSentinel)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Cluster != nil {
+		in, out := &in.Cluster, &out.Cluster
+		*out = new(RedisCluster)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Commands != nil {
+		in, out := &in.Commands, &out.Commands
+		*out = make([]RedisCommand, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisCheck.
@@ -2816,6 +3615,67 @@ func (in *RedisCheck) DeepCopy() *RedisCheck {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedisCluster) DeepCopyInto(out *RedisCluster) {
+	*out = *in
+	if in.Addrs != nil {
+		in, out := &in.Addrs, &out.Addrs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisCluster.
+func (in *RedisCluster) DeepCopy() *RedisCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(RedisCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedisCommand) DeepCopyInto(out *RedisCommand) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisCommand.
+func (in *RedisCommand) DeepCopy() *RedisCommand {
+	if in == nil {
+		return nil
+	}
+	out := new(RedisCommand)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedisSentinel) DeepCopyInto(out *RedisSentinel) {
+	*out = *in
+	if in.Addrs != nil {
+		in, out := &in.Addrs, &out.Addrs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Password.DeepCopyInto(&out.Password)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisSentinel.
+func (in *RedisSentinel) DeepCopy() *RedisSentinel {
+	if in == nil {
+		return nil
+	}
+	out := new(RedisSentinel)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Relatable) DeepCopyInto(out *Relatable) {
 	*out = *in
@@ -2967,6 +3827,38 @@ func (in *SQLCheck) DeepCopy() *SQLCheck {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SQLite) DeepCopyInto(out *SQLite) {
+	*out = *in
+	in.SQLiteCheck.DeepCopyInto(&out.SQLiteCheck)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SQLite.
+func (in *SQLite) DeepCopy() *SQLite {
+	if in == nil {
+		return nil
+	}
+	out := new(SQLite)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SQLiteCheck) DeepCopyInto(out *SQLiteCheck) {
+	*out = *in
+	in.SQLCheck.DeepCopyInto(&out.SQLCheck)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SQLiteCheck.
+func (in *SQLiteCheck) DeepCopy() *SQLiteCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(SQLiteCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Selector) DeepCopyInto(out *Selector) {
 	*out = *in
@@ -3214,6 +4106,13 @@ func (in *TopologyStatus) DeepCopyInto(out *TopologyStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologyStatus.
@@ -3272,6 +4171,42 @@ func (in *VarSource) DeepCopy() *VarSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Velero) DeepCopyInto(out *Velero) {
+	*out = *in
+	in.VeleroCheck.DeepCopyInto(&out.VeleroCheck)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Velero.
+func (in *Velero) DeepCopy() *Velero {
+	if in == nil {
+		return nil
+	}
+	out := new(Velero)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VeleroCheck) DeepCopyInto(out *VeleroCheck) {
+	*out = *in
+	in.Description.DeepCopyInto(&out.Description)
+	out.Templatable = in.Templatable
+	in.Relatable.DeepCopyInto(&out.Relatable)
+	in.KubernetesConnection.DeepCopyInto(&out.KubernetesConnection)
+	out.BackupRepository = in.BackupRepository
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VeleroCheck.
+func (in *VeleroCheck) DeepCopy() *VeleroCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(VeleroCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WebhookCheck) DeepCopyInto(out *WebhookCheck) {
 	*out = *in
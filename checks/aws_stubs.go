@@ -8,6 +8,9 @@ import (
 	"github.com/flanksource/canary-checker/api/context"
 	"github.com/flanksource/canary-checker/api/external"
 	"github.com/flanksource/canary-checker/pkg"
+	"github.com/flanksource/duty/connection"
+
+	artifactFS "github.com/flanksource/artifacts/fs"
 )
 
 type S3Checker struct{}
@@ -79,3 +82,7 @@ func (c *S3Checker) Check(ctx *context.Context, extConfig external.Check) pkg.Re
 func CheckS3Bucket(ctx *context.Context, extConfig external.Check) pkg.Results {
 	return pkg.SetupError(ctx.Canary, errors.New("AWS not included in binary"))
 }
+
+func getS3FS(ctx *context.Context, conn *connection.S3Connection, fullpath string) (artifactFS.Filesystem, string, error) {
+	return nil, "", errors.New("AWS not included in binary")
+}
@@ -0,0 +1,171 @@
+package checks
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"os"
+	osExec "os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/flanksource/canary-checker/api/context"
+	v1 "github.com/flanksource/canary-checker/api/v1"
+	"github.com/flanksource/canary-checker/pkg"
+	"github.com/flanksource/canary-checker/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	backupSnapshotAge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "canary_check_backup_snapshot_age_seconds",
+			Help: "Age of the newest snapshot in a backup repository",
+		},
+		[]string{"key", "type", "repository"},
+	)
+	backupSnapshotCount = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "canary_check_backup_snapshot_count",
+			Help: "The number of snapshots in a backup repository",
+		},
+		[]string{"key", "type", "repository"},
+	)
+)
+
+func init() {
+	metrics.RegisterCheckGauge(backupSnapshotAge)
+	metrics.RegisterCheckGauge(backupSnapshotCount)
+}
+
+// BackupSnapshot is a single snapshot, archive or backup in a backup repository
+type BackupSnapshot struct {
+	ID       string            `json:"id"`
+	Time     time.Time         `json:"time"`
+	Size     int64             `json:"size,omitempty"`
+	Status   string            `json:"status,omitempty"`
+	Failed   bool              `json:"failed,omitempty"`
+	Host     string            `json:"host,omitempty"`
+	Paths    []string          `json:"paths,omitempty"`
+	Tags     []string          `json:"tags,omitempty"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Messages []string          `json:"messages,omitempty"`
+}
+
+// BackupRepository is implemented by each backup tool (restic, kopia, borg, velero)
+type BackupRepository interface {
+	// Snapshots returns all snapshots in the repository
+	Snapshots(ctx *context.Context) ([]BackupSnapshot, error)
+	// Verify checks the integrity of the repository
+	Verify(ctx *context.Context) error
+}
+
+type BackupRepositoryDetails struct {
+	Snapshots []BackupSnapshot `json:"snapshots"`
+	Count     int              `json:"count"`
+	Newest    *BackupSnapshot  `json:"newest,omitempty"`
+	// Age of the newest snapshot in seconds
+	Age float64 `json:"age"`
+	// SizeChange is the percentage difference in size between the newest and previous snapshot
+	SizeChange float64 `json:"sizeChange"`
+}
+
+func newBackupRepositoryDetails(snapshots []BackupSnapshot, now time.Time) BackupRepositoryDetails {
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshots[i].Time.After(snapshots[j].Time)
+	})
+
+	details := BackupRepositoryDetails{Snapshots: snapshots, Count: len(snapshots)}
+	if len(snapshots) == 0 {
+		return details
+	}
+	details.Newest = &snapshots[0]
+	details.Age = now.Sub(snapshots[0].Time).Seconds()
+	if len(snapshots) > 1 && snapshots[1].Size > 0 {
+		details.SizeChange = float64(snapshots[0].Size-snapshots[1].Size) * 100 / float64(snapshots[1].Size)
+	}
+	return details
+}
+
+// Test returns the reasons the repository does not meet the thresholds
+func (d BackupRepositoryDetails) Test(check v1.BackupRepository) ([]string, error) {
+	var errors []string
+	if d.Newest == nil {
+		return []string{"no snapshots found"}, nil
+	}
+
+	if d.Newest.Failed {
+		errors = append(errors, strings.TrimSuffix(fmt.Sprintf("newest snapshot %s is %s: %s", d.Newest.ID, d.Newest.Status, strings.Join(d.Newest.Messages, ", ")), ": "))
+	}
+
+	if check.MaxAge != "" {
+		maxAge, err := check.MaxAge.GetDuration()
+		if err != nil {
+			return nil, fmt.Errorf("invalid maxAge: %w", err)
+		}
+		if age := time.Duration(d.Age * float64(time.Second)); age > *maxAge {
+			errors = append(errors, fmt.Sprintf("newest snapshot %s is %s old, older than %s", d.Newest.ID, age.Round(time.Second), check.MaxAge))
+		}
+	}
+
+	if check.MinCount > 0 && d.Count < check.MinCount {
+		errors = append(errors, fmt.Sprintf("found %d snapshots, expected at least %d", d.Count, check.MinCount))
+	}
+
+	if check.MaxSizeChange > 0 && math.Abs(d.SizeChange) > float64(check.MaxSizeChange) {
+		errors = append(errors, fmt.Sprintf("size of snapshot %s changed by %.1f%%", d.Newest.ID, d.SizeChange))
+	}
+	return errors, nil
+}
+
+// checkBackupRepository lists the snapshots of a repository, adds them to the result details
+// and fails the result if any of the thresholds are not met
+func checkBackupRepository(ctx *context.Context, result *pkg.CheckResult, repo BackupRepository, check v1.BackupRepository) pkg.Results {
+	results := pkg.Results{result}
+	endpoint := result.Check.GetEndpoint()
+	key := ctx.Canary.GetCheckID(result.Check.GetName())
+
+	if check.CheckIntegrity {
+		if err := repo.Verify(ctx); err != nil {
+			return results.Failf("integrity check failed: %v", err)
+		}
+	}
+
+	snapshots, err := repo.Snapshots(ctx)
+	if err != nil {
+		return results.Failf("failed to list snapshots: %v", err)
+	}
+
+	details := newBackupRepositoryDetails(snapshots, time.Now())
+	result.AddDetails(details)
+	backupSnapshotCount.WithLabelValues(key, result.Check.GetType(), endpoint).Set(float64(details.Count))
+	if details.Newest != nil {
+		backupSnapshotAge.WithLabelValues(key, result.Check.GetType(), endpoint).Set(details.Age)
+	}
+
+	errors, err := details.Test(check)
+	if err != nil {
+		return results.Invalidf("%v", err)
+	}
+	for _, e := range errors {
+		result.Failf("%s", e)
+	}
+	return results
+}
+
+// runBackupCommand runs a backup tool with the given environment and returns its stdout
+func runBackupCommand(ctx *context.Context, env map[string]string, name string, args ...string) ([]byte, error) {
+	cmd := osExec.CommandContext(ctx, name, args...)
+	cmd.Env = os.Environ()
+	for k, v := range env {
+		cmd.Env = append(cmd.Env, k+"="+v)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%s failed: %w: %s", name, err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
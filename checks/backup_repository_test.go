@@ -0,0 +1,60 @@
+package checks
+
+import (
+	"testing"
+	"time"
+
+	v1 "github.com/flanksource/canary-checker/api/v1"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestBackupRepositoryDetails(t *testing.T) {
+	RegisterTestingT(t)
+	now := time.Now()
+
+	details := newBackupRepositoryDetails([]BackupSnapshot{
+		{ID: "old", Time: now.Add(-48 * time.Hour), Size: 100},
+		{ID: "new", Time: now.Add(-2 * time.Hour), Size: 40},
+	}, now)
+
+	Expect(details.Count).To(Equal(2))
+	Expect(details.Newest.ID).To(Equal("new"))
+	Expect(details.SizeChange).To(BeNumerically("==", -60))
+
+	errors, err := details.Test(v1.BackupRepository{MaxAge: "24h", MinCount: 2, MaxSizeChange: 80})
+	Expect(err).ToNot(HaveOccurred())
+	Expect(errors).To(BeEmpty())
+
+	errors, err = details.Test(v1.BackupRepository{MaxAge: "1h", MinCount: 3, MaxSizeChange: 50})
+	Expect(err).ToNot(HaveOccurred())
+	Expect(errors).To(HaveLen(3))
+
+	errors, _ = newBackupRepositoryDetails(nil, now).Test(v1.BackupRepository{})
+	Expect(errors).To(ConsistOf("no snapshots found"))
+}
+
+func TestVeleroBackupToSnapshot(t *testing.T) {
+	RegisterTestingT(t)
+
+	backup := unstructured.Unstructured{Object: map[string]any{
+		"metadata": map[string]any{"name": "daily-20240101", "labels": map[string]any{"velero.io/schedule-name": "daily"}},
+		"status": map[string]any{
+			"phase":               "PartiallyFailed",
+			"completionTimestamp": "2024-01-01T01:00:00Z",
+			"errors":              int64(2),
+		},
+	}}
+	snapshot, ok := veleroBackupToSnapshot(backup)
+	Expect(ok).To(BeTrue())
+	Expect(snapshot.Failed).To(BeTrue())
+	Expect(snapshot.Time).To(Equal(time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC)))
+	Expect(snapshot.Messages).To(ConsistOf("2 errors"))
+
+	errors, _ := newBackupRepositoryDetails([]BackupSnapshot{snapshot}, time.Now()).Test(v1.BackupRepository{})
+	Expect(errors).To(ConsistOf("newest snapshot daily-20240101 is PartiallyFailed: 2 errors"))
+
+	backup.Object["status"] = map[string]any{"phase": "InProgress"}
+	_, ok = veleroBackupToSnapshot(backup)
+	Expect(ok).To(BeFalse())
+}
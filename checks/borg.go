@@ -0,0 +1,153 @@
+package checks
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/flanksource/canary-checker/api/context"
+	"github.com/flanksource/canary-checker/api/external"
+	v1 "github.com/flanksource/canary-checker/api/v1"
+	"github.com/flanksource/canary-checker/pkg"
+)
+
+type BorgChecker struct {
+}
+
+func (c *BorgChecker) Type() string {
+	return "borg"
+}
+
+func (c *BorgChecker) Run(ctx *context.Context) pkg.Results {
+	var results pkg.Results
+	for _, conf := range ctx.Canary.Spec.Borg {
+		results = append(results, c.Check(ctx, conf)...)
+	}
+	return results
+}
+
+func (c *BorgChecker) Check(ctx *context.Context, extConfig external.Check) pkg.Results {
+	check := extConfig.(v1.BorgCheck)
+	result := pkg.Success(check, ctx.Canary)
+	var results pkg.Results
+	results = append(results, result)
+
+	env := map[string]string{
+		// never prompt, borg would otherwise block waiting for input
+		"BORG_RELOCATED_REPO_ACCESS_IS_OK":           "yes",
+		"BORG_UNKNOWN_UNENCRYPTED_REPO_ACCESS_IS_OK": "yes",
+	}
+
+	if !check.Passphrase.IsEmpty() {
+		passphrase, err := ctx.GetEnvValueFromCache(check.Passphrase, ctx.GetNamespace())
+		if err != nil {
+			return results.Failf("error getting passphrase: %v", err)
+		}
+		env["BORG_PASSPHRASE"] = passphrase
+	}
+
+	if !check.SSHKey.IsEmpty() {
+		key, err := ctx.GetEnvValueFromCache(check.SSHKey, ctx.GetNamespace())
+		if err != nil {
+			return results.Failf("error getting ssh key: %v", err)
+		}
+		dir, err := os.MkdirTemp("", "borg-")
+		if err != nil {
+			return results.ErrorMessage(err)
+		}
+		defer os.RemoveAll(dir)
+
+		keyFile := filepath.Join(dir, "id")
+		if err := os.WriteFile(keyFile, []byte(strings.TrimSpace(key)+"\n"), 0600); err != nil {
+			return results.ErrorMessage(err)
+		}
+		env["BORG_RSH"] = fmt.Sprintf("ssh -i %s -o StrictHostKeyChecking=accept-new -o UserKnownHostsFile=%s", keyFile, filepath.Join(dir, "known_hosts"))
+	}
+
+	return checkBackupRepository(ctx, result, borgRepository{repository: check.Repository, env: env}, check.BackupRepository)
+}
+
+type borgRepository struct {
+	repository string
+	env        map[string]string
+}
+
+// borgTime is a timestamp in the local time of the borg client, without a timezone
+type borgTime time.Time
+
+func (t *borgTime) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	parsed, err := time.ParseInLocation("2006-01-02T15:04:05.999999", s, time.Local)
+	if err != nil {
+		return err
+	}
+	*t = borgTime(parsed)
+	return nil
+}
+
+type borgArchive struct {
+	ID       string   `json:"id"`
+	Name     string   `json:"name"`
+	Start    borgTime `json:"start"`
+	End      borgTime `json:"end"`
+	Hostname string   `json:"hostname"`
+	Stats    *struct {
+		OriginalSize int64 `json:"original_size"`
+	} `json:"stats,omitempty"`
+}
+
+func (r borgRepository) Snapshots(ctx *context.Context) ([]BackupSnapshot, error) {
+	output, err := runBackupCommand(ctx, r.env, "borg", "list", "--json", r.repository)
+	if err != nil {
+		return nil, err
+	}
+	var list struct {
+		Archives []borgArchive `json:"archives"`
+	}
+	if err := json.Unmarshal(output, &list); err != nil {
+		return nil, fmt.Errorf("failed to parse archives: %w", err)
+	}
+
+	// sizes are only returned by borg info, which is slow on large repositories so
+	// it is limited to the archives needed to calculate the size trend
+	sizes := make(map[string]int64)
+	if len(list.Archives) > 0 {
+		output, err := runBackupCommand(ctx, r.env, "borg", "info", "--json", "--last", "2", r.repository)
+		if err != nil {
+			return nil, err
+		}
+		var info struct {
+			Archives []borgArchive `json:"archives"`
+		}
+		if err := json.Unmarshal(output, &info); err != nil {
+			return nil, fmt.Errorf("failed to parse archive info: %w", err)
+		}
+		for _, archive := range info.Archives {
+			if archive.Stats != nil {
+				sizes[archive.ID] = archive.Stats.OriginalSize
+			}
+		}
+	}
+
+	var result []BackupSnapshot
+	for _, archive := range list.Archives {
+		result = append(result, BackupSnapshot{
+			ID:   archive.Name,
+			Time: time.Time(archive.Start),
+			Size: sizes[archive.ID],
+			Host: archive.Hostname,
+		})
+	}
+	return result, nil
+}
+
+func (r borgRepository) Verify(ctx *context.Context) error {
+	_, err := runBackupCommand(ctx, r.env, "borg", "check", r.repository)
+	return err
+}
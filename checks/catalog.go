@@ -1,12 +1,23 @@
 package checks
 
 import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
 	canaryContext "github.com/flanksource/canary-checker/api/context"
 	v1 "github.com/flanksource/canary-checker/api/v1"
 	"github.com/flanksource/canary-checker/pkg"
 	"github.com/flanksource/duty/query"
+	"github.com/flanksource/duty/types"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
 )
 
+// defaultCatalogMaxItems is the maximum number of config items that changes and relationships are fetched for,
+// as they are fetched with a query per config item
+const defaultCatalogMaxItems = 100
+
 type CatalogChecker struct{}
 
 func (c *CatalogChecker) Type() string {
@@ -28,10 +39,19 @@ func (c *CatalogChecker) Check(ctx *canaryContext.Context, check v1.CatalogCheck
 	var results pkg.Results
 	results = append(results, result)
 
-	items, err := query.FindConfigsByResourceSelector(ctx.Context, -1, check.Selector...)
+	limit := -1
+	if check.Changes != nil || check.Relationships != nil {
+		limit = ctx.Properties().Int("checks.catalog.maxItems", defaultCatalogMaxItems)
+	}
+
+	// fetch one more than the limit to detect when the selector matches too many items
+	items, err := query.FindConfigsByResourceSelector(ctx.Context, lo.Ternary(limit > 0, limit+1, -1), catalogSelectors(check)...)
 	if err != nil {
 		return results.Failf("failed to fetch catalogs: %v", err)
 	}
+	if limit > 0 && len(items) > limit {
+		return results.Invalidf("selector matched more than %d config items, narrow the selector or increase checks.catalog.maxItems to fetch changes and relationships", limit)
+	}
 
 	var configItems []map[string]any
 	for _, item := range items {
@@ -39,8 +59,99 @@ func (c *CatalogChecker) Check(ctx *canaryContext.Context, check v1.CatalogCheck
 		// The config should be map[string]any so
 		// that it can be accessed directly in templating
 		ci["config"], _ = item.ConfigJSONStringMap()
+
+		if check.Changes != nil {
+			req, err := catalogChangesRequest(item.ID.String(), *check.Changes)
+			if err != nil {
+				return results.Invalidf("%v", err)
+			}
+			changes, err := query.FindCatalogChanges(ctx.Context, req)
+			if err != nil {
+				return results.Failf("failed to fetch changes of %s: %v", item, err)
+			}
+			if ci["changes"], err = asMaps(changes.Changes); err != nil {
+				return results.Failf("%v", err)
+			}
+		}
+
+		if check.Relationships != nil {
+			related, err := query.GetRelatedConfigs(ctx.Context, catalogRelationQuery(item.ID, *check.Relationships))
+			if err != nil {
+				return results.Failf("failed to fetch relationships of %s: %v", item, err)
+			}
+			if len(check.Relationships.Types) > 0 {
+				related = lo.Filter(related, func(r query.RelatedConfig, _ int) bool {
+					return lo.Contains(check.Relationships.Types, r.Type)
+				})
+			}
+			if ci["relationships"], err = asMaps(related); err != nil {
+				return results.Failf("%v", err)
+			}
+		}
+
 		configItems = append(configItems, ci)
 	}
 	result.AddDetails(configItems)
 	return results
 }
+
+// catalogSelectors limits the selectors to the config items of the cluster targeted by the check
+func catalogSelectors(check v1.CatalogCheck) types.ResourceSelectors {
+	if check.Cluster == "" {
+		return check.Selector
+	}
+	selectors := make(types.ResourceSelectors, 0, len(check.Selector))
+	for _, selector := range check.Selector {
+		tag := v1.ClusterLabel + "=" + check.Cluster
+		selector.TagSelector = strings.Join(lo.Compact([]string{selector.TagSelector, tag}), ",")
+		selectors = append(selectors, selector)
+	}
+	return selectors
+}
+
+func catalogChangesRequest(id string, changes v1.CatalogChanges) (query.CatalogChangesSearchRequest, error) {
+	req := query.CatalogChangesSearchRequest{
+		CatalogID:  id,
+		ChangeType: strings.Join(changes.Types, ","),
+		Severity:   changes.Severity,
+		Source:     changes.Source,
+		Summary:    changes.Summary,
+		Recursive:  query.ChangeRelationDirection(lo.CoalesceOrEmpty(changes.Recursive, string(query.CatalogChangeRecursiveNone))),
+		Depth:      changes.Depth,
+		PageSize:   changes.Limit,
+		SortBy:     "-created_at",
+	}
+	if changes.Since != "" {
+		since, err := changes.Since.GetDuration()
+		if err != nil {
+			return req, fmt.Errorf("invalid since: %w", err)
+		}
+		req.From = fmt.Sprintf("now-%ds", int(since.Seconds()))
+	}
+	return req, nil
+}
+
+func catalogRelationQuery(id uuid.UUID, relationships v1.CatalogRelationships) query.RelationQuery {
+	relationType := query.RelationType(lo.CoalesceOrEmpty(relationships.Type, string(query.Both)))
+	return query.RelationQuery{
+		ID:             id,
+		Relation:       query.RelationDirection(lo.CoalesceOrEmpty(relationships.Direction, string(query.All))),
+		Incoming:       relationType,
+		Outgoing:       relationType,
+		IncludeDeleted: relationships.IncludeDeleted,
+		MaxDepth:       relationships.Depth,
+	}
+}
+
+// asMaps converts a list of rows into maps so that they can be accessed directly in templating
+func asMaps[T any](rows []T) ([]map[string]any, error) {
+	data, err := json.Marshal(rows)
+	if err != nil {
+		return nil, err
+	}
+	maps := make([]map[string]any, 0, len(rows))
+	if err := json.Unmarshal(data, &maps); err != nil {
+		return nil, err
+	}
+	return maps, nil
+}
@@ -0,0 +1,74 @@
+package checks
+
+import (
+	"testing"
+	"time"
+
+	v1 "github.com/flanksource/canary-checker/api/v1"
+	"github.com/flanksource/duty/query"
+	"github.com/flanksource/duty/types"
+	"github.com/google/uuid"
+	. "github.com/onsi/gomega"
+	"github.com/samber/lo"
+)
+
+func TestCatalogChangesRequest(t *testing.T) {
+	RegisterTestingT(t)
+
+	req, err := catalogChangesRequest("id", v1.CatalogChanges{Types: []string{"diff", "!Pulled"}, Since: "15m"})
+	Expect(err).ToNot(HaveOccurred())
+	Expect(req.CatalogID).To(Equal("id"))
+	Expect(req.ChangeType).To(Equal("diff,!Pulled"))
+	Expect(req.From).To(Equal("now-900s"))
+	Expect(req.Recursive).To(Equal(query.CatalogChangeRecursiveNone))
+
+	req.SetDefaults()
+	Expect(req.Validate()).To(Succeed())
+
+	_, err = catalogChangesRequest("id", v1.CatalogChanges{Since: "1 day"})
+	Expect(err).To(HaveOccurred())
+}
+
+func TestCatalogRelationQuery(t *testing.T) {
+	RegisterTestingT(t)
+	id := uuid.New()
+
+	q := catalogRelationQuery(id, v1.CatalogRelationships{})
+	Expect(q.ID).To(Equal(id))
+	Expect(q.Relation).To(Equal(query.All))
+	Expect(q.Incoming).To(Equal(query.Both))
+	Expect(q.Outgoing).To(Equal(query.Both))
+
+	q = catalogRelationQuery(id, v1.CatalogRelationships{Direction: "outgoing", Type: "hard", Depth: lo.ToPtr(1)})
+	Expect(q.Relation).To(Equal(query.Outgoing))
+	Expect(q.Outgoing).To(Equal(query.Hard))
+	Expect(*q.MaxDepth).To(Equal(1))
+}
+
+func TestAsMaps(t *testing.T) {
+	RegisterTestingT(t)
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	maps, err := asMaps([]query.ConfigChangeRow{{ChangeType: "diff", Severity: "info", CreatedAt: &created}})
+	Expect(err).ToNot(HaveOccurred())
+	Expect(maps).To(HaveLen(1))
+	Expect(maps[0]["change_type"]).To(Equal("diff"))
+	Expect(maps[0]["created_at"]).To(Equal("2024-01-01T00:00:00Z"))
+
+	maps, err = asMaps([]query.RelatedConfig{})
+	Expect(err).ToNot(HaveOccurred())
+	Expect(maps).To(BeEmpty())
+}
+
+func TestCatalogSelectors(t *testing.T) {
+	RegisterTestingT(t)
+	check := v1.CatalogCheck{Selector: types.ResourceSelectors{{Types: []string{"Kubernetes::Pod"}}, {TagSelector: "env=prod"}}}
+
+	Expect(catalogSelectors(check)).To(Equal(check.Selector))
+
+	check.Cluster = "eu-west-1"
+	selectors := catalogSelectors(check)
+	Expect(selectors[0].TagSelector).To(Equal("cluster=eu-west-1"))
+	Expect(selectors[1].TagSelector).To(Equal("env=prod,cluster=eu-west-1"))
+	Expect(check.Selector[1].TagSelector).To(Equal("env=prod"))
+}
@@ -27,8 +27,10 @@ var All = []Checker{
 	&AwsConfigChecker{},
 	&AwsConfigRuleChecker{},
 	&AzureDevopsChecker{},
+	&BorgChecker{},
 	&CloudWatchChecker{},
 	&CatalogChecker{},
+	&ClickhouseChecker{},
 	&DatabaseBackupChecker{},
 	&DNSChecker{},
 	&DynatraceChecker{},
@@ -41,18 +43,24 @@ var All = []Checker{
 	&IcmpChecker{},
 	&JmeterChecker{},
 	&JunitChecker{},
+	&K6Checker{},
+	&KopiaChecker{},
 	&KubernetesChecker{},
 	&KubernetesResourceChecker{},
 	&LdapChecker{},
 	&MongoDBChecker{},
 	&MssqlChecker{},
 	&MysqlChecker{},
+	&NetworkMatrixChecker{},
 	&OpenSearchChecker{},
+	&OracleChecker{},
 	&PostgresChecker{},
 	&PrometheusChecker{},
 	&RedisChecker{},
 	&ResticChecker{},
 	&S3Checker{},
+	&SQLiteChecker{},
+	&VeleroChecker{},
 	NewNamespaceChecker(),
 	NewPodChecker(),
 	NewTCPChecker(),
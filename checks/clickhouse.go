@@ -0,0 +1,30 @@
+package checks
+
+import (
+	"github.com/flanksource/canary-checker/api/context"
+
+	_ "github.com/ClickHouse/clickhouse-go/v2" // Necessary for clickhouse
+	"github.com/flanksource/canary-checker/api/external"
+	v1 "github.com/flanksource/canary-checker/api/v1"
+	"github.com/flanksource/canary-checker/pkg"
+)
+
+type ClickhouseChecker struct{}
+
+func (c *ClickhouseChecker) Type() string {
+	return "clickhouse"
+}
+
+// Run: Check every entry from config according to Checker interface
+// Returns check result and metrics
+func (c *ClickhouseChecker) Run(ctx *context.Context) pkg.Results {
+	var results pkg.Results
+	for _, conf := range ctx.Canary.Spec.Clickhouse {
+		results = append(results, c.Check(ctx, conf)...)
+	}
+	return results
+}
+
+func (c *ClickhouseChecker) Check(ctx *context.Context, extConfig external.Check) pkg.Results {
+	return CheckSQL(ctx, extConfig.(v1.ClickhouseCheck))
+}
@@ -9,11 +9,13 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/flanksource/canary-checker/api/context"
+	"github.com/flanksource/canary-checker/api/external"
 	v1 "github.com/flanksource/canary-checker/api/v1"
 	"github.com/flanksource/canary-checker/pkg"
 	"github.com/flanksource/canary-checker/pkg/utils"
 	cUtils "github.com/flanksource/commons/utils"
 	"github.com/robfig/cron/v3"
+	"github.com/samber/lo"
 )
 
 // DefaultArtifactConnection is the connection that's used to save all check artifacts.
@@ -111,33 +113,7 @@ func transform(ctx *context.Context, in *pkg.CheckResult) ([]*pkg.CheckResult, b
 	if t.Name != "" && t.Name != in.Check.GetName() {
 		// new check result created with a new name
 		for _, t := range transformed {
-			t.Icon = cUtils.Coalesce(t.Icon, in.Check.GetIcon())
-			t.Description = cUtils.Coalesce(t.Description, in.Check.GetDescription())
-			t.Name = cUtils.Coalesce(t.Name, in.Check.GetName())
-			t.Type = cUtils.Coalesce(t.Type, in.Check.GetType())
-			t.Endpoint = cUtils.Coalesce(t.Endpoint, in.Check.GetEndpoint())
-			t.TransformDeleteStrategy = cUtils.Coalesce(t.TransformDeleteStrategy, in.Check.GetTransformDeleteStrategy())
-
-			r := t.ToCheckResult()
-			r.ParentCheck = in.Check
-			r.Canary = in.Canary
-			r.Canary.Namespace = cUtils.Coalesce(t.Namespace, r.Canary.Namespace)
-			if r.Canary.Labels == nil {
-				r.Canary.Labels = make(map[string]string)
-			}
-
-			// We use this label to set the transformed column to true
-			// this label are used and then removed in pkg.FromV1 function
-			r.Canary.Labels["transformed"] = "true" //nolint:goconst
-			if t.DeletedAt != nil && !t.DeletedAt.IsZero() {
-				r.Canary.DeletionTimestamp = &metav1.Time{
-					Time: *t.DeletedAt,
-				}
-			}
-
-			r.Labels = t.Labels
-			r.Transformed = true
-			results = append(results, &r)
+			results = append(results, newTransformedResult(in, t))
 		}
 		if ctx.IsTrace() {
 			ctx.Tracef("transformed into %d results", len(results))
@@ -180,7 +156,7 @@ func transform(ctx *context.Context, in *pkg.CheckResult) ([]*pkg.CheckResult, b
 			in.DisplayType = t.DisplayType
 		}
 		if len(t.Labels) > 0 {
-			in.Labels = t.Labels
+			in.Labels = withClusterLabel(in.Check, t.Labels)
 		}
 		if len(t.Data) > 0 {
 			for k, v := range t.Data {
@@ -194,11 +170,60 @@ func transform(ctx *context.Context, in *pkg.CheckResult) ([]*pkg.CheckResult, b
 	return []*pkg.CheckResult{in}, hasTransformer, nil
 }
 
+// newTransformedResult creates a new check result from a transformed result of the parent check
+func newTransformedResult(in *pkg.CheckResult, t pkg.TransformedCheckResult) *pkg.CheckResult {
+	t.Icon = cUtils.Coalesce(t.Icon, in.Check.GetIcon())
+	t.Description = cUtils.Coalesce(t.Description, in.Check.GetDescription())
+	t.Name = cUtils.Coalesce(t.Name, in.Check.GetName())
+	t.Type = cUtils.Coalesce(t.Type, in.Check.GetType())
+	t.Endpoint = cUtils.Coalesce(t.Endpoint, in.Check.GetEndpoint())
+	t.TransformDeleteStrategy = cUtils.Coalesce(t.TransformDeleteStrategy, in.Check.GetTransformDeleteStrategy())
+	t.Labels = withClusterLabel(in.Check, t.Labels)
+
+	r := t.ToCheckResult()
+	r.ParentCheck = in.Check
+	r.Canary = in.Canary
+	r.Canary.Namespace = cUtils.Coalesce(t.Namespace, r.Canary.Namespace)
+	if r.Canary.Labels == nil {
+		r.Canary.Labels = make(map[string]string)
+	}
+
+	// We use this label to set the transformed column to true
+	// this label are used and then removed in pkg.FromV1 function
+	r.Canary.Labels["transformed"] = "true" //nolint:goconst
+	if t.DeletedAt != nil && !t.DeletedAt.IsZero() {
+		r.Canary.DeletionTimestamp = &metav1.Time{
+			Time: *t.DeletedAt,
+		}
+	}
+
+	r.Labels = t.Labels
+	r.Transformed = true
+	return &r
+}
+
+// withClusterLabel keeps the cluster targeted by the parent check on the labels of transformed results
+func withClusterLabel(check external.Check, labels map[string]string) map[string]string {
+	cluster := v1.GetCluster(check)
+	if cluster == "" {
+		return labels
+	}
+	return lo.Assign(map[string]string{v1.ClusterLabel: cluster}, labels)
+}
+
 func GetJunitReportFromResults(canaryName string, results []*pkg.CheckResult) JunitTestSuite {
 	var testSuite = JunitTestSuite{
 		Name: canaryName,
 	}
 	for _, result := range results {
+		if suites, ok := result.Detail.(JunitTestSuites); ok && len(suites.Suites) > 0 {
+			// include the individual tests of junit checks, whatever format they were parsed from
+			for _, suite := range suites.Suites {
+				testSuite.Tests = append(testSuite.Tests, suite.Tests...)
+			}
+			testSuite.Totals = testSuite.Totals.Add(suites.Totals)
+			continue
+		}
 		var test JunitTest
 		test.Classname = result.Check.GetType()
 		test.Name = result.Check.GetDescription()
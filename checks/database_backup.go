@@ -50,7 +50,9 @@ func (c *DatabaseBackupChecker) Check(ctx *context.Context, extConfig external.C
 	switch {
 	case check.GCP != nil:
 		return GCPDatabaseBackupCheck(ctx, check)
+	case check.Postgres != nil:
+		return PostgresDatabaseBackupCheck(ctx, check)
 	default:
-		return pkg.Invalid(check, ctx.Canary, "GCP details not provided in check")
+		return pkg.Invalid(check, ctx.Canary, "GCP or postgres details not provided in check")
 	}
 }
@@ -0,0 +1,230 @@
+package checks
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/flanksource/canary-checker/api/context"
+	v1 "github.com/flanksource/canary-checker/api/v1"
+	"github.com/flanksource/canary-checker/pkg"
+)
+
+const (
+	postgresArchiverQuery = `SELECT current_setting('archive_mode'), archived_count, failed_count,
+	last_archived_wal, last_archived_time, last_failed_wal, last_failed_time FROM pg_stat_archiver`
+
+	postgresSlotsQuery = `SELECT slot_name, slot_type, active,
+	COALESCE(pg_wal_lsn_diff(CASE WHEN pg_is_in_recovery() THEN pg_last_wal_receive_lsn() ELSE pg_current_wal_lsn() END, restart_lsn), 0)::bigint
+	FROM pg_replication_slots`
+
+	postgresReplicationQuery = `SELECT application_name, COALESCE(client_addr::text, ''), state,
+	COALESCE(EXTRACT(EPOCH FROM replay_lag), 0)::float FROM pg_stat_replication`
+)
+
+type PostgresArchiver struct {
+	Mode             string     `json:"mode"`
+	ArchivedCount    int64      `json:"archivedCount"`
+	FailedCount      int64      `json:"failedCount"`
+	LastArchivedWAL  string     `json:"lastArchivedWal,omitempty"`
+	LastArchivedTime *time.Time `json:"lastArchivedTime,omitempty"`
+	LastFailedWAL    string     `json:"lastFailedWal,omitempty"`
+	LastFailedTime   *time.Time `json:"lastFailedTime,omitempty"`
+}
+
+type PostgresReplicationSlot struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Active   bool   `json:"active"`
+	LagBytes int64  `json:"lagBytes"`
+}
+
+type PostgresStandby struct {
+	Name      string  `json:"name"`
+	Addr      string  `json:"addr,omitempty"`
+	State     string  `json:"state"`
+	ReplayLag float64 `json:"replayLag"`
+}
+
+type PostgresBackupStatus struct {
+	Archiver   PostgresArchiver          `json:"archiver"`
+	Slots      []PostgresReplicationSlot `json:"slots,omitempty"`
+	Standbys   []PostgresStandby         `json:"standbys,omitempty"`
+	BaseBackup *File                     `json:"baseBackup,omitempty"`
+}
+
+// Test returns the reasons the backup status is unhealthy, maxAge, maxReplicationLag and maxSlotLag are ignored when zero
+func (s PostgresBackupStatus) Test(now time.Time, maxAge, maxReplicationLag time.Duration, maxSlotLag int64, checkBaseBackup bool) []string {
+	var errors []string
+
+	archiver := s.Archiver
+	if archiver.Mode != "off" {
+		if archiver.LastFailedTime != nil && (archiver.LastArchivedTime == nil || archiver.LastFailedTime.After(*archiver.LastArchivedTime)) {
+			errors = append(errors, fmt.Sprintf("archiving of WAL %s failed at %s (%d failures)", archiver.LastFailedWAL, archiver.LastFailedTime.Format(time.RFC3339), archiver.FailedCount))
+		}
+		if maxAge > 0 {
+			if archiver.LastArchivedTime == nil {
+				errors = append(errors, "no WAL has been archived")
+			} else if age := now.Sub(*archiver.LastArchivedTime); age > maxAge {
+				errors = append(errors, fmt.Sprintf("last WAL %s was archived %s ago", archiver.LastArchivedWAL, age.Round(time.Second)))
+			}
+		}
+	}
+
+	for _, slot := range s.Slots {
+		if maxSlotLag > 0 && slot.LagBytes > maxSlotLag {
+			errors = append(errors, fmt.Sprintf("replication slot %s is retaining %s of WAL", slot.Name, v1.Size(fmt.Sprintf("%dB", slot.LagBytes))))
+		}
+	}
+
+	for _, standby := range s.Standbys {
+		if standby.State != "streaming" {
+			errors = append(errors, fmt.Sprintf("standby %s is %s", standby.Name, standby.State))
+		} else if lag := time.Duration(standby.ReplayLag * float64(time.Second)); maxReplicationLag > 0 && lag > maxReplicationLag {
+			errors = append(errors, fmt.Sprintf("standby %s replay lag of %s exceeds %s", standby.Name, lag.Round(time.Millisecond), maxReplicationLag))
+		}
+	}
+
+	if checkBaseBackup {
+		if s.BaseBackup == nil {
+			errors = append(errors, "no base backups found")
+		} else if age := now.Sub(s.BaseBackup.Modified); maxAge > 0 && age > maxAge {
+			errors = append(errors, fmt.Sprintf("newest base backup %s is %s old", s.BaseBackup.Name, age.Round(time.Second)))
+		}
+	}
+
+	return errors
+}
+
+func PostgresDatabaseBackupCheck(ctx *context.Context, check v1.DatabaseBackupCheck) pkg.Results {
+	result := pkg.Success(check, ctx.Canary)
+	var results pkg.Results
+	results = append(results, result)
+
+	connection, err := ctx.GetConnection(check.Postgres.Connection)
+	if err != nil {
+		return results.Failf("error getting connection: %v", err)
+	}
+	endpoint := check.Postgres.Connection.GetEndpoint()
+	databaseScanObjectCount.WithLabelValues("", endpoint).Inc()
+
+	var maxAge, maxReplicationLag time.Duration
+	if check.MaxAge != "" {
+		d, err := check.MaxAge.GetDuration()
+		if err != nil {
+			return results.Invalidf("invalid maxAge: %v", err)
+		}
+		maxAge = *d
+	}
+	maxReplicationLag = maxAge
+	if check.Postgres.MaxReplicationLag != "" {
+		d, err := check.Postgres.MaxReplicationLag.GetDuration()
+		if err != nil {
+			return results.Invalidf("invalid maxReplicationLag: %v", err)
+		}
+		maxReplicationLag = *d
+	}
+	var maxSlotLag int64
+	if check.Postgres.MaxSlotLag != "" {
+		v, err := check.Postgres.MaxSlotLag.Value()
+		if err != nil {
+			return results.Invalidf("invalid maxSlotLag: %v", err)
+		}
+		maxSlotLag = *v
+	}
+
+	db, err := sqlPools.Get(ctx, ctx.Canary.GetKey(check), "postgres", connection.URL)
+	if err != nil {
+		databaseScanFailCount.WithLabelValues("", endpoint).Inc()
+		return results.Failf("failed to connect to db: %v", err)
+	}
+
+	status, err := queryPostgresBackupStatus(ctx, db)
+	if err != nil {
+		databaseScanFailCount.WithLabelValues("", endpoint).Inc()
+		return results.ErrorMessage(err)
+	}
+
+	if check.Postgres.BaseBackup != nil {
+		if status.BaseBackup, err = newestPostgresBaseBackup(ctx, *check.Postgres.BaseBackup); err != nil {
+			databaseScanFailCount.WithLabelValues("", endpoint).Inc()
+			result.AddDetails(status)
+			return results.Failf("failed to list base backups: %v", err)
+		}
+	}
+	result.AddDetails(status)
+
+	if errors := status.Test(time.Now(), maxAge, maxReplicationLag, maxSlotLag, check.Postgres.BaseBackup != nil); len(errors) > 0 {
+		databaseScanFailCount.WithLabelValues("", endpoint).Inc()
+		return results.Failf("%s", strings.Join(errors, ", "))
+	}
+	return results
+}
+
+func queryPostgresBackupStatus(ctx *context.Context, db *sql.DB) (PostgresBackupStatus, error) {
+	var status PostgresBackupStatus
+
+	var lastArchivedWAL, lastFailedWAL sql.NullString
+	var lastArchivedTime, lastFailedTime sql.NullTime
+	archiver := &status.Archiver
+	if err := db.QueryRowContext(ctx, postgresArchiverQuery).Scan(&archiver.Mode, &archiver.ArchivedCount, &archiver.FailedCount,
+		&lastArchivedWAL, &lastArchivedTime, &lastFailedWAL, &lastFailedTime); err != nil {
+		return status, fmt.Errorf("failed to query pg_stat_archiver: %w", err)
+	}
+	archiver.LastArchivedWAL = lastArchivedWAL.String
+	archiver.LastFailedWAL = lastFailedWAL.String
+	if lastArchivedTime.Valid {
+		archiver.LastArchivedTime = &lastArchivedTime.Time
+	}
+	if lastFailedTime.Valid {
+		archiver.LastFailedTime = &lastFailedTime.Time
+	}
+
+	rows, err := db.QueryContext(ctx, postgresSlotsQuery)
+	if err != nil {
+		return status, fmt.Errorf("failed to query pg_replication_slots: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var slot PostgresReplicationSlot
+		if err := rows.Scan(&slot.Name, &slot.Type, &slot.Active, &slot.LagBytes); err != nil {
+			return status, err
+		}
+		status.Slots = append(status.Slots, slot)
+	}
+	if err := rows.Err(); err != nil {
+		return status, err
+	}
+
+	standbys, err := db.QueryContext(ctx, postgresReplicationQuery)
+	if err != nil {
+		return status, fmt.Errorf("failed to query pg_stat_replication: %w", err)
+	}
+	defer standbys.Close()
+	for standbys.Next() {
+		var standby PostgresStandby
+		if err := standbys.Scan(&standby.Name, &standby.Addr, &standby.State, &standby.ReplayLag); err != nil {
+			return status, err
+		}
+		status.Standbys = append(status.Standbys, standby)
+	}
+	return status, standbys.Err()
+}
+
+// newestPostgresBaseBackup returns the most recently modified file in the base backup location
+func newestPostgresBaseBackup(ctx *context.Context, backup v1.PostgresBaseBackup) (*File, error) {
+	fs, path, err := getFolderFS(ctx, backup.Path, backup.S3Connection, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	folders, err := genericFolderCheck(ctx, fs, path, backup.Recursive, backup.Filter)
+	if err != nil {
+		return nil, err
+	}
+	if len(folders.Files) == 0 {
+		return nil, nil
+	}
+	return folders.Newest, nil
+}
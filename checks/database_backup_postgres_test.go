@@ -0,0 +1,114 @@
+package checks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/flanksource/canary-checker/api/context"
+	v1 "github.com/flanksource/canary-checker/api/v1"
+	dutyCtx "github.com/flanksource/duty/context"
+	. "github.com/onsi/gomega"
+)
+
+func TestPostgresBackupStatus(t *testing.T) {
+	RegisterTestingT(t)
+	now := time.Now()
+	archived := now.Add(-time.Hour)
+	failed := now.Add(-time.Minute)
+
+	healthy := PostgresBackupStatus{
+		Archiver:   PostgresArchiver{Mode: "on", ArchivedCount: 10, LastArchivedTime: &archived},
+		Slots:      []PostgresReplicationSlot{{Name: "standby", LagBytes: 1024}},
+		Standbys:   []PostgresStandby{{Name: "standby", State: "streaming", ReplayLag: 0.5}},
+		BaseBackup: &File{Name: "base.tar.gz", Modified: now.Add(-2 * time.Hour)},
+	}
+	Expect(healthy.Test(now, 6*time.Hour, time.Second, 1<<20, true)).To(BeEmpty())
+
+	unhealthy := healthy
+	unhealthy.Archiver.FailedCount = 1
+	unhealthy.Archiver.LastFailedTime = &failed
+	unhealthy.Slots = []PostgresReplicationSlot{{Name: "standby", LagBytes: 2 << 20}}
+	unhealthy.Standbys = []PostgresStandby{{Name: "standby", State: "streaming", ReplayLag: 5}, {Name: "other", State: "catchup"}}
+	unhealthy.BaseBackup = nil
+	Expect(unhealthy.Test(now, 30*time.Minute, time.Second, 1<<20, true)).To(HaveLen(6))
+
+	// archiving checks are skipped when archive_mode is off
+	Expect(PostgresBackupStatus{Archiver: PostgresArchiver{Mode: "off"}}.Test(now, time.Hour, 0, 0, false)).To(BeEmpty())
+}
+
+func TestQueryPostgresBackupStatus(t *testing.T) {
+	RegisterTestingT(t)
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	Expect(err).ToNot(HaveOccurred())
+	defer db.Close()
+
+	now := time.Now().UTC().Truncate(time.Second)
+	archived := now.Add(-2 * time.Hour)
+	failed := now.Add(-time.Minute)
+
+	mock.ExpectQuery(postgresArchiverQuery).WillReturnRows(sqlmock.NewRows(
+		[]string{"archive_mode", "archived_count", "failed_count", "last_archived_wal", "last_archived_time", "last_failed_wal", "last_failed_time"}).
+		AddRow("on", 10, 1, "000000010000000000000010", archived, "000000010000000000000011", failed))
+	mock.ExpectQuery(postgresSlotsQuery).WillReturnRows(sqlmock.NewRows(
+		[]string{"slot_name", "slot_type", "active", "lag"}).
+		AddRow("standby", "physical", true, 1024).
+		AddRow("stale", "logical", false, 2<<20))
+	mock.ExpectQuery(postgresReplicationQuery).WillReturnRows(sqlmock.NewRows(
+		[]string{"application_name", "client_addr", "state", "replay_lag"}).
+		AddRow("standby", "10.0.0.2/32", "streaming", 0.25).
+		AddRow("other", "", "catchup", 0.0))
+
+	ctx := context.New(dutyCtx.New(), v1.Canary{})
+	status, err := queryPostgresBackupStatus(ctx, db)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(mock.ExpectationsWereMet()).To(Succeed())
+
+	Expect(status.Archiver).To(Equal(PostgresArchiver{
+		Mode:             "on",
+		ArchivedCount:    10,
+		FailedCount:      1,
+		LastArchivedWAL:  "000000010000000000000010",
+		LastArchivedTime: &archived,
+		LastFailedWAL:    "000000010000000000000011",
+		LastFailedTime:   &failed,
+	}))
+	Expect(status.Slots).To(Equal([]PostgresReplicationSlot{
+		{Name: "standby", Type: "physical", Active: true, LagBytes: 1024},
+		{Name: "stale", Type: "logical", Active: false, LagBytes: 2 << 20},
+	}))
+	Expect(status.Standbys).To(Equal([]PostgresStandby{
+		{Name: "standby", Addr: "10.0.0.2/32", State: "streaming", ReplayLag: 0.25},
+		{Name: "other", State: "catchup"},
+	}))
+
+	Expect(status.Test(now, 6*time.Hour, time.Second, 1<<20, false)).To(ConsistOf(
+		ContainSubstring("archiving of WAL 000000010000000000000011 failed"),
+		ContainSubstring("replication slot stale is retaining"),
+		"standby other is catchup",
+	))
+}
+
+func TestQueryPostgresBackupStatusNeverArchived(t *testing.T) {
+	RegisterTestingT(t)
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	Expect(err).ToNot(HaveOccurred())
+	defer db.Close()
+
+	mock.ExpectQuery(postgresArchiverQuery).WillReturnRows(sqlmock.NewRows(
+		[]string{"archive_mode", "archived_count", "failed_count", "last_archived_wal", "last_archived_time", "last_failed_wal", "last_failed_time"}).
+		AddRow("on", 0, 0, nil, nil, nil, nil))
+	mock.ExpectQuery(postgresSlotsQuery).WillReturnRows(sqlmock.NewRows([]string{"slot_name", "slot_type", "active", "lag"}))
+	mock.ExpectQuery(postgresReplicationQuery).WillReturnRows(sqlmock.NewRows([]string{"application_name", "client_addr", "state", "replay_lag"}))
+
+	ctx := context.New(dutyCtx.New(), v1.Canary{})
+	status, err := queryPostgresBackupStatus(ctx, db)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(mock.ExpectationsWereMet()).To(Succeed())
+
+	Expect(status.Archiver.LastArchivedTime).To(BeNil())
+	Expect(status.Archiver.LastFailedTime).To(BeNil())
+	Expect(status.Slots).To(BeEmpty())
+	Expect(status.Standbys).To(BeEmpty())
+	Expect(status.Test(time.Now(), time.Hour, 0, 0, false)).To(ConsistOf("no WAL has been archived"))
+}
@@ -31,6 +31,7 @@ func (c *DNSChecker) Run(ctx *canaryContext.Context) pkg.Results {
 	return results
 }
 
+// resolvers implements every query type in v1.DNSQueryTypes, which is used to validate the check
 var resolvers = map[string]func(ctx context.Context, r *net.Resolver, check v1.DNSCheck) (bool, string, error){
 	"A":     checkA,
 	"CNAME": checkCNAME,
@@ -0,0 +1,14 @@
+package checks
+
+import (
+	"testing"
+
+	v1 "github.com/flanksource/canary-checker/api/v1"
+	. "github.com/onsi/gomega"
+	"github.com/samber/lo"
+)
+
+func TestDNSResolversMatchQueryTypes(t *testing.T) {
+	RegisterTestingT(t)
+	Expect(lo.Keys(resolvers)).To(ConsistOf(v1.DNSQueryTypes))
+}
@@ -1,6 +1,8 @@
 package checks
 
 import (
+	"errors"
+
 	"github.com/flanksource/canary-checker/api/context"
 	"github.com/flanksource/canary-checker/api/external"
 	v1 "github.com/flanksource/canary-checker/api/v1"
@@ -28,14 +30,20 @@ func (c *ExecChecker) Check(ctx *context.Context, extConfig external.Check) pkg.
 	check := extConfig.(v1.ExecCheck)
 	result := pkg.Success(check, ctx.Canary).AddDetails(shell.ExecDetails{ExitCode: -1})
 
-	details, err := shell.Run(ctx.Context, shell.Exec{
-		Script:      check.Script,
-		Connections: check.Connections,
-		Checkout:    check.Checkout,
-		EnvVars:     check.EnvVars,
-		Artifacts:   check.Artifacts,
-	})
-	if err != nil {
+	var details *shell.ExecDetails
+	var err error
+	switch check.GetMode() {
+	case v1.ExecModeLocal:
+		details, err = runExecLocal(ctx, check)
+	case v1.ExecModePod:
+		details, err = runExecPod(ctx, check)
+	default:
+		return result.Invalidf("unknown mode: %s", check.Mode)
+	}
+	if errors.Is(err, errExecTimedOut) {
+		result.AddDetails(details)
+		return result.Failf("%v", err).ToSlice()
+	} else if err != nil {
 		return result.ErrorMessage(err).Invalidf(err.Error())
 	}
 	if details != nil {
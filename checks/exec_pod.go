@@ -0,0 +1,251 @@
+package checks
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/flanksource/artifacts"
+	"github.com/flanksource/canary-checker/api/context"
+	v1 "github.com/flanksource/canary-checker/api/v1"
+	"github.com/flanksource/duty/connection"
+	"github.com/flanksource/duty/shell"
+	"github.com/flanksource/duty/types"
+	"github.com/samber/lo"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/rand"
+)
+
+const (
+	execContainerName  = "exec"
+	execContainerImage = "ubuntu"
+	execLabelValue     = "exec-check"
+	execPodTimeout     = 5 * time.Minute
+
+	// execPodServiceAccounts is the property with the comma separated service accounts that
+	// exec pods may run as, * allows any service account
+	execPodServiceAccounts = "checks.exec.pod.serviceAccounts"
+)
+
+// newExecPod creates a pod that runs the script, stdout is read from the logs and stderr
+// from the termination message which kubernetes truncates to 4KB
+func newExecPod(ctx *context.Context, check v1.ExecCheck, env []corev1.EnvVar) (*corev1.Pod, error) {
+	podConfig := lo.FromPtr(check.Pod)
+	limits := lo.FromPtr(check.Limits)
+
+	if limits.NoNetwork {
+		return nil, fmt.Errorf("noNetwork is not supported in pod mode")
+	}
+
+	interpreter, args := shell.DetectInterpreterFromShebang(check.Script)
+	command := append([]string{"sh", "-c", `"$@" 2>/dev/termination-log`, "sh", interpreter}, args...)
+	command = append(command, shell.TrimLine(check.Script, "#!"))
+
+	container := corev1.Container{
+		Name:                     execContainerName,
+		Image:                    lo.CoalesceOrEmpty(podConfig.Image, execContainerImage),
+		Command:                  command,
+		Env:                      env,
+		TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: lo.ToPtr(false),
+			Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+			ReadOnlyRootFilesystem:   lo.ToPtr(limits.ReadOnlyRoot),
+			RunAsUser:                limits.GetRunAsUser(),
+		},
+	}
+
+	cpuMax, memoryMax, err := cgroupLimits(limits)
+	if err != nil {
+		return nil, err
+	}
+	if cpuMax != "" || memoryMax != "" {
+		container.Resources.Limits = corev1.ResourceList{}
+	}
+	if cpuMax != "" {
+		container.Resources.Limits[corev1.ResourceCPU] = resource.MustParse(limits.CPU)
+	}
+	if memoryMax != "" {
+		container.Resources.Limits[corev1.ResourceMemory] = resource.MustParse(limits.Memory)
+	}
+
+	if podConfig.ServiceAccount != "" && !execPodServiceAccountAllowed(podConfig.ServiceAccount, ctx.Properties().String(execPodServiceAccounts, "")) {
+		return nil, fmt.Errorf("service account %s is not allowed, it must be listed in the %s property", podConfig.ServiceAccount, execPodServiceAccounts)
+	}
+
+	pod := &corev1.Pod{}
+	pod.APIVersion = corev1.SchemeGroupVersion.Version
+	pod.Kind = podKind
+	// the pod is created in the namespace of the canary, so that canaries cannot run scripts in other tenants
+	pod.Namespace = ctx.Namespace
+	pod.Name = ctx.Canary.Name + "-" + strings.ToLower(rand.String(5))
+	pod.Labels = map[string]string{
+		junitCheckSelector: getJunitCheckLabel(execLabelValue, ctx.Canary.Name, ctx.Namespace),
+	}
+	pod.Spec.RestartPolicy = corev1.RestartPolicyNever
+	pod.Spec.ServiceAccountName = podConfig.ServiceAccount
+	pod.Spec.NodeSelector = podConfig.NodeSelector
+
+	if limits.ReadOnlyRoot {
+		pod.Spec.Volumes = []corev1.Volume{{Name: "tmp", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}}
+		container.VolumeMounts = []corev1.VolumeMount{{Name: "tmp", MountPath: "/tmp"}}
+	}
+
+	if limits.Timeout != "" {
+		timeout, err := limits.Timeout.GetDuration()
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout: %w", err)
+		}
+		pod.Spec.ActiveDeadlineSeconds = lo.ToPtr(int64(timeout.Seconds()))
+	}
+
+	pod.Spec.Containers = []corev1.Container{container}
+	return pod, nil
+}
+
+// runExecPod runs the script in an ephemeral pod and waits for it to complete
+func runExecPod(ctx *context.Context, check v1.ExecCheck) (*shell.ExecDetails, error) {
+	if ctx.KubernetesClient() == nil {
+		return nil, fmt.Errorf("kubernetes is not initialized")
+	}
+	if check.Checkout != nil || check.Connections != (connection.ExecConnections{}) {
+		return nil, fmt.Errorf("checkout and connections are not supported in pod mode")
+	}
+
+	env, err := execPodEnv(ctx, check.EnvVars)
+	if err != nil {
+		return nil, err
+	}
+
+	pod, err := newExecPod(ctx, check, env)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := execPodTimeout
+	if pod.Spec.ActiveDeadlineSeconds != nil {
+		// allow time for the pod to be scheduled and the image pulled
+		timeout = time.Duration(*pod.Spec.ActiveDeadlineSeconds)*time.Second + execPodTimeout
+	}
+
+	pods := ctx.Kubernetes().CoreV1().Pods(pod.Namespace)
+	if _, err := pods.Create(ctx, pod, metav1.CreateOptions{}); err != nil {
+		return nil, err
+	}
+	defer deletePod(ctx, pod)
+
+	status, terminated, err := waitForExecPod(ctx, pod, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	details := &shell.ExecDetails{
+		Path:     pod.Namespace + "/" + pod.Name,
+		Args:     pod.Spec.Containers[0].Command,
+		ExitCode: int(terminated.ExitCode),
+		Stderr:   strings.TrimSpace(terminated.Message),
+	}
+	if status.Reason == "DeadlineExceeded" {
+		return details, fmt.Errorf("%w after %s", errExecTimedOut, check.Limits.Timeout)
+	}
+
+	reader, err := ctx.KubernetesClient().GetPodLogs(ctx, pod.Namespace, pod.Name, execContainerName)
+	if err != nil {
+		return details, fmt.Errorf("failed to get pod logs: %w", err)
+	}
+	defer reader.Close()
+	stdout, err := io.ReadAll(reader)
+	if err != nil {
+		return details, fmt.Errorf("failed to read pod logs: %w", err)
+	}
+	details.Stdout = strings.TrimSpace(string(stdout))
+
+	for _, artifact := range check.Artifacts {
+		switch artifact.Path {
+		case "/dev/stdout":
+			details.Artifacts = append(details.Artifacts, artifacts.Artifact{Path: "stdout", Content: io.NopCloser(strings.NewReader(details.Stdout))})
+		case "/dev/stderr":
+			details.Artifacts = append(details.Artifacts, artifacts.Artifact{Path: "stderr", Content: io.NopCloser(strings.NewReader(details.Stderr))})
+		default:
+			ctx.Warnf("artifact %s is not supported in pod mode, only /dev/stdout and /dev/stderr are", artifact.Path)
+		}
+	}
+	return details, nil
+}
+
+// execPodEnv returns the env of the script container. Secret and configmap values are referenced
+// rather than resolved, so that they cannot be read from the pod spec
+func execPodEnv(ctx *context.Context, envVars []types.EnvVar) ([]corev1.EnvVar, error) {
+	var env []corev1.EnvVar
+	for _, envVar := range envVars {
+		from := lo.FromPtr(envVar.ValueFrom)
+		switch {
+		case from.SecretKeyRef != nil && !from.SecretKeyRef.IsEmpty():
+			env = append(env, corev1.EnvVar{Name: envVar.Name, ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: from.SecretKeyRef.Name},
+					Key:                  from.SecretKeyRef.Key,
+				},
+			}})
+		case from.ConfigMapKeyRef != nil && !from.ConfigMapKeyRef.IsEmpty():
+			env = append(env, corev1.EnvVar{Name: envVar.Name, ValueFrom: &corev1.EnvVarSource{
+				ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: from.ConfigMapKeyRef.Name},
+					Key:                  from.ConfigMapKeyRef.Key,
+				},
+			}})
+		case !from.IsEmpty():
+			return nil, fmt.Errorf("env %s: only secretKeyRef and configMapKeyRef values are supported in pod mode", envVar.Name)
+		default:
+			value, err := ctx.GetEnvValueFromCache(envVar, ctx.GetNamespace())
+			if err != nil {
+				return nil, fmt.Errorf("error fetching env value (name=%s): %w", envVar.Name, err)
+			}
+			env = append(env, corev1.EnvVar{Name: envVar.Name, Value: value})
+		}
+	}
+	return env, nil
+}
+
+// execPodServiceAccountAllowed returns true if the service account is in the comma separated allow list
+func execPodServiceAccountAllowed(serviceAccount, allowed string) bool {
+	for _, sa := range strings.Split(allowed, ",") {
+		if sa = strings.TrimSpace(sa); sa == "*" || sa == serviceAccount {
+			return true
+		}
+	}
+	return false
+}
+
+// waitForExecPod waits for the script container to terminate
+func waitForExecPod(ctx *context.Context, pod *corev1.Pod, timeout time.Duration) (corev1.PodStatus, *corev1.ContainerStateTerminated, error) {
+	pods := ctx.Kubernetes().CoreV1().Pods(pod.Namespace)
+	deadline := time.Now().Add(timeout)
+	for {
+		current, err := pods.Get(ctx, pod.Name, metav1.GetOptions{})
+		if err != nil {
+			return corev1.PodStatus{}, nil, err
+		}
+		for _, status := range current.Status.ContainerStatuses {
+			if status.Name == execContainerName && status.State.Terminated != nil {
+				return current.Status, status.State.Terminated, nil
+			}
+		}
+		if current.Status.Phase == corev1.PodFailed {
+			// the pod was killed before the container started, e.g. by activeDeadlineSeconds
+			return current.Status, &corev1.ContainerStateTerminated{ExitCode: -1, Message: current.Status.Message}, nil
+		}
+		if time.Now().After(deadline) {
+			return current.Status, nil, fmt.Errorf("timed out waiting for pod %s/%s, phase=%s", pod.Namespace, pod.Name, current.Status.Phase)
+		}
+
+		select {
+		case <-ctx.Done():
+			return current.Status, nil, ctx.Err()
+		case <-time.After(2 * time.Second):
+		}
+	}
+}
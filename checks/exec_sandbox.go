@@ -0,0 +1,205 @@
+package checks
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	osExec "os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/flanksource/canary-checker/api/context"
+	v1 "github.com/flanksource/canary-checker/api/v1"
+	"github.com/flanksource/duty/shell"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/rand"
+)
+
+const (
+	// execCgroupRoot is the cgroup v2 hierarchy that limited exec checks are placed in
+	execCgroupRoot = "/sys/fs/cgroup/canary-checker"
+	// cgroupCPUPeriod is the cpu.max period in microseconds
+	cgroupCPUPeriod = 100000
+	// timeoutExitCode is the exit code of coreutils timeout when the command times out
+	timeoutExitCode = 124
+)
+
+// errExecTimedOut is returned when the script is killed for exceeding the timeout limit,
+// it fails the check rather than marking it invalid
+var errExecTimedOut = errors.New("script timed out")
+
+// runExecLocal runs the script on the canary-checker host, wrapping it in a cgroup and
+// sandbox when limits are specified
+func runExecLocal(ctx *context.Context, check v1.ExecCheck) (*shell.ExecDetails, error) {
+	exec := shell.Exec{
+		Script:      check.Script,
+		Connections: check.Connections,
+		Checkout:    check.Checkout,
+		EnvVars:     check.EnvVars,
+		Artifacts:   check.Artifacts,
+	}
+	if check.Limits == nil {
+		return shell.Run(ctx.Context, exec)
+	}
+
+	limits := *check.Limits
+	runAsUser := limits.GetRunAsUser()
+	if runAsUser != nil && !canSwitchUser() {
+		ctx.Warnf("running script as the current user instead of %d, switching users requires root and setpriv", *runAsUser)
+		runAsUser = nil
+	}
+	if (limits.NoNetwork || limits.ReadOnlyRoot) && !hasCommand("bwrap") {
+		ctx.Warnf("running script without noNetwork and readOnlyRoot, they require bwrap")
+		limits.NoNetwork, limits.ReadOnlyRoot = false, false
+	}
+
+	cgroup, err := newExecCgroup(ctx, limits)
+	if err != nil {
+		return nil, err
+	}
+	if cgroup != "" {
+		defer removeExecCgroup(ctx, cgroup)
+	}
+
+	if exec.Script, err = sandboxedScript(check.Script, limits, runAsUser, cgroup, check.Checkout != nil); err != nil {
+		return nil, err
+	}
+
+	details, err := shell.Run(ctx.Context, exec)
+	if details != nil && limits.Timeout != "" && details.ExitCode == timeoutExitCode {
+		return details, fmt.Errorf("%w after %s", errExecTimedOut, limits.Timeout)
+	}
+	return details, err
+}
+
+// canSwitchUser returns true if the script can be run as another user with setpriv
+func canSwitchUser() bool {
+	return os.Geteuid() == 0 && hasCommand("setpriv")
+}
+
+func hasCommand(name string) bool {
+	_, err := osExec.LookPath(name)
+	return err == nil
+}
+
+// sandboxedScript wraps a script so that it runs with the given limits:
+// timeout enforces the wall-clock limit, bwrap creates the network and mount namespaces
+// and setpriv switches to runAsUser without any capabilities
+func sandboxedScript(script string, limits v1.ExecLimits, runAsUser *int64, cgroup string, checkout bool) (string, error) {
+	interpreter, args := shell.DetectInterpreterFromShebang(script)
+	command := []string{shellQuote(interpreter)}
+	for _, arg := range append(args, shell.TrimLine(script, "#!")) {
+		command = append(command, shellQuote(arg))
+	}
+
+	if runAsUser != nil {
+		command = append([]string{
+			"setpriv",
+			fmt.Sprintf("--reuid=%d", *runAsUser),
+			fmt.Sprintf("--regid=%d", *runAsUser),
+			"--clear-groups",
+			"--inh-caps=-all",
+			"--bounding-set=-all",
+			"--",
+		}, command...)
+	}
+
+	if limits.NoNetwork || limits.ReadOnlyRoot {
+		bwrap := []string{"bwrap", "--die-with-parent", "--unshare-pid"}
+		if limits.ReadOnlyRoot {
+			bwrap = append(bwrap, "--ro-bind", "/", "/", "--dev", "/dev", "--proc", "/proc", "--tmpfs", "/tmp")
+			if checkout {
+				// the checkout is mounted under /tmp, so it needs to be bound again after the scratch dir
+				bwrap = append(bwrap, "--bind", `"$PWD"`, `"$PWD"`)
+			}
+		} else {
+			bwrap = append(bwrap, "--bind", "/", "/", "--dev", "/dev", "--proc", "/proc")
+		}
+		bwrap = append(bwrap, "--chdir", `"$PWD"`)
+		if limits.NoNetwork {
+			bwrap = append(bwrap, "--unshare-net")
+		}
+		command = append(append(bwrap, "--"), command...)
+	}
+
+	if limits.Timeout != "" {
+		timeout, err := limits.Timeout.GetDuration()
+		if err != nil {
+			return "", fmt.Errorf("invalid timeout: %w", err)
+		}
+		// timeout kills the whole process group, unlike the context which only kills the interpreter
+		command = append([]string{"timeout", "--kill-after=10s", fmt.Sprintf("%ds", int(timeout.Round(time.Second).Seconds()))}, command...)
+	}
+
+	wrapped := "exec " + strings.Join(command, " ")
+	if cgroup != "" {
+		wrapped = fmt.Sprintf("echo $$ > %s && %s", shellQuote(filepath.Join(cgroup, "cgroup.procs")), wrapped)
+	}
+	return wrapped, nil
+}
+
+// cgroupLimits returns the cpu.max and memory.max values for the limits, empty values are unlimited
+func cgroupLimits(limits v1.ExecLimits) (string, string, error) {
+	var cpuMax, memoryMax string
+	if limits.CPU != "" {
+		cpu, err := resource.ParseQuantity(limits.CPU)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid cpu limit %s: %w", limits.CPU, err)
+		}
+		cpuMax = fmt.Sprintf("%d %d", cpu.MilliValue()*cgroupCPUPeriod/1000, cgroupCPUPeriod)
+	}
+	if limits.Memory != "" {
+		memory, err := resource.ParseQuantity(limits.Memory)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid memory limit %s: %w", limits.Memory, err)
+		}
+		memoryMax = fmt.Sprintf("%d", memory.Value())
+	}
+	return cpuMax, memoryMax, nil
+}
+
+// newExecCgroup creates a cgroup with the cpu and memory limits, returning an empty path if there are no limits
+// or the limits cannot be enforced on this host
+func newExecCgroup(ctx *context.Context, limits v1.ExecLimits) (string, error) {
+	cpuMax, memoryMax, err := cgroupLimits(limits)
+	if err != nil || (cpuMax == "" && memoryMax == "") {
+		return "", err
+	}
+
+	if err := os.MkdirAll(execCgroupRoot, 0755); err != nil {
+		ctx.Warnf("running script without cpu and memory limits, they require a writable cgroup v2 hierarchy: %v", err)
+		return "", nil
+	}
+	if err := os.WriteFile(filepath.Join(execCgroupRoot, "cgroup.subtree_control"), []byte("+cpu +memory"), 0644); err != nil {
+		ctx.Warnf("running script without cpu and memory limits, failed to enable the cpu and memory controllers: %v", err)
+		return "", nil
+	}
+
+	cgroup := filepath.Join(execCgroupRoot, "exec-"+strings.ToLower(rand.String(8)))
+	if err := os.Mkdir(cgroup, 0755); err != nil {
+		return "", fmt.Errorf("failed to create cgroup: %w", err)
+	}
+	for file, value := range map[string]string{"cpu.max": cpuMax, "memory.max": memoryMax} {
+		if value == "" {
+			continue
+		}
+		if err := os.WriteFile(filepath.Join(cgroup, file), []byte(value), 0644); err != nil {
+			_ = os.Remove(cgroup)
+			return "", fmt.Errorf("failed to set %s: %w", file, err)
+		}
+	}
+	return cgroup, nil
+}
+
+// removeExecCgroup kills any processes left behind by the script and removes the cgroup
+func removeExecCgroup(ctx *context.Context, cgroup string) {
+	_ = os.WriteFile(filepath.Join(cgroup, "cgroup.kill"), []byte("1"), 0644)
+	for i := 0; i < 10; i++ {
+		if err := os.Remove(cgroup); err == nil || os.IsNotExist(err) {
+			return
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	ctx.Warnf("failed to remove cgroup %s", cgroup)
+}
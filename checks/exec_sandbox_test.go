@@ -0,0 +1,115 @@
+package checks
+
+import (
+	"testing"
+
+	"github.com/flanksource/canary-checker/api/context"
+	v1 "github.com/flanksource/canary-checker/api/v1"
+	dutyCtx "github.com/flanksource/duty/context"
+	"github.com/flanksource/duty/types"
+	. "github.com/onsi/gomega"
+	"github.com/samber/lo"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSandboxedScript(t *testing.T) {
+	RegisterTestingT(t)
+
+	script, err := sandboxedScript("echo hello", v1.ExecLimits{Timeout: "1m"}, nil, "", false)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(script).To(Equal(`exec timeout --kill-after=10s 60s 'bash' '-c' 'echo hello'`))
+
+	limits := v1.ExecLimits{NoNetwork: true, ReadOnlyRoot: true}
+	script, err = sandboxedScript("#!/usr/bin/env python3\nprint('hi')", limits, limits.GetRunAsUser(), "/sys/fs/cgroup/canary-checker/exec-1", true)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(script).To(HavePrefix(`echo $$ > '/sys/fs/cgroup/canary-checker/exec-1/cgroup.procs' && exec bwrap --die-with-parent --unshare-pid --ro-bind / / --dev /dev --proc /proc --tmpfs /tmp --bind "$PWD" "$PWD" --chdir "$PWD" --unshare-net -- setpriv --reuid=65534 --regid=65534`))
+	Expect(script).To(HaveSuffix(`-- '/usr/bin/env' 'python3' 'print('"'"'hi'"'"')'`))
+
+	_, err = sandboxedScript("echo", v1.ExecLimits{Timeout: "soon"}, nil, "", false)
+	Expect(err).To(HaveOccurred())
+}
+
+func TestCgroupLimits(t *testing.T) {
+	RegisterTestingT(t)
+
+	cpuMax, memoryMax, err := cgroupLimits(v1.ExecLimits{CPU: "500m", Memory: "256Mi"})
+	Expect(err).ToNot(HaveOccurred())
+	Expect(cpuMax).To(Equal("50000 100000"))
+	Expect(memoryMax).To(Equal("268435456"))
+
+	cpuMax, memoryMax, err = cgroupLimits(v1.ExecLimits{})
+	Expect(err).ToNot(HaveOccurred())
+	Expect(cpuMax).To(BeEmpty())
+	Expect(memoryMax).To(BeEmpty())
+
+	_, _, err = cgroupLimits(v1.ExecLimits{CPU: "lots"})
+	Expect(err).To(HaveOccurred())
+}
+
+func TestNewExecPod(t *testing.T) {
+	RegisterTestingT(t)
+	ctx := context.New(dutyCtx.New(), v1.Canary{ObjectMeta: metav1.ObjectMeta{Name: "exec", Namespace: "canaries"}})
+
+	pod, err := newExecPod(ctx, v1.ExecCheck{
+		Script: "echo hello",
+		Limits: &v1.ExecLimits{CPU: "1", Memory: "64Mi", Timeout: "2m", ReadOnlyRoot: true},
+		Pod:    &v1.ExecPod{Image: "alpine"},
+	}, nil)
+	Expect(err).ToNot(HaveOccurred())
+
+	container := pod.Spec.Containers[0]
+	Expect(container.Image).To(Equal("alpine"))
+	Expect(container.Command).To(Equal([]string{"sh", "-c", `"$@" 2>/dev/termination-log`, "sh", "bash", "-c", "echo hello"}))
+	Expect(container.Resources.Limits.Cpu().String()).To(Equal("1"))
+	Expect(container.Resources.Limits.Memory().String()).To(Equal("64Mi"))
+	Expect(*container.SecurityContext.ReadOnlyRootFilesystem).To(BeTrue())
+	Expect(*container.SecurityContext.RunAsUser).To(Equal(int64(65534)))
+	Expect(container.VolumeMounts[0].MountPath).To(Equal("/tmp"))
+	Expect(*pod.Spec.ActiveDeadlineSeconds).To(Equal(int64(120)))
+	Expect(pod.Spec.RestartPolicy).To(Equal(corev1.RestartPolicyNever))
+
+	Expect(pod.Namespace).To(Equal("canaries"))
+	Expect(pod.Spec.ServiceAccountName).To(BeEmpty())
+
+	_, err = newExecPod(ctx, v1.ExecCheck{Script: "echo", Limits: &v1.ExecLimits{NoNetwork: true}}, nil)
+	Expect(err).To(HaveOccurred())
+
+	// service accounts must be allowed by the operator
+	_, err = newExecPod(ctx, v1.ExecCheck{Script: "echo", Pod: &v1.ExecPod{ServiceAccount: "admin"}}, nil)
+	Expect(err).To(MatchError(ContainSubstring("service account admin is not allowed")))
+	Expect(execPodServiceAccountAllowed("admin", "")).To(BeFalse())
+	Expect(execPodServiceAccountAllowed("admin", "runner, admin")).To(BeTrue())
+	Expect(execPodServiceAccountAllowed("admin", "*")).To(BeTrue())
+}
+
+func TestExecPodEnv(t *testing.T) {
+	RegisterTestingT(t)
+	ctx := context.New(dutyCtx.New(), v1.Canary{ObjectMeta: metav1.ObjectMeta{Name: "exec", Namespace: "canaries"}})
+
+	env, err := execPodEnv(ctx, []types.EnvVar{
+		{Name: "GREETING", ValueStatic: "hello"},
+		{Name: "PASSWORD", ValueFrom: &types.EnvVarSource{SecretKeyRef: &types.SecretKeySelector{LocalObjectReference: types.LocalObjectReference{Name: "db"}, Key: "password"}}},
+		{Name: "HOST", ValueFrom: &types.EnvVarSource{ConfigMapKeyRef: &types.ConfigMapKeySelector{LocalObjectReference: types.LocalObjectReference{Name: "db"}, Key: "host"}}},
+	})
+	Expect(err).ToNot(HaveOccurred())
+	Expect(env).To(Equal([]corev1.EnvVar{
+		{Name: "GREETING", Value: "hello"},
+		{Name: "PASSWORD", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "db"}, Key: "password"}}},
+		{Name: "HOST", ValueFrom: &corev1.EnvVarSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "db"}, Key: "host"}}},
+	}))
+
+	_, err = execPodEnv(ctx, []types.EnvVar{{Name: "TOKEN", ValueFrom: &types.EnvVarSource{ServiceAccount: lo.ToPtr("default")}}})
+	Expect(err).To(HaveOccurred())
+}
+
+func TestExecTimeoutFails(t *testing.T) {
+	RegisterTestingT(t)
+	ctx := context.New(dutyCtx.New(), v1.Canary{ObjectMeta: metav1.ObjectMeta{Name: "exec", Namespace: "canaries"}})
+
+	results := (&ExecChecker{}).Check(ctx, v1.ExecCheck{Script: "sleep 10", Limits: &v1.ExecLimits{Timeout: "1s"}})
+	Expect(results).To(HaveLen(1))
+	Expect(results[0].Pass).To(BeFalse())
+	Expect(results[0].Invalid).To(BeFalse())
+	Expect(results[0].Error).To(Equal("script timed out after 1s"))
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/flanksource/canary-checker/api/external"
 	v1 "github.com/flanksource/canary-checker/api/v1"
 	"github.com/flanksource/canary-checker/pkg"
+	"github.com/flanksource/duty/connection"
 	"github.com/flanksource/duty/models"
 )
 
@@ -102,6 +103,9 @@ func checkLocalFolder(ctx *context.Context, check v1.FolderCheck) pkg.Results {
 	}
 
 	folders, err := genericFolderCheck(ctx, localFS, check.Path, check.Recursive, check.Filter)
+	if err == nil {
+		err = readFolderContent(ctx, localFS, check.Path, &folders, check.Content)
+	}
 	result.AddDetails(folders)
 
 	if err != nil {
@@ -114,6 +118,19 @@ func checkLocalFolder(ctx *context.Context, check v1.FolderCheck) pkg.Results {
 	return results
 }
 
+// getFolderFS returns the filesystem for a local path, or an s3:// or gcs:// url along with the path inside the bucket
+func getFolderFS(ctx *context.Context, path string, s3 *connection.S3Connection, gcs *connection.GCSConnection) (artifactFS.Filesystem, string, error) {
+	switch {
+	case strings.HasPrefix(path, "s3://"):
+		return getS3FS(ctx, s3, path)
+	case strings.HasPrefix(path, "gcs://"):
+		return getGCSFS(ctx, gcs, path)
+	default:
+		fs, err := artifacts.GetFSForConnection(ctx.Context, models.Connection{Type: models.ConnectionTypeFolder})
+		return fs, path, err
+	}
+}
+
 func genericFolderCheck(ctx *context.Context, dirFS artifactFS.Filesystem, path string, recursive bool, filter v1.FolderFilter) (FolderCheck, error) {
 	result := FolderCheck{}
 	_filter, err := filter.New()
@@ -5,6 +5,7 @@ import (
 	"os"
 	"time"
 
+	artifactFS "github.com/flanksource/artifacts/fs"
 	v1 "github.com/flanksource/canary-checker/api/v1"
 )
 
@@ -16,6 +17,8 @@ type FolderCheck struct {
 	TotalSize     int64  `json:"size,omitempty"`
 	AvailableSize int64  `json:"availableSize,omitempty"`
 	Files         []File `json:"files"`
+	// Content of the newest files when content parsing is enabled
+	Content []FileContent `json:"content,omitempty"`
 }
 
 type File struct {
@@ -24,16 +27,22 @@ type File struct {
 	Mode     string    `json:"mode,omitempty"`
 	Modified time.Time `json:"modified"`
 	IsDir    bool      `json:"is_dir,omitempty"`
+	// path is the full path of the file, if known by the filesystem
+	path string
 }
 
 func newFile(file os.FileInfo) *File {
-	return &File{
+	f := &File{
 		Name:     file.Name(),
 		Size:     file.Size(),
 		Mode:     file.Mode().String(),
 		Modified: file.ModTime().UTC(),
 		IsDir:    file.IsDir(),
 	}
+	if info, ok := file.(artifactFS.FileInfo); ok {
+		f.path = info.FullPath()
+	}
+	return f
 }
 
 func (f *FolderCheck) Append(osFile os.FileInfo) {
@@ -70,6 +79,15 @@ func (f FolderCheck) Test(test v1.FolderTest) string {
 		return fmt.Sprintf("too many files %d > %d", len(f.Files), *test.MaxCount)
 	}
 
+	for _, content := range f.Content {
+		if content.Error != "" {
+			return content.Error
+		}
+		if content.Checksum != "" && !content.ChecksumMatches() {
+			return fmt.Sprintf("checksum of %s does not match: %s", content.Name, content.Checksum)
+		}
+	}
+
 	if test.AvailableSize != "" {
 		if f.AvailableSize == SizeNotSupported {
 			return "available size not supported"
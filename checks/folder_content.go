@@ -0,0 +1,195 @@
+package checks
+
+import (
+	"bytes"
+	"crypto/md5" // nolint: gosec
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	artifactFS "github.com/flanksource/artifacts/fs"
+	"github.com/flanksource/canary-checker/api/context"
+	v1 "github.com/flanksource/canary-checker/api/v1"
+	"sigs.k8s.io/yaml"
+)
+
+var checksumExtensions = []string{".sha256", ".md5"}
+
+type FileContent struct {
+	Name     string    `json:"name"`
+	Size     int64     `json:"size"`
+	Modified time.Time `json:"modified"`
+	Format   string    `json:"format"`
+	SHA256   string    `json:"sha256"`
+	MD5      string    `json:"md5"`
+	// Checksum is the expected checksum read from the sidecar file
+	Checksum string `json:"checksum,omitempty"`
+	// ChecksumAlgorithm is the algorithm of the checksum, md5 or sha256 from the extension of the sidecar file
+	ChecksumAlgorithm string `json:"checksumAlgorithm,omitempty"`
+	// Data is the parsed content, csv files are parsed into a list of rows keyed by the header
+	Data  any    `json:"data,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// ChecksumMatches returns true if the expected checksum matches the checksum of the sidecar's algorithm
+func (f FileContent) ChecksumMatches() bool {
+	switch f.ChecksumAlgorithm {
+	case "sha256":
+		return strings.EqualFold(f.Checksum, f.SHA256)
+	case "md5":
+		return strings.EqualFold(f.Checksum, f.MD5)
+	}
+	return false
+}
+
+// readFolderContent reads and parses the newest files in the folder
+func readFolderContent(ctx *context.Context, fs artifactFS.Filesystem, path string, folders *FolderCheck, content *v1.FolderContent) error {
+	if content == nil {
+		return nil
+	}
+
+	rw, ok := fs.(artifactFS.FilesystemRW)
+	if !ok {
+		return fmt.Errorf("reading file contents is not supported for %s", path)
+	}
+
+	maxSize, err := content.GetMaxSize()
+	if err != nil {
+		return err
+	}
+
+	var files []File
+	for _, file := range folders.Files {
+		if !file.IsDir && !isChecksumFile(file.Name) {
+			files = append(files, file)
+		}
+	}
+	sort.SliceStable(files, func(i, j int) bool {
+		return files[i].Modified.After(files[j].Modified)
+	})
+	if len(files) > content.GetLimit() {
+		files = files[:content.GetLimit()]
+	}
+
+	for _, file := range files {
+		filePath := file.path
+		if filePath == "" {
+			filePath = filepath.Join(path, file.Name)
+		}
+		if file.Size > maxSize {
+			return fmt.Errorf("%s is larger than %s", file.Name, content.MaxSize)
+		}
+
+		data, err := readFile(ctx, rw, filePath, maxSize)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", filePath, err)
+		}
+
+		fileContent := newFileContent(file, data, content.GetFormat(file.Name))
+		if content.VerifyChecksum {
+			if fileContent.Checksum, fileContent.ChecksumAlgorithm = readChecksumFile(ctx, rw, filePath); fileContent.Checksum == "" {
+				fileContent.Error = fmt.Sprintf("no checksum file found for %s", file.Name)
+			}
+		}
+		folders.Content = append(folders.Content, fileContent)
+	}
+	return nil
+}
+
+func newFileContent(file File, data []byte, format string) FileContent {
+	sha := sha256.Sum256(data)
+	md := md5.Sum(data) // nolint: gosec
+	content := FileContent{
+		Name:     file.Name,
+		Size:     int64(len(data)),
+		Modified: file.Modified,
+		Format:   format,
+		SHA256:   hex.EncodeToString(sha[:]),
+		MD5:      hex.EncodeToString(md[:]),
+	}
+
+	var err error
+	if content.Data, err = parseFileContent(data, format); err != nil {
+		content.Error = fmt.Sprintf("failed to parse %s as %s: %v", file.Name, format, err)
+	}
+	return content
+}
+
+func parseFileContent(data []byte, format string) (any, error) {
+	var result any
+	switch format {
+	case v1.FolderContentJSON:
+		err := json.Unmarshal(data, &result)
+		return result, err
+	case v1.FolderContentYAML:
+		err := yaml.Unmarshal(data, &result)
+		return result, err
+	case v1.FolderContentCSV:
+		records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+		if err != nil || len(records) == 0 {
+			return nil, err
+		}
+		rows := make([]map[string]string, 0, len(records)-1)
+		for _, record := range records[1:] {
+			row := make(map[string]string, len(record))
+			for i, header := range records[0] {
+				if i < len(record) {
+					row[header] = record[i]
+				}
+			}
+			rows = append(rows, row)
+		}
+		return rows, nil
+	case v1.FolderContentLines:
+		return strings.Split(strings.TrimRight(string(data), "\r\n"), "\n"), nil
+	}
+	return nil, fmt.Errorf("unknown format %s", format)
+}
+
+func readFile(ctx *context.Context, fs artifactFS.FilesystemRW, path string, maxSize int64) ([]byte, error) {
+	reader, err := fs.Read(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(io.LimitReader(reader, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxSize {
+		return nil, fmt.Errorf("file is larger than %d bytes", maxSize)
+	}
+	return data, nil
+}
+
+// readChecksumFile returns the checksum and its algorithm from the first sidecar file found,
+// e.g. the output of `sha256sum file > file.sha256`
+func readChecksumFile(ctx *context.Context, fs artifactFS.FilesystemRW, path string) (string, string) {
+	for _, ext := range checksumExtensions {
+		data, err := readFile(ctx, fs, path+ext, 1024)
+		if err != nil {
+			continue
+		}
+		if fields := strings.Fields(string(data)); len(fields) > 0 {
+			return fields[0], strings.TrimPrefix(ext, ".")
+		}
+	}
+	return "", ""
+}
+
+func isChecksumFile(name string) bool {
+	for _, ext := range checksumExtensions {
+		if strings.HasSuffix(strings.ToLower(name), ext) {
+			return true
+		}
+	}
+	return false
+}
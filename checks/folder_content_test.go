@@ -0,0 +1,87 @@
+package checks
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/flanksource/canary-checker/api/context"
+	v1 "github.com/flanksource/canary-checker/api/v1"
+	dutyCtx "github.com/flanksource/duty/context"
+	. "github.com/onsi/gomega"
+)
+
+func TestParseFileContent(t *testing.T) {
+	RegisterTestingT(t)
+
+	data, err := parseFileContent([]byte("name,count\na,1\nb,2\n"), v1.FolderContentCSV)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(data).To(Equal([]map[string]string{{"name": "a", "count": "1"}, {"name": "b", "count": "2"}}))
+
+	data, err = parseFileContent([]byte("items:\n  - a\n"), v1.FolderContentYAML)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(data).To(Equal(map[string]any{"items": []any{"a"}}))
+
+	data, err = parseFileContent([]byte("first\nsecond\n"), v1.FolderContentLines)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(data).To(Equal([]string{"first", "second"}))
+
+	_, err = parseFileContent([]byte("{"), v1.FolderContentJSON)
+	Expect(err).To(HaveOccurred())
+}
+
+func TestFolderContent(t *testing.T) {
+	RegisterTestingT(t)
+	dir := t.TempDir()
+
+	write := func(name, content string, modified time.Time) {
+		path := filepath.Join(dir, name)
+		Expect(os.WriteFile(path, []byte(content), 0600)).To(Succeed())
+		Expect(os.Chtimes(path, modified, modified)).To(Succeed())
+	}
+	write("old.json", `{"rows": 1}`, time.Now().Add(-time.Hour))
+	write("export.json", `{"rows": 2}`, time.Now())
+	write("export.json.sha256", "ddd3bf7d1c0c36e6ae6c3ef72b7b0ad6c6cdc0bdfa04db26b4b04a13e6f5e11d  export.json\n", time.Now())
+
+	ctx := context.New(dutyCtx.New(), v1.Canary{})
+	check := v1.FolderCheck{Path: dir, Content: &v1.FolderContent{}}
+
+	results := checkLocalFolder(ctx, check)
+	Expect(results[0].Pass).To(BeTrue(), results[0].Error)
+	content := results[0].Detail.(FolderCheck).Content
+	Expect(content).To(HaveLen(1))
+	Expect(content[0].Name).To(Equal("export.json"))
+	Expect(content[0].Data).To(Equal(map[string]any{"rows": float64(2)}))
+
+	check.Content.VerifyChecksum = true
+	results = checkLocalFolder(ctx, check)
+	Expect(results[0].Pass).To(BeFalse())
+	Expect(results[0].Error).To(ContainSubstring("checksum of export.json does not match"))
+
+	content = results[0].Detail.(FolderCheck).Content
+	write("export.json.sha256", content[0].SHA256+"  export.json\n", time.Now())
+	results = checkLocalFolder(ctx, check)
+	Expect(results[0].Pass).To(BeTrue(), results[0].Error)
+
+	check.Content.Limit = 2
+	results = checkLocalFolder(ctx, check)
+	Expect(results[0].Pass).To(BeFalse())
+	Expect(results[0].Error).To(Equal("no checksum file found for old.json"))
+
+	check.Content = &v1.FolderContent{MaxSize: "5b"}
+	results = checkLocalFolder(ctx, check)
+	Expect(results[0].Pass).To(BeFalse())
+}
+
+func TestChecksumMatches(t *testing.T) {
+	RegisterTestingT(t)
+	file := newFileContent(File{Name: "export.json"}, []byte(`{"rows": 2}`), v1.FolderContentJSON)
+
+	Expect(FileContent{SHA256: file.SHA256, MD5: file.MD5, Checksum: file.SHA256, ChecksumAlgorithm: "sha256"}.ChecksumMatches()).To(BeTrue())
+	Expect(FileContent{SHA256: file.SHA256, MD5: file.MD5, Checksum: file.MD5, ChecksumAlgorithm: "md5"}.ChecksumMatches()).To(BeTrue())
+
+	// the checksum must be of the algorithm of the sidecar file
+	Expect(FileContent{SHA256: file.SHA256, MD5: file.MD5, Checksum: file.MD5, ChecksumAlgorithm: "sha256"}.ChecksumMatches()).To(BeFalse())
+	Expect(FileContent{SHA256: file.SHA256, MD5: file.MD5, Checksum: file.SHA256, ChecksumAlgorithm: "md5"}.ChecksumMatches()).To(BeFalse())
+}
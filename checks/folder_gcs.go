@@ -2,13 +2,16 @@ package checks
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 
 	gcs "cloud.google.com/go/storage"
 	"github.com/flanksource/artifacts"
+	artifactFS "github.com/flanksource/artifacts/fs"
 	"github.com/flanksource/canary-checker/api/context"
 	v1 "github.com/flanksource/canary-checker/api/v1"
 	"github.com/flanksource/canary-checker/pkg"
+	"github.com/flanksource/duty/connection"
 	"github.com/flanksource/duty/models"
 )
 
@@ -22,35 +25,16 @@ func CheckGCSBucket(ctx *context.Context, check v1.FolderCheck) pkg.Results {
 	var results pkg.Results
 	results = append(results, result)
 
-	if check.GCSConnection == nil {
-		return results.ErrorMessage(errors.New("missing GCS connection"))
-	}
-
-	var bucket string
-	bucket, check.Path = parseGCSPath(check.Path)
-
-	connection, err := ctx.HydrateConnectionByURL(check.GCPConnection.ConnectionName)
+	fs, path, err := getGCSFS(ctx, check.GCSConnection, check.Path)
 	if err != nil {
-		return results.Failf("failed to populate GCS connection: %v", err)
-	} else if connection == nil {
-		connection = &models.Connection{Type: models.ConnectionTypeGCS}
-		if check.GCSConnection.Bucket == "" {
-			check.GCSConnection.Bucket = bucket
-		}
-
-		connection, err = connection.Merge(ctx, check.GCSConnection)
-		if err != nil {
-			return results.Failf("failed to populate GCS connection: %v", err)
-		}
+		return results.ErrorMessage(err)
 	}
 
-	fs, err := artifacts.GetFSForConnection(ctx.Context, *connection)
+	folders, err := genericFolderCheck(ctx, fs, path, check.Recursive, check.Filter)
 	if err != nil {
 		return results.ErrorMessage(err)
 	}
-
-	folders, err := genericFolderCheck(ctx, fs, check.Path, check.Recursive, check.Filter)
-	if err != nil {
+	if err := readFolderContent(ctx, fs, path, &folders, check.Content); err != nil {
 		return results.ErrorMessage(err)
 	}
 	result.AddDetails(folders)
@@ -62,6 +46,36 @@ func CheckGCSBucket(ctx *context.Context, check v1.FolderCheck) pkg.Results {
 	return results
 }
 
+// getGCSFS returns a filesystem for the bucket of a gcs:// path, along with the path inside the bucket
+func getGCSFS(ctx *context.Context, conn *connection.GCSConnection, fullpath string) (artifactFS.Filesystem, string, error) {
+	if conn == nil {
+		return nil, "", errors.New("missing GCS connection")
+	}
+
+	bucket, path := parseGCSPath(fullpath)
+
+	model, err := ctx.HydrateConnectionByURL(conn.GCPConnection.ConnectionName)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to populate GCS connection: %w", err)
+	} else if model == nil {
+		model = &models.Connection{Type: models.ConnectionTypeGCS}
+		if conn.Bucket == "" {
+			conn.Bucket = bucket
+		}
+
+		model, err = model.Merge(ctx, conn)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to populate GCS connection: %w", err)
+		}
+	}
+
+	fs, err := artifacts.GetFSForConnection(ctx.Context, *model)
+	if err != nil {
+		return nil, "", err
+	}
+	return fs, path, nil
+}
+
 // parseGCSPath returns the bucket name and the actual path stripping of the gcs:// prefix and the bucket name.
 // The path is expected to be in the format "gcs://bucket_name/<actual_path>"
 func parseGCSPath(fullpath string) (bucket, path string) {
@@ -12,6 +12,7 @@ import (
 	"github.com/flanksource/canary-checker/api/context"
 	v1 "github.com/flanksource/canary-checker/api/v1"
 	"github.com/flanksource/canary-checker/pkg"
+	"github.com/flanksource/duty/connection"
 )
 
 type S3 struct {
@@ -24,31 +25,16 @@ func CheckS3Bucket(ctx *context.Context, check v1.FolderCheck) pkg.Results {
 	var results pkg.Results
 	results = append(results, result)
 
-	if check.S3Connection == nil {
-		return results.ErrorMessage(errors.New("missing AWS connection"))
-	}
-
-	var bucket string
-	bucket, check.Path = parseS3Path(check.Path)
-
-	if err := check.S3Connection.Populate(ctx); err != nil {
+	fs, path, err := getS3FS(ctx, check.S3Connection, check.Path)
+	if err != nil {
 		return results.ErrorMessage(err)
 	}
 
-	conn := check.S3Connection.ToModel()
-	conn.SetProperty("bucket", bucket)
-
-	fs, err := artifacts.GetFSForConnection(ctx.Context, conn)
+	folders, err := genericFolderCheck(ctx, fs, path, check.Recursive, check.Filter)
 	if err != nil {
 		return results.ErrorMessage(err)
 	}
-
-	if limitFS, ok := fs.(artifactFS.ListItemLimiter); ok {
-		limitFS.SetMaxListItems(ctx.Properties().Int("s3.list.max-objects", 50_000))
-	}
-
-	folders, err := genericFolderCheck(ctx, fs, check.Path, check.Recursive, check.Filter)
-	if err != nil {
+	if err := readFolderContent(ctx, fs, path, &folders, check.Content); err != nil {
 		return results.ErrorMessage(err)
 	}
 	result.AddDetails(folders)
@@ -60,6 +46,31 @@ func CheckS3Bucket(ctx *context.Context, check v1.FolderCheck) pkg.Results {
 	return results
 }
 
+// getS3FS returns a filesystem for the bucket of an s3:// path, along with the path inside the bucket
+func getS3FS(ctx *context.Context, conn *connection.S3Connection, fullpath string) (artifactFS.Filesystem, string, error) {
+	if conn == nil {
+		return nil, "", errors.New("missing AWS connection")
+	}
+
+	bucket, path := parseS3Path(fullpath)
+	if err := conn.Populate(ctx); err != nil {
+		return nil, "", err
+	}
+
+	model := conn.ToModel()
+	model.SetProperty("bucket", bucket)
+
+	fs, err := artifacts.GetFSForConnection(ctx.Context, model)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if limitFS, ok := fs.(artifactFS.ListItemLimiter); ok {
+		limitFS.SetMaxListItems(ctx.Properties().Int("s3.list.max-objects", 50_000))
+	}
+	return fs, path, nil
+}
+
 // parseS3Path returns the bucket name and the actual path stripping of the s3:// prefix and the bucket name.
 // The path is expected to be in the format "s3://bucket_name/<actual_path>"
 func parseS3Path(fullpath string) (bucket, path string) {
@@ -25,6 +25,9 @@ func CheckSFTP(ctx *context.Context, check v1.FolderCheck) pkg.Results {
 	if err != nil {
 		return results.ErrorMessage(err)
 	}
+	if err := readFolderContent(ctx, fs, check.Path, &folders, check.Content); err != nil {
+		return results.ErrorMessage(err)
+	}
 	result.AddDetails(folders)
 
 	if test := folders.Test(check.FolderTest); test != "" {
@@ -42,6 +42,9 @@ func CheckSmb(ctx *context.Context, check v1.FolderCheck) pkg.Results {
 	if err != nil {
 		return results.ErrorMessage(err)
 	}
+	if err := readFolderContent(ctx, fs, path, &folders, check.Content); err != nil {
+		return results.ErrorMessage(err)
+	}
 
 	var totalBlockCount, freeBlockCount, blockSize int // TODO:
 	folders.AvailableSize = int64(freeBlockCount * blockSize)
@@ -1,9 +1,11 @@
 package checks
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path"
+	"strings"
 	"time"
 
 	"github.com/flanksource/canary-checker/api/context"
@@ -11,8 +13,13 @@ import (
 	v1 "github.com/flanksource/canary-checker/api/v1"
 	"github.com/flanksource/canary-checker/pkg"
 	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
 	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"github.com/go-git/go-git/v5/storage/memory"
 )
 
 const (
@@ -33,7 +40,7 @@ func (c *GitProtocolChecker) Run(ctx *context.Context) pkg.Results {
 	return results
 }
 
-func pushChanges(repoURL, username, password, filename string) error {
+func pushChanges(repoURL, username string, auth transport.AuthMethod, filename string) error {
 	dir, err := os.MkdirTemp("", "repo-clone-")
 	if err != nil {
 		return fmt.Errorf("failed to create temp directory: %v", err)
@@ -42,7 +49,7 @@ func pushChanges(repoURL, username, password, filename string) error {
 
 	r, err := git.PlainClone(dir, false, &git.CloneOptions{
 		URL:  repoURL,
-		Auth: &http.BasicAuth{Username: username, Password: password},
+		Auth: auth,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to clone repo: %v", err)
@@ -74,6 +81,9 @@ func pushChanges(repoURL, username, password, filename string) error {
 		return fmt.Errorf("failed to add changes to staging area: %v", err)
 	}
 
+	if username == "" {
+		username = "canary-checker"
+	}
 	commitMsg := fmt.Sprintf("Updated %s with time %s", filename, currentTime)
 	if _, err := w.Commit(commitMsg, &git.CommitOptions{
 		Author: &object.Signature{
@@ -86,7 +96,7 @@ func pushChanges(repoURL, username, password, filename string) error {
 	}
 
 	if err := r.Push(&git.PushOptions{
-		Auth: &http.BasicAuth{Username: username, Password: password},
+		Auth: auth,
 	}); err != nil {
 		return fmt.Errorf("failed to push changes: %v", err)
 	}
@@ -94,6 +104,127 @@ func pushChanges(repoURL, username, password, filename string) error {
 	return nil
 }
 
+// GitCommit is the last commit of the branch checked in readOnly mode
+type GitCommit struct {
+	Branch  string    `json:"branch"`
+	Hash    string    `json:"hash"`
+	Author  string    `json:"author"`
+	Email   string    `json:"email"`
+	Message string    `json:"message"`
+	When    time.Time `json:"when"`
+	// Age of the commit in seconds
+	Age float64 `json:"age"`
+}
+
+// gitAuth returns public key auth for SSH repositories and basic auth for HTTP repositories
+func gitAuth(ctx *context.Context, check v1.GitProtocolCheck, username, password string) (transport.AuthMethod, error) {
+	endpoint, err := transport.NewEndpoint(check.Repository)
+	if err != nil {
+		return nil, fmt.Errorf("invalid repository: %w", err)
+	}
+
+	switch endpoint.Protocol {
+	case "ssh":
+		privateKey, err := ctx.GetEnvValueFromCache(check.PrivateKey, ctx.GetNamespace())
+		if err != nil {
+			return nil, fmt.Errorf("error fetching private key from env cache: %w", err)
+		}
+		if privateKey == "" {
+			return nil, errors.New("privateKey is required for SSH repositories")
+		}
+		user := username
+		if user == "" {
+			user = endpoint.User
+		}
+		if user == "" {
+			user = "git"
+		}
+		auth, err := ssh.NewPublicKeys(user, []byte(privateKey), password)
+		if err != nil {
+			return nil, fmt.Errorf("invalid private key: %w", err)
+		}
+
+		knownHosts, err := ctx.GetEnvValueFromCache(check.KnownHosts, ctx.GetNamespace())
+		if err != nil {
+			return nil, fmt.Errorf("error fetching known hosts from env cache: %w", err)
+		}
+		if knownHosts != "" {
+			file, err := os.CreateTemp("", "known_hosts-")
+			if err != nil {
+				return nil, err
+			}
+			defer os.Remove(file.Name())
+			if _, err := file.WriteString(knownHosts); err != nil {
+				return nil, err
+			}
+			_ = file.Close()
+			// the callback reads the file when it is created, so the file can be removed straight away
+			if auth.HostKeyCallback, err = ssh.NewKnownHostsCallback(file.Name()); err != nil {
+				return nil, fmt.Errorf("invalid known hosts: %w", err)
+			}
+		}
+		return auth, nil
+	case "http", "https":
+		if username == "" && password == "" {
+			return nil, nil
+		}
+		return &http.BasicAuth{Username: username, Password: password}, nil
+	}
+	return nil, nil
+}
+
+// lastCommit checks that the branch exists and returns its last commit using a shallow fetch
+func lastCommit(ctx *context.Context, repoURL, branch string, auth transport.AuthMethod) (*GitCommit, error) {
+	remote := git.NewRemote(memory.NewStorage(), &config.RemoteConfig{Name: "origin", URLs: []string{repoURL}})
+	refs, err := remote.ListContext(ctx, &git.ListOptions{Auth: auth})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list remote: %w", err)
+	}
+
+	var ref *plumbing.Reference
+	for _, r := range refs {
+		if branch == "" && r.Name() == plumbing.HEAD {
+			branch = r.Target().Short()
+		}
+	}
+	for _, r := range refs {
+		if r.Name() == plumbing.NewBranchReferenceName(branch) {
+			ref = r
+		}
+	}
+	if ref == nil {
+		return nil, fmt.Errorf("branch %s not found", branch)
+	}
+
+	repo, err := git.CloneContext(ctx, memory.NewStorage(), nil, &git.CloneOptions{
+		URL:           repoURL,
+		Auth:          auth,
+		ReferenceName: ref.Name(),
+		SingleBranch:  true,
+		Depth:         1,
+		NoCheckout:    true,
+		Tags:          git.NoTags,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", branch, err)
+	}
+
+	commit, err := repo.CommitObject(ref.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit %s: %w", ref.Hash(), err)
+	}
+
+	return &GitCommit{
+		Branch:  branch,
+		Hash:    commit.Hash.String(),
+		Author:  commit.Author.Name,
+		Email:   commit.Author.Email,
+		Message: strings.TrimSpace(commit.Message),
+		When:    commit.Committer.When,
+		Age:     time.Since(commit.Committer.When).Seconds(),
+	}, nil
+}
+
 func (c *GitProtocolChecker) Check(ctx *context.Context, extConfig external.Check) pkg.Results {
 	check := extConfig.(v1.GitProtocolCheck)
 	result := pkg.Success(check, ctx.Canary)
@@ -113,12 +244,36 @@ func (c *GitProtocolChecker) Check(ctx *context.Context, extConfig external.Chec
 		return results.Failf("error fetching git password from env cache: %v", err)
 	}
 
+	auth, err := gitAuth(ctx, check, username, password)
+	if err != nil {
+		return results.Failf("%v", err)
+	}
+
+	if check.ReadOnly {
+		commit, err := lastCommit(ctx, check.Repository, check.Branch, auth)
+		if err != nil {
+			return results.Failf("%v", err)
+		}
+		result.AddDetails(commit)
+
+		if check.MaxAge != "" {
+			maxAge, err := check.MaxAge.GetDuration()
+			if err != nil {
+				return results.Invalidf("invalid maxAge: %v", err)
+			}
+			if age := time.Since(commit.When); age > *maxAge {
+				return results.Failf("last commit on %s is %s old, older than %s", commit.Branch, age.Round(time.Second), check.MaxAge)
+			}
+		}
+		return results
+	}
+
 	if len(filename) == 0 {
 		filename = DefaultFileName
 	}
 
 	// Push Changes
-	if err := pushChanges(check.Repository, username, password, filename); err != nil {
+	if err := pushChanges(check.Repository, username, auth, filename); err != nil {
 		return results.Failf("error pushing changes: %v", err)
 	}
 
@@ -0,0 +1,87 @@
+package checks
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/flanksource/canary-checker/api/context"
+	v1 "github.com/flanksource/canary-checker/api/v1"
+	dutyCtx "github.com/flanksource/duty/context"
+	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	. "github.com/onsi/gomega"
+)
+
+// newTestRepository creates a bare repository with a single commit on main made at the given time
+func newTestRepository(t *testing.T, when time.Time) string {
+	source := t.TempDir()
+	repo, err := git.PlainInit(source, false)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(os.WriteFile(filepath.Join(source, "README.md"), []byte("hello"), 0600)).To(Succeed())
+
+	worktree, err := repo.Worktree()
+	Expect(err).ToNot(HaveOccurred())
+	_, err = worktree.Add("README.md")
+	Expect(err).ToNot(HaveOccurred())
+	signature := &object.Signature{Name: "tester", Email: "tester@example.com", When: when}
+	_, err = worktree.Commit("initial commit", &git.CommitOptions{Author: signature, Committer: signature})
+	Expect(err).ToNot(HaveOccurred())
+
+	bare := filepath.Join(t.TempDir(), "repo.git")
+	_, err = git.PlainClone(bare, true, &git.CloneOptions{URL: "file://" + source})
+	Expect(err).ToNot(HaveOccurred())
+	return "file://" + bare
+}
+
+func TestGitProtocolReadOnly(t *testing.T) {
+	RegisterTestingT(t)
+	ctx := context.New(dutyCtx.New(), v1.Canary{})
+	checker := &GitProtocolChecker{}
+
+	repo := newTestRepository(t, time.Now().Add(-2*time.Hour))
+
+	results := checker.Check(ctx, v1.GitProtocolCheck{Repository: repo, ReadOnly: true, MaxAge: "24h"})
+	Expect(results[0].Pass).To(BeTrue(), results[0].Error)
+	commit := results[0].Detail.(*GitCommit)
+	Expect(commit.Branch).To(Equal("master"))
+	Expect(commit.Author).To(Equal("tester"))
+	Expect(commit.Message).To(Equal("initial commit"))
+	Expect(results[0].Data["results"]).ToNot(BeNil())
+
+	results = checker.Check(ctx, v1.GitProtocolCheck{Repository: repo, ReadOnly: true, MaxAge: "1h"})
+	Expect(results[0].Pass).To(BeFalse())
+	Expect(results[0].Error).To(And(HavePrefix("last commit on master is 2h0m"), HaveSuffix("old, older than 1h")))
+
+	results = checker.Check(ctx, v1.GitProtocolCheck{Repository: repo, ReadOnly: true, Branch: "release"})
+	Expect(results[0].Pass).To(BeFalse())
+	Expect(results[0].Error).To(Equal("branch release not found"))
+}
+
+func TestGitProtocolPush(t *testing.T) {
+	RegisterTestingT(t)
+	ctx := context.New(dutyCtx.New(), v1.Canary{})
+	checker := &GitProtocolChecker{}
+
+	repo := newTestRepository(t, time.Now().Add(-48*time.Hour))
+
+	results := checker.Check(ctx, v1.GitProtocolCheck{Repository: repo})
+	Expect(results[0].Pass).To(BeTrue(), results[0].Error)
+
+	results = checker.Check(ctx, v1.GitProtocolCheck{Repository: repo, ReadOnly: true, MaxAge: "1h"})
+	Expect(results[0].Pass).To(BeTrue(), results[0].Error)
+	Expect(results[0].Detail.(*GitCommit).Message).To(HavePrefix("Updated test.txt"))
+}
+
+func TestGitAuth(t *testing.T) {
+	RegisterTestingT(t)
+	ctx := context.New(dutyCtx.New(), v1.Canary{})
+
+	auth, err := gitAuth(ctx, v1.GitProtocolCheck{Repository: "https://github.com/org/repo.git"}, "user", "pass")
+	Expect(err).ToNot(HaveOccurred())
+	Expect(auth.Name()).To(Equal("http-basic-auth"))
+
+	_, err = gitAuth(ctx, v1.GitProtocolCheck{Repository: "git@github.com:org/repo.git"}, "", "")
+	Expect(err).To(MatchError("privateKey is required for SSH repositories"))
+}
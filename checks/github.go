@@ -36,6 +36,18 @@ func (c *GitHubChecker) Check(ctx *context.Context, extConfig external.Check) pk
 	var results pkg.Results
 	results = append(results, result)
 
+	if check.GitLab != nil && check.Actions != nil {
+		return results.Invalidf("actions and gitlab are mutually exclusive")
+	}
+
+	if check.GitLab != nil {
+		token, err := gitlabToken(ctx, check)
+		if err != nil {
+			return results.Failf("%v", err)
+		}
+		return checkCIPipelines(ctx, result, check, token)
+	}
+
 	var githubToken string
 	if connection, err := ctx.HydrateConnectionByURL(check.ConnectionName); err != nil {
 		return results.Failf("failed to find connection for github token %q: %v", check.ConnectionName, err)
@@ -48,6 +60,10 @@ func (c *GitHubChecker) Check(ctx *context.Context, extConfig external.Check) pk
 		}
 	}
 
+	if check.Actions != nil {
+		return checkCIPipelines(ctx, result, check, githubToken)
+	}
+
 	askGitCmd := fmt.Sprintf("mergestat \"%v\" --format json", check.Query)
 	if ctx.IsTrace() {
 		ctx.Tracef("Executing askgit command: %v", askGitCmd)
@@ -68,3 +84,16 @@ func (c *GitHubChecker) Check(ctx *context.Context, extConfig external.Check) pk
 	result.AddDetails(rowResults)
 	return results
 }
+
+func gitlabToken(ctx *context.Context, check v1.GitHubCheck) (string, error) {
+	if connection, err := ctx.HydrateConnectionByURL(check.ConnectionName); err != nil {
+		return "", fmt.Errorf("failed to find connection for gitlab token %q: %w", check.ConnectionName, err)
+	} else if connection != nil {
+		return connection.Password, nil
+	}
+	token, err := ctx.GetEnvValueFromCache(check.GitLabToken, ctx.GetNamespace())
+	if err != nil {
+		return "", fmt.Errorf("error fetching gitlab token from env cache: %w", err)
+	}
+	return token, nil
+}
@@ -0,0 +1,336 @@
+package checks
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/flanksource/canary-checker/api/context"
+	v1 "github.com/flanksource/canary-checker/api/v1"
+	"github.com/flanksource/canary-checker/pkg"
+	"github.com/flanksource/commons/http"
+	"github.com/samber/lo"
+)
+
+const (
+	defaultGitHubAPI = "https://api.github.com"
+	defaultGitLabAPI = "https://gitlab.com"
+	ciRunsPerPage    = "100"
+)
+
+// CIRun is the latest completed run of a pipeline on a branch
+type CIRun struct {
+	ID       int64     `json:"id"`
+	Pipeline string    `json:"pipeline"`
+	Branch   string    `json:"branch"`
+	Status   string    `json:"status"`
+	Success  bool      `json:"success"`
+	Event    string    `json:"event,omitempty"`
+	URL      string    `json:"url"`
+	Started  time.Time `json:"started"`
+	Finished time.Time `json:"finished"`
+	// Duration of the run in seconds
+	Duration float64 `json:"duration"`
+}
+
+type CIPipelineDetails struct {
+	Runs []CIRun `json:"runs"`
+}
+
+// Test returns the reasons the latest runs do not meet the thresholds
+func (d CIPipelineDetails) Test(check v1.CIPipelineCheck, now time.Time) ([]string, error) {
+	var errors []string
+	for _, branch := range check.Branches {
+		if !lo.ContainsBy(d.Runs, func(run CIRun) bool { return run.Branch == branch }) {
+			errors = append(errors, fmt.Sprintf("no completed runs found on %s", branch))
+		}
+	}
+	if len(d.Runs) == 0 {
+		if len(errors) == 0 {
+			errors = append(errors, "no completed runs found")
+		}
+		return errors, nil
+	}
+
+	var maxDuration, maxAge *time.Duration
+	var err error
+	if check.MaxDuration != "" {
+		if maxDuration, err = check.MaxDuration.GetDuration(); err != nil {
+			return nil, fmt.Errorf("invalid maxDuration: %w", err)
+		}
+	}
+	if check.MaxAge != "" {
+		if maxAge, err = check.MaxAge.GetDuration(); err != nil {
+			return nil, fmt.Errorf("invalid maxAge: %w", err)
+		}
+	}
+
+	for _, run := range d.Runs {
+		name := fmt.Sprintf("%s on %s", run.Pipeline, run.Branch)
+		if !run.Success {
+			errors = append(errors, fmt.Sprintf("%s %s: %s", name, run.Status, run.URL))
+		}
+		duration := time.Duration(run.Duration * float64(time.Second))
+		if maxDuration != nil && duration > *maxDuration {
+			errors = append(errors, fmt.Sprintf("%s took %s, longer than %s", name, duration.Round(time.Second), check.MaxDuration))
+		}
+		if age := now.Sub(run.Finished); maxAge != nil && age > *maxAge {
+			errors = append(errors, fmt.Sprintf("%s is stale, the latest run is %s old", name, age.Round(time.Second)))
+		}
+	}
+	return errors, nil
+}
+
+// checkCIPipelines checks the latest GitHub Actions workflow runs or GitLab pipelines
+func checkCIPipelines(ctx *context.Context, result *pkg.CheckResult, check v1.GitHubCheck, token string) pkg.Results {
+	results := pkg.Results{result}
+
+	var runs []CIRun
+	var err error
+	var pipeline v1.CIPipelineCheck
+	if check.Actions != nil {
+		pipeline = *check.Actions
+		runs, err = githubLatestRuns(ctx, pipeline, token)
+	} else {
+		pipeline = *check.GitLab
+		runs, err = gitlabLatestRuns(ctx, pipeline, token)
+	}
+	if err != nil {
+		return results.Failf("%v", err)
+	}
+
+	details := CIPipelineDetails{Runs: runs}
+	result.AddDetails(details)
+
+	errors, err := details.Test(pipeline, time.Now())
+	if err != nil {
+		return results.Invalidf("%v", err)
+	}
+	for _, e := range errors {
+		result.Failf("%s", e)
+	}
+	return results
+}
+
+// pipelineMatcher matches pipeline names against a regular expression, or literally if it is not a valid expression
+func pipelineMatcher(pipeline string) func(names ...string) bool {
+	re, err := regexp.Compile(pipeline)
+	return func(names ...string) bool {
+		if pipeline == "" {
+			return true
+		}
+		for _, name := range names {
+			if name == pipeline || (err == nil && re.MatchString(name)) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// latestRunPerBranch keeps the first run of each pipeline and branch, runs must be sorted newest first
+func latestRunPerBranch(runs []CIRun) []CIRun {
+	seen := make(map[string]bool)
+	var latest []CIRun
+	for _, run := range runs {
+		key := run.Pipeline + "/" + run.Branch
+		if !seen[key] {
+			seen[key] = true
+			latest = append(latest, run)
+		}
+	}
+	return latest
+}
+
+type githubWorkflowRuns struct {
+	WorkflowRuns []struct {
+		ID           int64     `json:"id"`
+		Name         string    `json:"name"`
+		Path         string    `json:"path"`
+		HeadBranch   string    `json:"head_branch"`
+		Event        string    `json:"event"`
+		Status       string    `json:"status"`
+		Conclusion   string    `json:"conclusion"`
+		HTMLURL      string    `json:"html_url"`
+		RunStartedAt time.Time `json:"run_started_at"`
+		UpdatedAt    time.Time `json:"updated_at"`
+	} `json:"workflow_runs"`
+}
+
+func githubLatestRuns(ctx *context.Context, check v1.CIPipelineCheck, token string) ([]CIRun, error) {
+	client := http.NewClient().
+		BaseURL(strings.TrimSuffix(lo.CoalesceOrEmpty(check.URL, defaultGitHubAPI), "/")).
+		Header("Accept", "application/vnd.github+json")
+	if token != "" {
+		client = client.Header("Authorization", "Bearer "+token)
+	}
+	match := pipelineMatcher(check.Pipeline)
+
+	var runs []CIRun
+	for _, branch := range branchesOrAll(check.Branches) {
+		req := client.R(ctx).QueryParam("status", "completed").QueryParam("per_page", ciRunsPerPage)
+		if branch != "" {
+			req = req.QueryParam("branch", branch)
+		}
+		resp, err := req.Get(fmt.Sprintf("/repos/%s/actions/runs", check.Project))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list workflow runs: %w", err)
+		}
+		if !resp.IsOK() {
+			body, _ := resp.AsString()
+			return nil, fmt.Errorf("failed to list workflow runs: %d %s", resp.StatusCode, body)
+		}
+
+		var list githubWorkflowRuns
+		if err := resp.Into(&list); err != nil {
+			return nil, fmt.Errorf("failed to parse workflow runs: %w", err)
+		}
+
+		for _, run := range list.WorkflowRuns {
+			if !match(run.Name, run.Path) {
+				continue
+			}
+			runs = append(runs, CIRun{
+				ID:       run.ID,
+				Pipeline: run.Name,
+				Branch:   run.HeadBranch,
+				Status:   run.Conclusion,
+				Success:  run.Conclusion == "success" || run.Conclusion == "skipped" || run.Conclusion == "neutral",
+				Event:    run.Event,
+				URL:      run.HTMLURL,
+				Started:  run.RunStartedAt,
+				Finished: run.UpdatedAt,
+				Duration: run.UpdatedAt.Sub(run.RunStartedAt).Seconds(),
+			})
+		}
+	}
+	return latestRunPerBranch(runs), nil
+}
+
+type gitlabPipeline struct {
+	ID         int64      `json:"id"`
+	Name       string     `json:"name"`
+	Ref        string     `json:"ref"`
+	Status     string     `json:"status"`
+	Source     string     `json:"source"`
+	WebURL     string     `json:"web_url"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	StartedAt  *time.Time `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at"`
+	Duration   *float64   `json:"duration"`
+}
+
+func gitlabLatestRuns(ctx *context.Context, check v1.CIPipelineCheck, token string) ([]CIRun, error) {
+	client := http.NewClient().BaseURL(strings.TrimSuffix(lo.CoalesceOrEmpty(check.URL, defaultGitLabAPI), "/") + "/api/v4")
+	if token != "" {
+		client = client.Header("PRIVATE-TOKEN", token)
+	}
+	project := "/projects/" + url.PathEscape(check.Project)
+	match := pipelineMatcher(check.Pipeline)
+
+	get := func(path string, into any, params ...string) error {
+		req := client.R(ctx)
+		for i := 0; i+1 < len(params); i += 2 {
+			req = req.QueryParam(params[i], params[i+1])
+		}
+		resp, err := req.Get(project + path)
+		if err != nil {
+			return err
+		}
+		if !resp.IsOK() {
+			body, _ := resp.AsString()
+			return fmt.Errorf("%s returned %d %s", path, resp.StatusCode, body)
+		}
+		return resp.Into(into)
+	}
+
+	var runs []CIRun
+	for _, branch := range branchesOrAll(check.Branches) {
+		params := []string{"scope", "finished", "order_by", "id", "sort", "desc", "per_page", ciRunsPerPage}
+		if branch != "" {
+			params = append(params, "ref", branch)
+		}
+		var pipelines []gitlabPipeline
+		if err := get("/pipelines", &pipelines, params...); err != nil {
+			return nil, fmt.Errorf("failed to list pipelines: %w", err)
+		}
+
+		seen := make(map[string]bool)
+		for _, pipeline := range pipelines {
+			key := pipeline.Name + "/" + pipeline.Ref
+			if seen[key] || !match(pipeline.Name) {
+				continue
+			}
+
+			if len(check.Variables) > 0 {
+				var variables []struct {
+					Key   string `json:"key"`
+					Value string `json:"value"`
+				}
+				if err := get(fmt.Sprintf("/pipelines/%d/variables", pipeline.ID), &variables); err != nil {
+					return nil, fmt.Errorf("failed to get variables of pipeline %d: %w", pipeline.ID, err)
+				}
+				got := make(map[string]string)
+				for _, v := range variables {
+					got[v.Key] = v.Value
+				}
+				if !matchVariables(check.Variables, got) {
+					continue
+				}
+			}
+			seen[key] = true
+
+			// the list API does not include the duration
+			if err := get(fmt.Sprintf("/pipelines/%d", pipeline.ID), &pipeline); err != nil {
+				return nil, fmt.Errorf("failed to get pipeline %d: %w", pipeline.ID, err)
+			}
+			run := CIRun{
+				ID:       pipeline.ID,
+				Pipeline: pipeline.Name,
+				Branch:   pipeline.Ref,
+				Status:   pipeline.Status,
+				Success:  pipeline.Status == "success",
+				Event:    pipeline.Source,
+				URL:      pipeline.WebURL,
+				Started:  pipeline.CreatedAt,
+				Finished: pipeline.UpdatedAt,
+			}
+			if pipeline.StartedAt != nil {
+				run.Started = *pipeline.StartedAt
+			}
+			if pipeline.FinishedAt != nil {
+				run.Finished = *pipeline.FinishedAt
+			}
+			if pipeline.Duration != nil {
+				run.Duration = *pipeline.Duration
+			} else {
+				run.Duration = run.Finished.Sub(run.Started).Seconds()
+			}
+			if run.Pipeline == "" {
+				run.Pipeline = check.Project
+			}
+			runs = append(runs, run)
+		}
+	}
+	return runs, nil
+}
+
+func matchVariables(want, got map[string]string) bool {
+	for k, v := range want {
+		if value, ok := got[k]; !ok || value != v {
+			return false
+		}
+	}
+	return true
+}
+
+// branchesOrAll returns the branches to query, an empty branch queries all branches
+func branchesOrAll(branches []string) []string {
+	if len(branches) == 0 {
+		return []string{""}
+	}
+	return branches
+}
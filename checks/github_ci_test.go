@@ -0,0 +1,169 @@
+package checks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/flanksource/canary-checker/api/context"
+	v1 "github.com/flanksource/canary-checker/api/v1"
+	dutyCtx "github.com/flanksource/duty/context"
+	"github.com/flanksource/duty/types"
+	. "github.com/onsi/gomega"
+)
+
+func serveJSON(t *testing.T, routes map[string]any) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := routes[r.URL.EscapedPath()]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		if fn, ok := body.(func(r *http.Request) any); ok {
+			body = fn(r)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(body)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGitHubActions(t *testing.T) {
+	RegisterTestingT(t)
+	ctx := context.New(dutyCtx.New(), v1.Canary{})
+	checker := &GitHubChecker{}
+
+	now := time.Now().UTC()
+	run := func(id int, name, branch, conclusion string, started time.Time, duration time.Duration) map[string]any {
+		return map[string]any{
+			"id":             id,
+			"name":           name,
+			"path":           ".github/workflows/" + name + ".yml",
+			"head_branch":    branch,
+			"event":          "push",
+			"status":         "completed",
+			"conclusion":     conclusion,
+			"html_url":       fmt.Sprintf("https://github.com/org/repo/actions/runs/%d", id),
+			"run_started_at": started,
+			"updated_at":     started.Add(duration),
+		}
+	}
+
+	var token string
+	server := serveJSON(t, map[string]any{
+		"/repos/org/repo/actions/runs": func(r *http.Request) any {
+			token = r.Header.Get("Authorization")
+			Expect(r.URL.Query().Get("status")).To(Equal("completed"))
+			runs := []map[string]any{
+				run(3, "build", "main", "success", now.Add(-time.Hour), 5*time.Minute),
+				run(2, "build", "main", "failure", now.Add(-2*time.Hour), 5*time.Minute),
+				run(1, "lint", "main", "success", now.Add(-3*time.Hour), time.Minute),
+			}
+			if r.URL.Query().Get("branch") == "release" {
+				runs = []map[string]any{run(4, "build", "release", "failure", now.Add(-48*time.Hour), 30*time.Minute)}
+			}
+			return map[string]any{"workflow_runs": runs}
+		},
+	})
+
+	check := v1.GitHubCheck{
+		GithubToken: types.EnvVar{ValueStatic: "secret"},
+		Actions:     &v1.CIPipelineCheck{URL: server.URL, Project: "org/repo", Pipeline: "build", Branches: []string{"main"}, MaxDuration: "10m", MaxAge: "24h"},
+	}
+	results := checker.Check(ctx, check)
+	Expect(results[0].Pass).To(BeTrue(), results[0].Error)
+	Expect(token).To(Equal("Bearer secret"))
+	details := results[0].Detail.(CIPipelineDetails)
+	Expect(details.Runs).To(HaveLen(1))
+	Expect(details.Runs[0].ID).To(Equal(int64(3)))
+	Expect(details.Runs[0].Duration).To(Equal(300.0))
+
+	check.Actions.Branches = []string{"main", "release"}
+	results = checker.Check(ctx, check)
+	Expect(results[0].Pass).To(BeFalse())
+	Expect(results[0].Error).To(ContainSubstring("build on release failure: https://github.com/org/repo/actions/runs/4"))
+	Expect(results[0].Error).To(ContainSubstring("build on release took 30m0s, longer than 10m"))
+	Expect(results[0].Error).To(ContainSubstring("build on release is stale"))
+
+	// every branch without runs is reported
+	check.Actions.Branches = []string{"main", "develop"}
+	results = checker.Check(ctx, check)
+	Expect(results[0].Pass).To(BeFalse())
+	Expect(results[0].Error).To(Equal("no completed runs found on develop"))
+
+	check.Actions.Pipeline = "deploy"
+	results = checker.Check(ctx, check)
+	Expect(results[0].Pass).To(BeFalse())
+	Expect(results[0].Error).To(Equal("no completed runs found on main, no completed runs found on develop"))
+
+	check.Actions.Branches = nil
+	results = checker.Check(ctx, check)
+	Expect(results[0].Pass).To(BeFalse())
+	Expect(results[0].Error).To(Equal("no completed runs found"))
+
+	check.GitLab = &v1.CIPipelineCheck{Project: "group/project"}
+	results = checker.Check(ctx, check)
+	Expect(results[0].Invalid).To(BeTrue())
+}
+
+func TestGitLabPipelines(t *testing.T) {
+	RegisterTestingT(t)
+	ctx := context.New(dutyCtx.New(), v1.Canary{})
+	checker := &GitHubChecker{}
+
+	now := time.Now().UTC()
+	pipeline := func(id int, status string, duration float64) map[string]any {
+		return map[string]any{
+			"id":          id,
+			"ref":         "main",
+			"status":      status,
+			"source":      "schedule",
+			"web_url":     fmt.Sprintf("https://gitlab.com/group/project/-/pipelines/%d", id),
+			"created_at":  now.Add(-time.Hour),
+			"updated_at":  now.Add(-30 * time.Minute),
+			"started_at":  now.Add(-time.Hour),
+			"finished_at": now.Add(-30 * time.Minute),
+			"duration":    duration,
+		}
+	}
+
+	var token string
+	server := serveJSON(t, map[string]any{
+		"/api/v4/projects/group%2Fproject/pipelines": func(r *http.Request) any {
+			token = r.Header.Get("PRIVATE-TOKEN")
+			Expect(r.URL.Query().Get("ref")).To(Equal("main"))
+			return []map[string]any{{"id": 2, "ref": "main"}, {"id": 1, "ref": "main"}}
+		},
+		"/api/v4/projects/group%2Fproject/pipelines/2":           pipeline(2, "failed", 60),
+		"/api/v4/projects/group%2Fproject/pipelines/1":           pipeline(1, "success", 120),
+		"/api/v4/projects/group%2Fproject/pipelines/2/variables": []map[string]string{{"key": "ENV", "value": "staging"}},
+		"/api/v4/projects/group%2Fproject/pipelines/1/variables": []map[string]string{{"key": "ENV", "value": "production"}},
+	})
+
+	check := v1.GitHubCheck{
+		GitLabToken: types.EnvVar{ValueStatic: "secret"},
+		GitLab:      &v1.CIPipelineCheck{URL: server.URL, Project: "group/project", Branches: []string{"main"}},
+	}
+	results := checker.Check(ctx, check)
+	Expect(token).To(Equal("secret"))
+	Expect(results[0].Pass).To(BeFalse())
+	Expect(results[0].Error).To(Equal("group/project on main failed: https://gitlab.com/group/project/-/pipelines/2"))
+
+	check.GitLab.Variables = map[string]string{"ENV": "production"}
+	check.GitLab.MaxDuration = "1m"
+	results = checker.Check(ctx, check)
+	Expect(results[0].Pass).To(BeFalse())
+	Expect(results[0].Error).To(Equal("group/project on main took 2m0s, longer than 1m"))
+	details := results[0].Detail.(CIPipelineDetails)
+	Expect(details.Runs).To(HaveLen(1))
+	Expect(details.Runs[0].ID).To(Equal(int64(1)))
+	Expect(details.Runs[0].Event).To(Equal("schedule"))
+
+	check.GitLab.MaxDuration = "5m"
+	results = checker.Check(ctx, check)
+	Expect(results[0].Pass).To(BeTrue(), results[0].Error)
+}
@@ -194,32 +194,38 @@ func cleanupExistingPods(ctx *context.Context, k8s kubernetes.Interface, selecto
 	return skip, err
 }
 
-func (c *JunitChecker) Check(ctx *context.Context, extConfig external.Check) pkg.Results {
-	check := extConfig.(v1.JunitCheck)
-	result := pkg.Success(check, ctx.Canary)
-	var results pkg.Results
-	results = append(results, result)
+// runJunitPod runs the tests in a new pod and collects the junit results from it,
+// returning done when the check should stop with the returned results
+func runJunitPod(ctx *context.Context, check v1.JunitCheck, results pkg.Results) (JunitTestSuites, pkg.Results, bool) {
+	var suites JunitTestSuites
+	result := results[0]
+
+	kctx, err := ctx.WithKubernetesConnection(check.KubernetesConnection)
+	if err != nil {
+		return suites, results.WithError(err).Invalidf("Cannot connect to kubernetes"), true
+	}
+	ctx = &kctx
 
 	if ctx.KubernetesClient() == nil {
-		return results.Failf("Kubernetes is not initialized")
+		return suites, results.Failf("Kubernetes is not initialized"), true
 	}
 
 	k8s := ctx.Kubernetes()
 	timeout := time.Duration(check.GetTimeout()) * time.Minute
 	pod, err := newPod(ctx, check)
 	if err != nil {
-		return results.ErrorMessage(err)
+		return suites, results.ErrorMessage(err), true
 	}
 	pods := k8s.CoreV1().Pods(ctx.Namespace)
 
 	if skip, err := cleanupExistingPods(ctx, k8s, fmt.Sprintf("%s=%s", junitCheckSelector, pod.Labels[junitCheckSelector])); err != nil {
-		return results.ErrorMessage(err)
+		return suites, results.ErrorMessage(err), true
 	} else if skip {
-		return nil
+		return suites, nil, true
 	}
 
 	if _, err := k8s.CoreV1().Pods(ctx.Namespace).Create(ctx, pod, metav1.CreateOptions{}); err != nil {
-		return results.ErrorMessage(err)
+		return suites, results.ErrorMessage(err), true
 	}
 
 	defer deletePod(ctx, pod)
@@ -239,40 +245,75 @@ func (c *JunitChecker) Check(ctx *context.Context, extConfig external.Check) pkg
 
 	podObj, err := pods.Get(ctx, pod.Name, metav1.GetOptions{})
 	if err != nil {
-		return results.ErrorMessage(err)
+		return suites, results.ErrorMessage(err), true
 	}
 
 	if !kommons.IsPodHealthy(*podObj) {
-		return podFail(ctx, *pod, results)
+		return suites, podFail(ctx, *pod, results), true
 	}
 
-	var suites JunitTestSuites
 	exitCode, _ := podExecf(ctx, *pod, results, "cat %v/exit-code", mountPath)
 
 	if exitCode != "" && exitCode != "0" {
 		// we don't exit early as junit files may have been generated in addition to a failing exit code
 		result.Failf("process exited with: %s:\n%s", exitCode, getLogs(ctx, *pod))
 	}
-	files, ok := podExecf(ctx, *pod, results, fmt.Sprintf("find %v -name \\*.xml -type f", mountPath))
+	formats := enabledTestFormats(check.Formats)
+	var names []string
+	for _, ext := range testReportExtensions(formats) {
+		names = append(names, "-name \\*."+ext)
+	}
+	files, ok := podExecf(ctx, *pod, results, fmt.Sprintf("find %v -type f \\( %s \\)", mountPath, strings.Join(names, " -o ")))
 	if !ok {
-		return results
+		return suites, results, true
 	}
 	files = strings.TrimSpace(files)
 	if files == "" && exitCode != "" && exitCode != "0" {
-		return results.Failf("No junit files found")
+		return suites, results.Failf("No junit files found"), true
 	}
+	skipped := map[string]string{}
 	for _, file := range strings.Split(files, "\n") {
 		output, ok := podExecf(ctx, *pod, results, "cat %v", file)
 		if !ok {
-			return results
+			return suites, results, true
 		}
-		if suites, err = suites.Ingest(output); err != nil {
-			return results.ErrorMessage(err)
+		var reason string
+		var err error
+		if suites, reason, err = suites.IngestFormat(output, formats); err != nil {
+			return suites, results.Failf("%s: %v", file, err), true
+		} else if reason != "" {
+			ctx.Warnf("skipping %s: %s", file, reason)
+			skipped[file] = reason
 		}
 	}
+	if len(skipped) > 0 {
+		result.AddData(map[string]any{"skipped": skipped})
+	}
 
 	// signal container to exit
 	_, _ = podExecf(ctx, *pod, results, "touch %s/done", mountPath)
+	return suites, results, false
+}
+
+func (c *JunitChecker) Check(ctx *context.Context, extConfig external.Check) pkg.Results {
+	check := extConfig.(v1.JunitCheck)
+	result := pkg.Success(check, ctx.Canary)
+	var results pkg.Results
+	results = append(results, result)
+
+	var suites JunitTestSuites
+	if check.Source != nil {
+		var err error
+		if suites, err = junitSuitesFromSource(ctx, result, *check.Source, check.Formats); err != nil {
+			return results.ErrorMessage(err)
+		}
+	} else {
+		var done bool
+		if suites, results, done = runJunitPod(ctx, check, results); done {
+			return results
+		}
+	}
+
 	result.AddDetails(suites)
 	result.Duration = int64(suites.Duration * 1000)
 	if check.Test.IsEmpty() && suites.Failed > 0 {
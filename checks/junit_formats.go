@@ -0,0 +1,447 @@
+package checks
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joshdk/go-junit"
+	"github.com/samber/lo"
+)
+
+const (
+	TestFormatJUnit    = "junit"
+	TestFormatTAP      = "tap"
+	TestFormatTRX      = "trx"
+	TestFormatCucumber = "cucumber"
+	TestFormatGoTest   = "gotest"
+)
+
+// testFormatExtensions are the file extensions that reports of each format are discovered by,
+// it has an entry for every format in v1.JunitFormats, which is used to validate the check
+var testFormatExtensions = map[string]string{
+	TestFormatJUnit:    "xml",
+	TestFormatTAP:      "tap",
+	TestFormatTRX:      "trx",
+	TestFormatCucumber: "json",
+	TestFormatGoTest:   "json",
+}
+
+var (
+	tapPlan = regexp.MustCompile(`^1\.\.\d+`)
+	tapTest = regexp.MustCompile(`^(not )?ok\b\s*(\d+)?\s*(?:- )?([^#]*)(?:#\s*(\w+)\s*(.*))?$`)
+)
+
+// DetectTestFormat returns the format of a test report, or an empty string if it is not recognised
+func DetectTestFormat(content []byte) string {
+	content = bytes.TrimSpace(bytes.TrimPrefix(content, []byte("\xef\xbb\xbf")))
+	switch {
+	case bytes.HasPrefix(content, []byte("<")):
+		if bytes.Contains(content, []byte("<TestRun")) {
+			return TestFormatTRX
+		}
+		return TestFormatJUnit
+	case bytes.HasPrefix(content, []byte("[")):
+		return TestFormatCucumber
+	case bytes.HasPrefix(content, []byte("{")):
+		return TestFormatGoTest
+	case bytes.HasPrefix(content, []byte("TAP version")), tapPlan.Match(content), tapTest.Match(content):
+		return TestFormatTAP
+	}
+	return ""
+}
+
+// enabledTestFormats returns JUnit XML and the additional formats enabled on the check
+func enabledTestFormats(formats []string) []string {
+	return lo.Uniq(append([]string{TestFormatJUnit}, formats...))
+}
+
+// testReportExtensions returns the file extensions of the formats
+func testReportExtensions(formats []string) []string {
+	var extensions []string
+	for _, format := range formats {
+		if ext, ok := testFormatExtensions[format]; ok {
+			extensions = append(extensions, ext)
+		}
+	}
+	return lo.Uniq(extensions)
+}
+
+// IngestFormat parses the report if it is in one of the formats. Reports that are not in any of the formats
+// are skipped and the reason is returned, an error is returned for reports that cannot be parsed
+func (suites JunitTestSuites) IngestFormat(report string, formats []string) (JunitTestSuites, string, error) {
+	format := DetectTestFormat([]byte(report))
+	if format == "" {
+		return suites, "unknown test report format", nil
+	}
+	if !lo.Contains(formats, format) {
+		return suites, fmt.Sprintf("%s format is not enabled", format), nil
+	}
+	ingested, err := suites.Ingest(report)
+	if err != nil {
+		return suites, "", fmt.Errorf("failed to parse %s report: %w", format, err)
+	}
+	return ingested, "", nil
+}
+
+// newJunitTestSuite creates a suite with totals calculated from its tests
+func newJunitTestSuite(name string, tests []JunitTest) JunitTestSuite {
+	suite := JunitTestSuite{Name: name, Tests: tests}
+	for _, test := range tests {
+		suite.Duration += test.Duration
+		switch test.Status {
+		case junit.StatusPassed:
+			suite.Passed++
+		case junit.StatusSkipped:
+			suite.Skipped++
+		case junit.StatusFailed:
+			suite.Failed++
+		case junit.StatusError:
+			suite.Error++
+		}
+	}
+	return suite
+}
+
+func (suites JunitTestSuites) AppendSuite(suite JunitTestSuite) JunitTestSuites {
+	suites.Suites = append(suites.Suites, suite)
+	suites.Totals = suites.Totals.Add(suite.Totals)
+	return suites
+}
+
+// parseTAP parses Test Anything Protocol output, e.g. from bats
+func parseTAP(content string) ([]JunitTestSuite, error) {
+	var tests []JunitTest
+	var diagnostics []string
+	inYAML := false
+
+	flush := func() {
+		if len(tests) > 0 && len(diagnostics) > 0 {
+			last := &tests[len(tests)-1]
+			last.SystemOut = strings.Join(diagnostics, "\n")
+			if last.Status == junit.StatusFailed && last.Message == "" {
+				last.Message = last.SystemOut
+				last.Error = errors.New(last.Message)
+			}
+		}
+		diagnostics = nil
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	scanner.Buffer(make([]byte, 1024*1024), 10*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case inYAML:
+			if trimmed == "..." {
+				inYAML = false
+			} else {
+				diagnostics = append(diagnostics, trimmed)
+			}
+		case trimmed == "---" && len(tests) > 0:
+			inYAML = true
+		case strings.HasPrefix(trimmed, "#"):
+			diagnostics = append(diagnostics, strings.TrimSpace(strings.TrimPrefix(trimmed, "#")))
+		case tapTest.MatchString(line):
+			flush()
+			match := tapTest.FindStringSubmatch(line)
+			test := JunitTest{
+				Name:   strings.TrimSpace(match[3]),
+				Status: junit.StatusPassed,
+			}
+			if test.Name == "" {
+				test.Name = match[2]
+			}
+			directive := strings.ToUpper(match[4])
+			switch {
+			case directive == "SKIP":
+				test.Status = junit.StatusSkipped
+				test.Message = strings.TrimSpace(match[5])
+			case directive == "TODO":
+				// failing TODO tests are expected to fail and do not count as failures
+				test.Status = junit.StatusSkipped
+				test.Message = strings.TrimSpace("TODO " + match[5])
+			case match[1] != "":
+				test.Status = junit.StatusFailed
+			}
+			tests = append(tests, test)
+		}
+	}
+	flush()
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(tests) == 0 {
+		return nil, fmt.Errorf("no TAP test results found")
+	}
+	return []JunitTestSuite{newJunitTestSuite("TAP", tests)}, nil
+}
+
+type trxTestRun struct {
+	Name    string `xml:"name,attr"`
+	Results struct {
+		UnitTestResults []struct {
+			TestID   string `xml:"testId,attr"`
+			TestName string `xml:"testName,attr"`
+			Outcome  string `xml:"outcome,attr"`
+			Duration string `xml:"duration,attr"`
+			Output   struct {
+				StdOut    string `xml:"StdOut"`
+				StdErr    string `xml:"StdErr"`
+				ErrorInfo struct {
+					Message    string `xml:"Message"`
+					StackTrace string `xml:"StackTrace"`
+				} `xml:"ErrorInfo"`
+			} `xml:"Output"`
+		} `xml:"UnitTestResult"`
+	} `xml:"Results"`
+	TestDefinitions struct {
+		UnitTests []struct {
+			ID         string `xml:"id,attr"`
+			TestMethod struct {
+				ClassName string `xml:"className,attr"`
+			} `xml:"TestMethod"`
+		} `xml:"UnitTest"`
+	} `xml:"TestDefinitions"`
+}
+
+// parseTRXDuration parses the hh:mm:ss.fffffff durations used by TRX files
+func parseTRXDuration(s string) float64 {
+	parts := strings.Split(s, ":")
+	if len(parts) != 3 {
+		return 0
+	}
+	hours, _ := strconv.ParseFloat(parts[0], 64)
+	minutes, _ := strconv.ParseFloat(parts[1], 64)
+	seconds, _ := strconv.ParseFloat(parts[2], 64)
+	return hours*3600 + minutes*60 + seconds
+}
+
+// parseTRX parses Visual Studio test results (.trx) produced by dotnet test
+func parseTRX(content string) ([]JunitTestSuite, error) {
+	var run trxTestRun
+	if err := xml.Unmarshal([]byte(content), &run); err != nil {
+		return nil, fmt.Errorf("invalid TRX: %w", err)
+	}
+
+	classNames := make(map[string]string)
+	for _, test := range run.TestDefinitions.UnitTests {
+		classNames[test.ID] = test.TestMethod.ClassName
+	}
+
+	var tests []JunitTest
+	for _, result := range run.Results.UnitTestResults {
+		test := JunitTest{
+			Name:      result.TestName,
+			Classname: classNames[result.TestID],
+			Duration:  parseTRXDuration(result.Duration),
+			SystemOut: strings.TrimSpace(result.Output.StdOut),
+			SystemErr: strings.TrimSpace(result.Output.StdErr),
+		}
+		switch result.Outcome {
+		case "Passed", "PassedButRunAborted", "Warning":
+			test.Status = junit.StatusPassed
+		case "Failed":
+			test.Status = junit.StatusFailed
+		case "Error", "Timeout", "Aborted":
+			test.Status = junit.StatusError
+		default:
+			test.Status = junit.StatusSkipped
+		}
+		if message := strings.TrimSpace(result.Output.ErrorInfo.Message); message != "" {
+			test.Message = message
+			if test.Status == junit.StatusFailed || test.Status == junit.StatusError {
+				test.Error = errors.New(strings.TrimSpace(message + "\n" + result.Output.ErrorInfo.StackTrace))
+			}
+		}
+		tests = append(tests, test)
+	}
+	return []JunitTestSuite{newJunitTestSuite(run.Name, tests)}, nil
+}
+
+type cucumberFeature struct {
+	URI      string `json:"uri"`
+	Name     string `json:"name"`
+	Elements []struct {
+		Name  string `json:"name"`
+		Type  string `json:"type"`
+		Steps []struct {
+			Keyword string `json:"keyword"`
+			Name    string `json:"name"`
+			Result  struct {
+				Status       string `json:"status"`
+				Duration     int64  `json:"duration"`
+				ErrorMessage string `json:"error_message"`
+			} `json:"result"`
+		} `json:"steps"`
+	} `json:"elements"`
+}
+
+// parseCucumber parses cucumber JSON reports, each feature is a suite and each scenario a test
+func parseCucumber(content string) ([]JunitTestSuite, error) {
+	var features []cucumberFeature
+	if err := json.Unmarshal([]byte(content), &features); err != nil {
+		return nil, fmt.Errorf("invalid cucumber JSON: %w", err)
+	}
+
+	var suites []JunitTestSuite
+	for _, feature := range features {
+		var tests []JunitTest
+		for _, element := range feature.Elements {
+			if element.Type == "background" {
+				continue
+			}
+			test := JunitTest{
+				Name:      element.Name,
+				Classname: feature.Name,
+				Status:    junit.StatusPassed,
+			}
+			for _, step := range element.Steps {
+				test.Duration += time.Duration(step.Result.Duration).Seconds()
+				switch step.Result.Status {
+				case "failed":
+					test.Status = junit.StatusFailed
+					test.Message = strings.TrimSpace(step.Keyword) + " " + step.Name
+					test.Error = errors.New(step.Result.ErrorMessage)
+				case "skipped", "pending", "undefined":
+					if test.Status == junit.StatusPassed {
+						test.Status = junit.StatusSkipped
+						test.Message = fmt.Sprintf("%s step: %s %s", step.Result.Status, strings.TrimSpace(step.Keyword), step.Name)
+					}
+				}
+			}
+			tests = append(tests, test)
+		}
+		name := feature.Name
+		if name == "" {
+			name = feature.URI
+		}
+		suites = append(suites, newJunitTestSuite(name, tests))
+	}
+	return suites, nil
+}
+
+type goTestEvent struct {
+	Action  string  `json:"Action"`
+	Package string  `json:"Package"`
+	Test    string  `json:"Test"`
+	Elapsed float64 `json:"Elapsed"`
+	Output  string  `json:"Output"`
+}
+
+// goTestPackageTest is the name of the synthetic test case reported for a package that failed without any of
+// its tests failing, e.g. because it failed to build, TestMain failed or the test binary exited early
+const goTestPackageTest = "(package)"
+
+// parseGoTest parses the output of `go test -json`, each package is a suite
+func parseGoTest(content string) ([]JunitTestSuite, error) {
+	var packages []string
+	tests := make(map[string][]*JunitTest)
+	index := make(map[string]*JunitTest)
+	output := make(map[string]*strings.Builder)
+	failed := make(map[string]float64)
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	scanner.Buffer(make([]byte, 1024*1024), 10*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 || line[0] != '{' {
+			continue
+		}
+		var event goTestEvent
+		if err := json.Unmarshal(line, &event); err != nil {
+			return nil, fmt.Errorf("invalid go test event: %w", err)
+		}
+		if event.Package == "" {
+			continue
+		}
+		if _, seen := tests[event.Package]; !seen {
+			packages = append(packages, event.Package)
+			tests[event.Package] = nil
+			output[event.Package] = &strings.Builder{}
+		}
+
+		if event.Test == "" {
+			switch event.Action {
+			case "output":
+				output[event.Package].WriteString(event.Output)
+			case "fail":
+				failed[event.Package] = event.Elapsed
+			}
+			continue
+		}
+
+		key := event.Package + "/" + event.Test
+		test, ok := index[key]
+		if !ok {
+			test = &JunitTest{Name: event.Test, Classname: event.Package}
+			index[key] = test
+			output[key] = &strings.Builder{}
+			tests[event.Package] = append(tests[event.Package], test)
+		}
+
+		switch event.Action {
+		case "output":
+			output[key].WriteString(event.Output)
+		case "pass":
+			test.Status = junit.StatusPassed
+			test.Duration = event.Elapsed
+		case "skip":
+			test.Status = junit.StatusSkipped
+			test.Duration = event.Elapsed
+		case "fail":
+			test.Status = junit.StatusFailed
+			test.Duration = event.Elapsed
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	var suites []JunitTestSuite
+	for _, pkg := range packages {
+		var _tests []JunitTest
+		for _, test := range tests[pkg] {
+			test.SystemOut = output[pkg+"/"+test.Name].String()
+			if test.Status == "" {
+				// the test never completed, e.g. the binary panicked or timed out
+				test.Status = junit.StatusError
+			}
+			if test.Status == junit.StatusFailed || test.Status == junit.StatusError {
+				test.Error = errors.New(test.SystemOut)
+			}
+			_tests = append(_tests, *test)
+		}
+		testFailed := lo.ContainsBy(_tests, func(test JunitTest) bool {
+			return test.Status == junit.StatusFailed || test.Status == junit.StatusError
+		})
+		if elapsed, ok := failed[pkg]; ok && !testFailed {
+			out := output[pkg].String()
+			_tests = append(_tests, JunitTest{
+				Name:      goTestPackageTest,
+				Classname: pkg,
+				Status:    junit.StatusFailed,
+				Duration:  elapsed,
+				SystemOut: out,
+				Error:     errors.New(out),
+			})
+		}
+		if len(_tests) == 0 {
+			continue
+		}
+		suites = append(suites, newJunitTestSuite(pkg, _tests))
+	}
+	if len(suites) == 0 {
+		return nil, fmt.Errorf("no go test results found")
+	}
+	return suites, nil
+}
@@ -0,0 +1,175 @@
+package checks
+
+import (
+	"testing"
+
+	v1 "github.com/flanksource/canary-checker/api/v1"
+	"github.com/joshdk/go-junit"
+	. "github.com/onsi/gomega"
+	"github.com/samber/lo"
+)
+
+const tapReport = `1..3
+ok 1 addition works
+not ok 2 subtraction works
+# (in test file test/math.bats, line 8)
+#   [ "$result" -eq 1 ] failed
+ok 3 division works # skip not implemented
+`
+
+const trxReport = `<?xml version="1.0" encoding="utf-8"?>
+<TestRun id="1" name="dotnet tests" xmlns="http://microsoft.com/schemas/VisualStudio/TeamTest/2010">
+  <Results>
+    <UnitTestResult testId="a" testName="Adds" outcome="Passed" duration="00:00:01.5000000" />
+    <UnitTestResult testId="b" testName="Subtracts" outcome="Failed" duration="00:00:00.2500000">
+      <Output>
+        <ErrorInfo>
+          <Message>Assert.Equal() Failure</Message>
+          <StackTrace>at MathTests.Subtracts()</StackTrace>
+        </ErrorInfo>
+      </Output>
+    </UnitTestResult>
+    <UnitTestResult testId="c" testName="Divides" outcome="NotExecuted" />
+  </Results>
+  <TestDefinitions>
+    <UnitTest id="a" name="Adds"><TestMethod className="MathTests" name="Adds" /></UnitTest>
+    <UnitTest id="b" name="Subtracts"><TestMethod className="MathTests" name="Subtracts" /></UnitTest>
+  </TestDefinitions>
+</TestRun>`
+
+const cucumberReport = `[{
+  "uri": "features/login.feature",
+  "name": "Login",
+  "elements": [
+    {"name": "setup", "type": "background", "steps": [{"keyword": "Given ", "name": "a user", "result": {"status": "passed", "duration": 1000000}}]},
+    {"name": "valid login", "type": "scenario", "steps": [
+      {"keyword": "When ", "name": "I login", "result": {"status": "passed", "duration": 500000000}},
+      {"keyword": "Then ", "name": "I see the dashboard", "result": {"status": "passed", "duration": 500000000}}
+    ]},
+    {"name": "invalid login", "type": "scenario", "steps": [
+      {"keyword": "When ", "name": "I login with a bad password", "result": {"status": "failed", "error_message": "expected 401"}},
+      {"keyword": "Then ", "name": "I see an error", "result": {"status": "skipped"}}
+    ]}
+  ]
+}]`
+
+const goTestReport = `{"Action":"start","Package":"example.com/math"}
+{"Action":"run","Package":"example.com/math","Test":"TestAdd"}
+{"Action":"output","Package":"example.com/math","Test":"TestAdd","Output":"=== RUN   TestAdd\n"}
+{"Action":"pass","Package":"example.com/math","Test":"TestAdd","Elapsed":0.5}
+{"Action":"run","Package":"example.com/math","Test":"TestSub"}
+{"Action":"output","Package":"example.com/math","Test":"TestSub","Output":"    math_test.go:12: expected 1\n"}
+{"Action":"fail","Package":"example.com/math","Test":"TestSub","Elapsed":0.1}
+{"Action":"run","Package":"example.com/math","Test":"TestDiv"}
+{"Action":"skip","Package":"example.com/math","Test":"TestDiv","Elapsed":0}
+{"Action":"fail","Package":"example.com/math","Elapsed":0.7}
+`
+
+const goTestBuildFailure = `{"Action":"start","Package":"example.com/broken"}
+{"Action":"output","Package":"example.com/broken","Output":"# example.com/broken\nbroken.go:3:1: syntax error\n"}
+{"Action":"output","Package":"example.com/broken","Output":"FAIL\texample.com/broken [build failed]\n"}
+{"Action":"fail","Package":"example.com/broken","Elapsed":0}
+{"Action":"start","Package":"example.com/setup"}
+{"Action":"run","Package":"example.com/setup","Test":"TestA"}
+{"Action":"pass","Package":"example.com/setup","Test":"TestA","Elapsed":0.1}
+{"Action":"output","Package":"example.com/setup","Output":"panic: database is not reachable\n"}
+{"Action":"fail","Package":"example.com/setup","Elapsed":0.2}
+`
+
+func TestTestFormatsMatchJunitFormats(t *testing.T) {
+	RegisterTestingT(t)
+	Expect(lo.Keys(testFormatExtensions)).To(ConsistOf(v1.JunitFormats))
+}
+
+func TestDetectTestFormat(t *testing.T) {
+	RegisterTestingT(t)
+	Expect(DetectTestFormat([]byte(`<testsuites></testsuites>`))).To(Equal(TestFormatJUnit))
+	Expect(DetectTestFormat([]byte(trxReport))).To(Equal(TestFormatTRX))
+	Expect(DetectTestFormat([]byte(tapReport))).To(Equal(TestFormatTAP))
+	Expect(DetectTestFormat([]byte("TAP version 13\nok 1"))).To(Equal(TestFormatTAP))
+	Expect(DetectTestFormat([]byte(cucumberReport))).To(Equal(TestFormatCucumber))
+	Expect(DetectTestFormat([]byte(goTestReport))).To(Equal(TestFormatGoTest))
+	Expect(DetectTestFormat([]byte("hello world"))).To(BeEmpty())
+}
+
+func TestIngestTestFormats(t *testing.T) {
+	RegisterTestingT(t)
+
+	suites, err := JunitTestSuites{}.Ingest(tapReport)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(suites.Totals).To(Equal(Totals{Passed: 1, Failed: 1, Skipped: 1}))
+	Expect(suites.Suites[0].Tests[1].Name).To(Equal("subtraction works"))
+	Expect(suites.Suites[0].Tests[1].Message).To(ContainSubstring("math.bats, line 8"))
+	Expect(suites.Suites[0].Tests[2].Message).To(Equal("not implemented"))
+
+	suites, err = JunitTestSuites{}.Ingest(trxReport)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(suites.Totals).To(Equal(Totals{Passed: 1, Failed: 1, Skipped: 1, Duration: 1.75}))
+	Expect(suites.Suites[0].Name).To(Equal("dotnet tests"))
+	Expect(suites.Suites[0].Tests[1].Classname).To(Equal("MathTests"))
+	Expect(suites.Suites[0].Tests[1].Message).To(Equal("Assert.Equal() Failure"))
+
+	suites, err = JunitTestSuites{}.Ingest(cucumberReport)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(suites.Suites[0].Name).To(Equal("Login"))
+	Expect(suites.Suites[0].Tests).To(HaveLen(2))
+	Expect(suites.Passed).To(Equal(1))
+	Expect(suites.Failed).To(Equal(1))
+	Expect(suites.Suites[0].Tests[0].Duration).To(BeNumerically("~", 1.0))
+	Expect(suites.Suites[0].Tests[1].Error).To(MatchError("expected 401"))
+
+	suites, err = JunitTestSuites{}.Ingest(goTestReport)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(suites.Suites[0].Name).To(Equal("example.com/math"))
+	Expect(suites.Totals).To(Equal(Totals{Passed: 1, Failed: 1, Skipped: 1, Duration: 0.6}))
+	Expect(suites.Suites[0].Tests[1].Status).To(Equal(junit.StatusFailed))
+	Expect(suites.Suites[0].Tests[1].SystemOut).To(ContainSubstring("expected 1"))
+
+	// packages that fail outside of a test are reported as a failed test case
+	failures, err := JunitTestSuites{}.Ingest(goTestBuildFailure)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(failures.Suites).To(HaveLen(2))
+	Expect(failures.Passed).To(Equal(1))
+	Expect(failures.Failed).To(Equal(2))
+	Expect(failures.Suites[0].Tests).To(HaveLen(1))
+	Expect(failures.Suites[0].Tests[0].Name).To(Equal(goTestPackageTest))
+	Expect(failures.Suites[0].Tests[0].Error).To(MatchError(ContainSubstring("syntax error")))
+	Expect(failures.Suites[1].Tests[1].Error).To(MatchError(ContainSubstring("database is not reachable")))
+
+	// reports of different formats can be combined
+	suites, err = suites.Ingest(tapReport)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(suites.Suites).To(HaveLen(2))
+	Expect(suites.Failed).To(Equal(2))
+
+	_, err = JunitTestSuites{}.Ingest("hello world")
+	Expect(err).To(HaveOccurred())
+}
+
+func TestIngestFormat(t *testing.T) {
+	RegisterTestingT(t)
+	formats := enabledTestFormats([]string{TestFormatTAP})
+	Expect(formats).To(Equal([]string{TestFormatJUnit, TestFormatTAP}))
+	Expect(testReportExtensions(formats)).To(Equal([]string{"xml", "tap"}))
+	Expect(testReportExtensions(enabledTestFormats([]string{TestFormatCucumber, TestFormatGoTest}))).To(Equal([]string{"xml", "json"}))
+
+	suites, reason, err := JunitTestSuites{}.IngestFormat(tapReport, formats)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(reason).To(BeEmpty())
+	Expect(suites.Suites).To(HaveLen(1))
+
+	// reports that are not enabled or unknown are skipped
+	suites, reason, err = suites.IngestFormat(goTestReport, formats)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(reason).To(Equal("gotest format is not enabled"))
+	Expect(suites.Suites).To(HaveLen(1))
+
+	_, reason, err = suites.IngestFormat("hello world", formats)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(reason).To(Equal("unknown test report format"))
+
+	// reports of enabled formats that cannot be parsed are errors
+	_, reason, err = suites.IngestFormat(`{"name": "my-app", "version": "1.0.0"}`, enabledTestFormats([]string{TestFormatGoTest}))
+	Expect(err).To(MatchError(ContainSubstring("failed to parse gotest report")))
+	Expect(reason).To(BeEmpty())
+}
@@ -0,0 +1,101 @@
+package checks
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	artifactFS "github.com/flanksource/artifacts/fs"
+	"github.com/flanksource/canary-checker/api/context"
+	v1 "github.com/flanksource/canary-checker/api/v1"
+	"github.com/flanksource/canary-checker/pkg"
+)
+
+// junitSuitesFromSource reads the newest junit reports from a local folder or object storage
+func junitSuitesFromSource(ctx *context.Context, result *pkg.CheckResult, source v1.JunitSource, formats []string) (JunitTestSuites, error) {
+	var suites JunitTestSuites
+
+	formats = enabledTestFormats(formats)
+	if source.Filter.Regex == "" {
+		source.Filter.Regex = fmt.Sprintf(`\.(%s)$`, strings.Join(testReportExtensions(formats), "|"))
+	}
+
+	fs, path, err := getFolderFS(ctx, source.Path, source.S3Connection, source.GCSConnection)
+	if err != nil {
+		return suites, err
+	}
+	defer fs.Close()
+
+	rw, ok := fs.(artifactFS.FilesystemRW)
+	if !ok {
+		return suites, fmt.Errorf("reading files from %s is not supported", source.Path)
+	}
+
+	filter, err := source.Filter.New()
+	if err != nil {
+		return suites, err
+	}
+
+	files, err := getFolderContents(ctx, fs, path, filter)
+	if err != nil {
+		return suites, err
+	}
+
+	var reports []artifactFS.FileInfo
+	for _, file := range files {
+		if info, ok := file.(artifactFS.FileInfo); ok && !info.IsDir() {
+			reports = append(reports, info)
+		}
+	}
+	if len(reports) == 0 {
+		return suites, fmt.Errorf("no junit reports found in %s", source.Path)
+	}
+
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].ModTime().After(reports[j].ModTime())
+	})
+	if len(reports) > source.GetLimit() {
+		reports = reports[:source.GetLimit()]
+	}
+
+	var names []string
+	skipped := map[string]string{}
+	for _, report := range reports {
+		name := report.FullPath()
+		if name == "" {
+			name = filepath.Join(path, report.Name())
+		}
+		names = append(names, name)
+
+		content, err := readJunitReport(ctx, rw, name)
+		if err != nil {
+			return suites, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		var reason string
+		if suites, reason, err = suites.IngestFormat(content, formats); err != nil {
+			return suites, fmt.Errorf("%s: %w", name, err)
+		} else if reason != "" {
+			ctx.Warnf("skipping %s: %s", name, reason)
+			skipped[name] = reason
+		}
+	}
+
+	result.AddData(map[string]any{"files": names})
+	if len(skipped) > 0 {
+		result.AddData(map[string]any{"skipped": skipped})
+	}
+	return suites, nil
+}
+
+func readJunitReport(ctx *context.Context, fs artifactFS.FilesystemRW, path string) (string, error) {
+	reader, err := fs.Read(ctx, path)
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+
+	content, err := io.ReadAll(reader)
+	return string(content), err
+}
@@ -0,0 +1,74 @@
+package checks
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/flanksource/canary-checker/api/context"
+	v1 "github.com/flanksource/canary-checker/api/v1"
+	"github.com/flanksource/canary-checker/pkg"
+	dutyCtx "github.com/flanksource/duty/context"
+	. "github.com/onsi/gomega"
+)
+
+const junitReport = `<testsuites>
+  <testsuite name="%s" tests="2" failures="%d" time="1.5">
+    <testcase name="first" classname="%s" time="0.5"></testcase>
+    <testcase name="second" classname="%s" time="1.0">%s</testcase>
+  </testsuite>
+</testsuites>`
+
+func TestJunitSuitesFromSource(t *testing.T) {
+	RegisterTestingT(t)
+	dir := t.TempDir()
+
+	write := func(name string, failures int, modified time.Time) {
+		failure := ""
+		if failures > 0 {
+			failure = `<failure message="expected true">assertion failed</failure>`
+		}
+		path := filepath.Join(dir, name)
+		Expect(os.WriteFile(path, []byte(fmt.Sprintf(junitReport, name, failures, name, name, failure)), 0600)).To(Succeed())
+		Expect(os.Chtimes(path, modified, modified)).To(Succeed())
+	}
+	write("old.xml", 1, time.Now().Add(-time.Hour))
+	write("new.xml", 0, time.Now())
+	Expect(os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("not a report"), 0600)).To(Succeed())
+
+	ctx := context.New(dutyCtx.New(), v1.Canary{})
+	result := &pkg.CheckResult{}
+
+	suites, err := junitSuitesFromSource(ctx, result, v1.JunitSource{Path: dir}, nil)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(suites.Suites).To(HaveLen(1))
+	Expect(suites.Passed).To(Equal(2))
+	Expect(suites.Failed).To(Equal(0))
+	Expect(result.Data["files"]).To(Equal([]string{filepath.Join(dir, "new.xml")}))
+
+	suites, err = junitSuitesFromSource(ctx, result, v1.JunitSource{Path: dir, Limit: 5}, nil)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(suites.Suites).To(HaveLen(2))
+	Expect(suites.Failed).To(Equal(1))
+
+	_, err = junitSuitesFromSource(ctx, result, v1.JunitSource{Path: dir, Filter: v1.FolderFilter{Regex: `\.json$`}}, nil)
+	Expect(err).To(HaveOccurred())
+
+	// json files are only read when a json format is enabled, and fail the check if they cannot be parsed
+	Expect(os.WriteFile(filepath.Join(dir, "package.json"), []byte(`{"name": "app"}`), 0600)).To(Succeed())
+	suites, err = junitSuitesFromSource(ctx, result, v1.JunitSource{Path: dir, Limit: 5}, nil)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(suites.Suites).To(HaveLen(2))
+
+	_, err = junitSuitesFromSource(ctx, result, v1.JunitSource{Path: dir, Limit: 5}, []string{TestFormatGoTest})
+	Expect(err).To(MatchError(ContainSubstring("package.json: failed to parse gotest report")))
+
+	// files that match none of the enabled formats are skipped
+	Expect(os.WriteFile(filepath.Join(dir, "package.json"), []byte(`[{"name": "app"}]`), 0600)).To(Succeed())
+	suites, err = junitSuitesFromSource(ctx, result, v1.JunitSource{Path: dir, Limit: 5}, []string{TestFormatGoTest})
+	Expect(err).ToNot(HaveOccurred())
+	Expect(suites.Suites).To(HaveLen(2))
+	Expect(result.Data["skipped"]).To(HaveKeyWithValue(filepath.Join(dir, "package.json"), "cucumber format is not enabled"))
+}
@@ -177,13 +177,36 @@ func (suites JunitTestSuites) Append(suite junit.Suite) JunitTestSuites {
 	return suites
 }
 
-func (suites JunitTestSuites) Ingest(xml string) (JunitTestSuites, error) {
-	testSuite, err := junit.Ingest([]byte(xml))
+// Ingest parses a test report in any of the supported formats (JUnit, TAP, TRX, Cucumber JSON or go test -json)
+func (suites JunitTestSuites) Ingest(report string) (JunitTestSuites, error) {
+	var parsed []JunitTestSuite
+	var err error
+	switch format := DetectTestFormat([]byte(report)); format {
+	case TestFormatJUnit:
+		testSuite, err := junit.Ingest([]byte(report))
+		if err != nil {
+			return suites, err
+		}
+		for _, suite := range testSuite {
+			suites = suites.Append(suite)
+		}
+		return suites, nil
+	case TestFormatTAP:
+		parsed, err = parseTAP(report)
+	case TestFormatTRX:
+		parsed, err = parseTRX(report)
+	case TestFormatCucumber:
+		parsed, err = parseCucumber(report)
+	case TestFormatGoTest:
+		parsed, err = parseGoTest(report)
+	default:
+		return suites, fmt.Errorf("unknown test report format")
+	}
 	if err != nil {
 		return suites, err
 	}
-	for _, suite := range testSuite {
-		suites = suites.Append(suite)
+	for _, suite := range parsed {
+		suites = suites.AppendSuite(suite)
 	}
 	return suites, nil
 }
@@ -0,0 +1,206 @@
+package checks
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/flanksource/artifacts"
+	"github.com/flanksource/canary-checker/api/context"
+	"github.com/flanksource/canary-checker/api/external"
+	v1 "github.com/flanksource/canary-checker/api/v1"
+	"github.com/flanksource/canary-checker/pkg"
+	"github.com/flanksource/duty/shell"
+	"github.com/flanksource/duty/types"
+)
+
+const (
+	k6Script  = "script.js"
+	k6Summary = "summary.json"
+	k6Report  = "report.html"
+)
+
+type K6Checker struct {
+}
+
+func (c *K6Checker) Type() string {
+	return "k6"
+}
+
+func (c *K6Checker) Run(ctx *context.Context) pkg.Results {
+	var results pkg.Results
+	for _, conf := range ctx.Canary.Spec.K6 {
+		results = append(results, c.Check(ctx, conf)...)
+	}
+	return results
+}
+
+func (c *K6Checker) Check(ctx *context.Context, extConfig external.Check) pkg.Results {
+	check := extConfig.(v1.K6Check)
+	result := pkg.Success(check, ctx.Canary)
+	var results pkg.Results
+	results = append(results, result)
+
+	dir, err := os.MkdirTemp("", "k6-")
+	if err != nil {
+		return results.ErrorMessage(err)
+	}
+	defer os.RemoveAll(dir)
+
+	script := check.Path
+	if check.Script != "" || check.ScriptFrom != nil {
+		content := check.Script
+		if check.ScriptFrom != nil {
+			if content, err = ctx.GetEnvValueFromCache(types.EnvVar{ValueFrom: check.ScriptFrom}, ctx.GetNamespace()); err != nil {
+				return results.Failf("error getting script: %v", err)
+			}
+		}
+		script = filepath.Join(dir, k6Script)
+		if err := os.WriteFile(script, []byte(content), 0600); err != nil {
+			return results.ErrorMessage(err)
+		}
+	} else if check.Path == "" {
+		return results.Invalidf("one of script, scriptFrom or path is required")
+	}
+
+	envVars := append([]types.EnvVar{
+		{Name: "K6_SUMMARY_EXPORT", ValueStatic: filepath.Join(dir, k6Summary)},
+		{Name: "K6_SUMMARY_TREND_STATS", ValueStatic: "avg,min,med,max,p(90),p(95),p(99)"},
+		{Name: "K6_WEB_DASHBOARD", ValueStatic: "true"},
+		{Name: "K6_WEB_DASHBOARD_PORT", ValueStatic: "-1"},
+		{Name: "K6_WEB_DASHBOARD_EXPORT", ValueStatic: filepath.Join(dir, k6Report)},
+	}, check.EnvVars...)
+
+	run := []string{"k6", "run", "--no-color", shellQuote(script)}
+	for _, arg := range check.Args {
+		run = append(run, shellQuote(arg))
+	}
+	command := strings.Join(run, " ")
+	if script == check.Path {
+		// keep a copy of the script from the checkout so that it can be saved as an artifact
+		command = fmt.Sprintf("cp %s %s; %s", shellQuote(script), shellQuote(filepath.Join(dir, k6Script)), command)
+	}
+
+	details, err := shell.Run(ctx.Context, shell.Exec{
+		Script:   command,
+		Checkout: check.Checkout,
+		EnvVars:  envVars,
+	})
+	if details == nil {
+		return results.ErrorMessage(err).Invalidf("failed to run k6: %v", err)
+	}
+	result.Artifacts = append(result.Artifacts, k6Artifacts(dir)...)
+
+	summary, err := os.ReadFile(filepath.Join(dir, k6Summary))
+	if err != nil {
+		return results.Failf("k6 did not export a summary: %s", details.String())
+	}
+
+	k6Details, err := parseK6Summary(summary)
+	if err != nil {
+		return results.Failf("%v", err)
+	}
+	k6Details.ExitCode = details.ExitCode
+	result.AddDetails(k6Details)
+
+	for _, threshold := range k6Details.FailedThresholds {
+		result.Failf("threshold %s failed", threshold)
+	}
+	if details.ExitCode != 0 && len(k6Details.FailedThresholds) == 0 {
+		return results.Failf("%s", details.String())
+	}
+	return results
+}
+
+// k6Artifacts returns the script, summary and html report from the output directory
+func k6Artifacts(dir string) []artifacts.Artifact {
+	var result []artifacts.Artifact
+	for _, name := range []string{k6Script, k6Summary, k6Report} {
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			continue
+		}
+		result = append(result, artifacts.Artifact{
+			Path:    name,
+			Content: io.NopCloser(bytes.NewReader(content)),
+		})
+	}
+	return result
+}
+
+type K6Checks struct {
+	Passes int     `json:"passes"`
+	Fails  int     `json:"fails"`
+	Rate   float64 `json:"rate"`
+}
+
+type K6Details struct {
+	ExitCode int `json:"exitCode"`
+	// Metrics are the values of each metric, e.g. metrics.http_reqs.count
+	Metrics map[string]map[string]float64 `json:"metrics"`
+	// Thresholds maps each threshold e.g. `http_req_duration: p(95)<500` to whether it passed
+	Thresholds       map[string]bool    `json:"thresholds"`
+	FailedThresholds []string           `json:"failedThresholds,omitempty"`
+	Checks           K6Checks           `json:"checks"`
+	HTTPReqDuration  map[string]float64 `json:"http_req_duration,omitempty"`
+}
+
+// parseK6Summary parses the output of `k6 run --summary-export`
+func parseK6Summary(data []byte) (K6Details, error) {
+	var export struct {
+		Metrics map[string]map[string]json.RawMessage `json:"metrics"`
+	}
+	details := K6Details{
+		Metrics:    make(map[string]map[string]float64),
+		Thresholds: make(map[string]bool),
+	}
+	if err := json.Unmarshal(data, &export); err != nil {
+		return details, fmt.Errorf("invalid k6 summary: %w", err)
+	}
+
+	for name, metric := range export.Metrics {
+		values := make(map[string]float64)
+		for key, raw := range metric {
+			if key == "thresholds" {
+				// the summary export reports true for thresholds that have been crossed
+				var thresholds map[string]bool
+				if err := json.Unmarshal(raw, &thresholds); err != nil {
+					return details, fmt.Errorf("invalid thresholds for %s: %w", name, err)
+				}
+				for expr, failed := range thresholds {
+					threshold := name + ": " + expr
+					details.Thresholds[threshold] = !failed
+					if failed {
+						details.FailedThresholds = append(details.FailedThresholds, threshold)
+					}
+				}
+				continue
+			}
+			var value float64
+			if err := json.Unmarshal(raw, &value); err == nil {
+				values[key] = value
+			}
+		}
+		details.Metrics[name] = values
+	}
+	sort.Strings(details.FailedThresholds)
+
+	if checks, ok := details.Metrics["checks"]; ok {
+		details.Checks = K6Checks{
+			Passes: int(checks["passes"]),
+			Fails:  int(checks["fails"]),
+			Rate:   checks["value"],
+		}
+	}
+	details.HTTPReqDuration = details.Metrics["http_req_duration"]
+	return details, nil
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'"'"'`) + "'"
+}
@@ -0,0 +1,33 @@
+package checks
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestParseK6Summary(t *testing.T) {
+	RegisterTestingT(t)
+
+	details, err := parseK6Summary([]byte(`{
+		"root_group": {"name": "", "checks": {}},
+		"metrics": {
+			"checks": {"passes": 9, "fails": 1, "value": 0.9, "thresholds": {"rate>0.99": true}},
+			"http_req_duration": {"avg": 120.5, "min": 80, "med": 110, "max": 300, "p(90)": 200, "p(95)": 250, "thresholds": {"p(95)<500": false}},
+			"http_reqs": {"count": 10, "rate": 1.5}
+		}
+	}`))
+	Expect(err).ToNot(HaveOccurred())
+
+	Expect(details.Checks).To(Equal(K6Checks{Passes: 9, Fails: 1, Rate: 0.9}))
+	Expect(details.HTTPReqDuration).To(HaveKeyWithValue("p(95)", 250.0))
+	Expect(details.Metrics["http_reqs"]).To(HaveKeyWithValue("count", 10.0))
+	Expect(details.Thresholds).To(Equal(map[string]bool{
+		"checks: rate>0.99":            false,
+		"http_req_duration: p(95)<500": true,
+	}))
+	Expect(details.FailedThresholds).To(Equal([]string{"checks: rate>0.99"}))
+
+	_, err = parseK6Summary([]byte(`not json`))
+	Expect(err).To(HaveOccurred())
+}
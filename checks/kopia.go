@@ -0,0 +1,137 @@
+package checks
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/flanksource/canary-checker/api/context"
+	"github.com/flanksource/canary-checker/api/external"
+	v1 "github.com/flanksource/canary-checker/api/v1"
+	"github.com/flanksource/canary-checker/pkg"
+	"github.com/flanksource/duty/types"
+)
+
+type KopiaChecker struct {
+}
+
+func (c *KopiaChecker) Type() string {
+	return "kopia"
+}
+
+func (c *KopiaChecker) Run(ctx *context.Context) pkg.Results {
+	var results pkg.Results
+	for _, conf := range ctx.Canary.Spec.Kopia {
+		results = append(results, c.Check(ctx, conf)...)
+	}
+	return results
+}
+
+func (c *KopiaChecker) Check(ctx *context.Context, extConfig external.Check) pkg.Results {
+	check := extConfig.(v1.KopiaCheck)
+	result := pkg.Success(check, ctx.Canary)
+	var results pkg.Results
+	results = append(results, result)
+
+	env := make(map[string]string)
+	for key, envVar := range map[string]types.EnvVar{
+		"KOPIA_PASSWORD":        check.Password,
+		"AWS_ACCESS_KEY_ID":     check.AccessKey,
+		"AWS_SECRET_ACCESS_KEY": check.SecretKey,
+	} {
+		if envVar.IsEmpty() {
+			continue
+		}
+		value, err := ctx.GetEnvValueFromCache(envVar, ctx.GetNamespace())
+		if err != nil {
+			return results.Failf("error getting %s: %v", key, err)
+		}
+		env[key] = value
+	}
+
+	dir, err := os.MkdirTemp("", "kopia-")
+	if err != nil {
+		return results.ErrorMessage(err)
+	}
+	defer os.RemoveAll(dir)
+
+	repo := kopiaRepository{
+		env:  env,
+		args: []string{"--config-file", filepath.Join(dir, "repository.config"), "--cache-directory", filepath.Join(dir, "cache")},
+	}
+
+	connect := append([]string{"repository", "connect"}, strings.Fields(check.Repository)...)
+	if _, err := runBackupCommand(ctx, env, "kopia", append(connect, repo.args...)...); err != nil {
+		return results.Failf("failed to connect to repository: %v", err)
+	}
+
+	return checkBackupRepository(ctx, result, repo, check.BackupRepository)
+}
+
+type kopiaRepository struct {
+	env map[string]string
+	// args are the global flags pointing kopia at a temporary config
+	args []string
+}
+
+type kopiaSnapshot struct {
+	ID     string `json:"id"`
+	Source struct {
+		Host     string `json:"host"`
+		UserName string `json:"userName"`
+		Path     string `json:"path"`
+	} `json:"source"`
+	Description string    `json:"description"`
+	StartTime   time.Time `json:"startTime"`
+	EndTime     time.Time `json:"endTime"`
+	Incomplete  string    `json:"incomplete,omitempty"`
+	Stats       struct {
+		TotalSize  int64 `json:"totalSize"`
+		ErrorCount int   `json:"errorCount"`
+	} `json:"stats"`
+}
+
+func (r kopiaRepository) Snapshots(ctx *context.Context) ([]BackupSnapshot, error) {
+	output, err := runBackupCommand(ctx, r.env, "kopia", append([]string{"snapshot", "list", "--all", "--json"}, r.args...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshots []kopiaSnapshot
+	if err := json.Unmarshal(output, &snapshots); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshots: %w", err)
+	}
+
+	var result []BackupSnapshot
+	for _, snapshot := range snapshots {
+		s := BackupSnapshot{
+			ID:     snapshot.ID,
+			Time:   snapshot.EndTime,
+			Size:   snapshot.Stats.TotalSize,
+			Host:   snapshot.Source.UserName + "@" + snapshot.Source.Host,
+			Paths:  []string{snapshot.Source.Path},
+			Status: "complete",
+		}
+		if snapshot.Incomplete != "" {
+			s.Status = "incomplete"
+			s.Failed = true
+			s.Messages = append(s.Messages, snapshot.Incomplete)
+		}
+		if snapshot.Stats.ErrorCount > 0 {
+			s.Messages = append(s.Messages, fmt.Sprintf("%d errors", snapshot.Stats.ErrorCount))
+		}
+		if s.Time.IsZero() {
+			s.Time = snapshot.StartTime
+		}
+		result = append(result, s)
+	}
+	return result, nil
+}
+
+func (r kopiaRepository) Verify(ctx *context.Context) error {
+	_, err := runBackupCommand(ctx, r.env, "kopia", append([]string{"snapshot", "verify"}, r.args...)...)
+	return err
+}
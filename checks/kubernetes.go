@@ -2,6 +2,9 @@ package checks
 
 import (
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/flanksource/canary-checker/api/context"
 	"github.com/flanksource/canary-checker/api/external"
@@ -9,11 +12,19 @@ import (
 	"github.com/flanksource/canary-checker/pkg"
 	"github.com/flanksource/is-healthy/pkg/health"
 	"github.com/gobwas/glob"
+	"github.com/samber/lo"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/client-go/dynamic"
 )
 
+const (
+	// kubernetesEventsWindow is how far back warning events are attached to unhealthy resources
+	kubernetesEventsWindow = time.Hour
+	kubernetesEventsLimit  = 10
+)
+
 type KubernetesChecker struct{}
 
 func (c *KubernetesChecker) Type() string {
@@ -37,12 +48,9 @@ func (c *KubernetesChecker) Check(ctx context.Context, extConfig external.Check)
 	var results pkg.Results
 	results = append(results, result)
 
-	if check.KubeConfig != nil {
-		var err error
-		ctx, err = ctx.WithKubeconfig(*check.KubeConfig)
-		if err != nil {
-			return results.WithError(err).Invalidf("Cannot connect to kubernetes")
-		}
+	ctx, err := ctx.WithKubernetesConnection(check.KubernetesConnection)
+	if err != nil {
+		return results.WithError(err).Invalidf("Cannot connect to kubernetes")
 	}
 
 	if ctx.KubernetesRestConfig() == nil {
@@ -58,11 +66,21 @@ func (c *KubernetesChecker) Check(ctx context.Context, extConfig external.Check)
 	if err != nil {
 		return results.Failf("Failed to get namespaces: %v", err)
 	}
+	var watch *kubernetesWatch
+	if check.Watch {
+		if watch, err = getKubernetesWatch(ctx, check); err != nil {
+			return results.Failf("failed to watch %s: %v", check.Kind, err)
+		}
+	}
+
 	var allResources []unstructured.Unstructured
+	var perResource []pkg.TransformedCheckResult
 
 	for _, namespace := range namespaces {
-		resources, err := getResourcesFromNamespace(ctx, client, check, namespace)
-		if err != nil {
+		var resources []unstructured.Unstructured
+		if watch != nil {
+			resources = watch.list(namespace, check.Resource.Name)
+		} else if resources, err = getResourcesFromNamespace(ctx, client, check, namespace); err != nil {
 			return results.Failf("failed to get resources: %v. namespace: %v", err, namespace)
 		}
 		for _, filter := range check.Ignore {
@@ -76,23 +94,35 @@ func (c *KubernetesChecker) Check(ctx context.Context, extConfig external.Check)
 		ctx.Tracef("Found %d %s in namespace %s with label=%s field=%s", len(resources), check.Kind, namespace, check.Resource.LabelSelector, check.Resource.FieldSelector)
 		for _, resource := range resources {
 			_resource := resource
+			var failures []string
 			resourceHealth, err := health.GetResourceHealth(&_resource, nil)
 			if err != nil {
-				results.Failf("error getting resource health (%s/%s/%s): %v",
-					resource.GetKind(), resource.GetNamespace(), resource.GetName(), err)
+				failures = append(failures, fmt.Sprintf("error getting resource health (%s/%s/%s): %v",
+					resource.GetKind(), resource.GetNamespace(), resource.GetName(), err))
 			} else {
 				resource.Object["healthStatus"] = resourceHealth
+				failures = append(failures, resourceHealthFailures(check, resource, resourceHealth)...)
+			}
 
-				if check.Healthy && resourceHealth.Health != health.HealthHealthy {
-					results.Failf("%s/%s/%s is not healthy (health: %s, status: %s): %s",
-						resource.GetKind(), resource.GetNamespace(), resource.GetName(), resourceHealth.Health, resourceHealth.Status, resourceHealth.Message)
+			if !check.PerResource {
+				for _, failure := range failures {
+					results.Failf("%s", failure)
 				}
+				continue
+			}
 
-				if check.Ready && !resourceHealth.Ready {
-					results.Failf("%s/%s/%s is not ready (status: %s): %s", resource.GetKind(),
-						resource.GetNamespace(), resource.GetName(), resourceHealth.Status, resourceHealth.Message)
+			if len(failures) > 0 || (resourceHealth != nil && resourceHealth.Health == health.HealthUnhealthy) {
+				events, err := getWarningEvents(ctx, resource)
+				if err != nil {
+					ctx.Warnf("failed to get events of %s/%s/%s: %v", resource.GetKind(), resource.GetNamespace(), resource.GetName(), err)
+				} else if len(events) > 0 {
+					resource.Object["events"], _ = asMaps(events)
+					if len(failures) > 0 {
+						failures = append(failures, fmt.Sprintf("%s: %s", events[0].Reason, events[0].Message))
+					}
 				}
 			}
+			perResource = append(perResource, kubernetesResourceResult(check, resource, resourceHealth, failures))
 		}
 
 		allResources = append(allResources, resources...)
@@ -103,9 +133,106 @@ func (c *KubernetesChecker) Check(ctx context.Context, extConfig external.Check)
 	}
 
 	result.AddDetails(allResources)
+
+	if watch != nil {
+		checkKey := fmt.Sprintf("%s/%s/%s", ctx.Canary.Namespace, ctx.Canary.Name, check.GetName())
+		transitions, err := filterTransitions(watch.transitionsSince(checkKey, time.Now()), namespaces, check.Resource.Name, check.Ignore)
+		if err != nil {
+			return results.Failf("failed to filter transitions: %v", err)
+		}
+		result.Data["transitions"], _ = asMaps(transitions)
+		for _, t := range transitions {
+			if check.Healthy && t.From == health.HealthUnhealthy {
+				results.Failf("%s", t)
+			}
+		}
+	}
+
+	for _, t := range perResource {
+		results = append(results, newTransformedResult(result, t))
+	}
 	return results
 }
 
+func resourceHealthFailures(check v1.KubernetesCheck, resource unstructured.Unstructured, resourceHealth *health.HealthStatus) []string {
+	var failures []string
+	if check.Healthy && resourceHealth.Health != health.HealthHealthy {
+		failures = append(failures, fmt.Sprintf("%s/%s/%s is not healthy (health: %s, status: %s): %s",
+			resource.GetKind(), resource.GetNamespace(), resource.GetName(), resourceHealth.Health, resourceHealth.Status, resourceHealth.Message))
+	}
+
+	if check.Ready && !resourceHealth.Ready {
+		failures = append(failures, fmt.Sprintf("%s/%s/%s is not ready (status: %s): %s", resource.GetKind(),
+			resource.GetNamespace(), resource.GetName(), resourceHealth.Status, resourceHealth.Message))
+	}
+	return failures
+}
+
+// kubernetesResourceResult creates the result of a single resource in perResource mode
+func kubernetesResourceResult(check v1.KubernetesCheck, resource unstructured.Unstructured, resourceHealth *health.HealthStatus, failures []string) pkg.TransformedCheckResult {
+	name := resource.GetKind() + "/" + resource.GetName()
+	if resource.GetNamespace() != "" {
+		name = resource.GetKind() + "/" + resource.GetNamespace() + "/" + resource.GetName()
+	}
+
+	t := pkg.TransformedCheckResult{
+		Name:      name,
+		Namespace: resource.GetNamespace(),
+		Pass:      lo.ToPtr(len(failures) == 0),
+		Error:     strings.Join(failures, ", "),
+		Detail:    resource.Object,
+		Data:      map[string]any{"results": resource.Object},
+		Labels:    lo.Assign(check.Labels, map[string]string{"kind": resource.GetKind()}),
+		Endpoint:  fmt.Sprintf("%s/%s/%s", resource.GetKind(), resource.GetNamespace(), resource.GetName()),
+	}
+	if resourceHealth != nil {
+		t.Message = strings.TrimSpace(fmt.Sprintf("%s %s", resourceHealth.Status, resourceHealth.Message))
+	}
+	return t
+}
+
+// KubernetesEvent is a warning event of an unhealthy resource
+type KubernetesEvent struct {
+	Reason   string    `json:"reason"`
+	Message  string    `json:"message"`
+	Count    int32     `json:"count"`
+	LastSeen time.Time `json:"lastSeen"`
+}
+
+// getWarningEvents returns the recent warning events of the resource, newest first
+func getWarningEvents(ctx context.Context, resource unstructured.Unstructured) ([]KubernetesEvent, error) {
+	list, err := ctx.Kubernetes().CoreV1().Events(resource.GetNamespace()).List(ctx, metav1.ListOptions{
+		FieldSelector: fmt.Sprintf("involvedObject.uid=%s,type=%s", resource.GetUID(), corev1.EventTypeWarning),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var events []KubernetesEvent
+	for _, event := range list.Items {
+		lastSeen := event.LastTimestamp.Time
+		if lastSeen.IsZero() {
+			lastSeen = event.EventTime.Time
+		}
+		if time.Since(lastSeen) > kubernetesEventsWindow {
+			continue
+		}
+		events = append(events, KubernetesEvent{
+			Reason:   event.Reason,
+			Message:  event.Message,
+			Count:    event.Count,
+			LastSeen: lastSeen,
+		})
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].LastSeen.After(events[j].LastSeen)
+	})
+	if len(events) > kubernetesEventsLimit {
+		events = events[:kubernetesEventsLimit]
+	}
+	return events, nil
+}
+
 func getResourcesFromNamespace(ctx context.Context, client dynamic.NamespaceableResourceInterface, check v1.KubernetesCheck, namespace string) ([]unstructured.Unstructured, error) {
 	var resources []unstructured.Unstructured
 	if check.Resource.Name != "" {
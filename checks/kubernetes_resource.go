@@ -21,6 +21,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/dynamic"
 
 	"github.com/flanksource/canary-checker/api/context"
@@ -75,18 +76,15 @@ func (c *KubernetesResourceChecker) Check(ctx context.Context, check v1.Kubernet
 		return results.Failf("validation: %v", err)
 	}
 
-	if check.Kubeconfig != nil {
-		var err error
-		ctx, err = ctx.WithKubeconfig(*check.Kubeconfig)
-		if err != nil {
-			return results.WithError(err).Invalidf("Cannot connect to kubernetes")
-		}
+	ctx, err := ctx.WithKubernetesConnection(check.KubernetesConnection)
+	if err != nil {
+		return results.WithError(err).Invalidf("Cannot connect to kubernetes")
 	}
 
 	if check.HasResourcesWithMissingNamespace() {
 		namespacedResources, err := fetchNamespacedResources(ctx)
 		if err != nil {
-			return results.Failf("failed to get api resources: %w", err)
+			return results.Failf("failed to get api resources: %v", err)
 		}
 
 		check.SetMissingNamespace(ctx.Canary, namespacedResources)
@@ -98,6 +96,10 @@ func (c *KubernetesResourceChecker) Check(ctx context.Context, check v1.Kubernet
 		return results.Failf("templating error: %v", err)
 	}
 
+	if check.DryRun {
+		return c.dryRunResources(ctx, check, results)
+	}
+
 	kClient := kommons.NewClient(ctx.KubernetesRestConfig(), ctx.Logger)
 
 	for i := range check.StaticResources {
@@ -325,6 +327,10 @@ func (c *KubernetesResourceChecker) validate(ctx context.Context, check v1.Kuber
 		return fmt.Errorf("too many resources (%d). only %d supported", check.TotalResources(), maxResourcesAllowed)
 	}
 
+	if errs := check.Validate(field.NewPath("kubernetesResource")); len(errs) > 0 {
+		return errs.ToAggregate()
+	}
+
 	return nil
 }
 
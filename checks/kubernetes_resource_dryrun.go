@@ -0,0 +1,596 @@
+package checks
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/flanksource/canary-checker/api/context"
+	v1 "github.com/flanksource/canary-checker/api/v1"
+	"github.com/flanksource/canary-checker/pkg"
+	cDiff "github.com/flanksource/commons/diff"
+	"github.com/samber/lo"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/structured-merge-diff/v4/fieldpath"
+	"sigs.k8s.io/structured-merge-diff/v4/value"
+)
+
+const dryRunFieldManager = "canary-checker"
+
+// KubernetesDryRunResult is the outcome of submitting a resource with a server-side dry-run
+type KubernetesDryRunResult struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	// Allowed is false when the resource was rejected e.g. by a validating admission webhook
+	Allowed bool `json:"allowed"`
+	// Reason the resource was rejected e.g. Forbidden, Invalid or BadRequest
+	Reason   string   `json:"reason,omitempty"`
+	Message  string   `json:"message,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+	// Mutated is true when the admitted resource differs from the submitted resource, other than by the
+	// fields defaulted by the API server and the fields of an existing resource that are managed by others
+	Mutated bool `json:"mutated"`
+	// Diff from the submitted to the admitted resource, without the defaulted fields and the fields managed by others
+	Diff string `json:"diff,omitempty"`
+	// Exists is true when the resource already exists in the cluster
+	Exists bool `json:"exists"`
+	// Drift from the live to the admitted resource
+	Drift string `json:"drift,omitempty"`
+	// Object is the admitted resource
+	Object map[string]any `json:"object,omitempty"`
+}
+
+func (r KubernetesDryRunResult) String() string {
+	if !r.Allowed {
+		return "denied: " + r.Message
+	}
+	var s []string
+	if r.Mutated {
+		s = append(s, "mutated")
+	}
+	if r.Drift != "" {
+		s = append(s, "drifted")
+	}
+	if len(r.Warnings) > 0 {
+		s = append(s, "warnings: "+strings.Join(r.Warnings, ", "))
+	}
+	if len(s) == 0 {
+		return "allowed"
+	}
+	return "allowed, " + strings.Join(s, ", ")
+}
+
+// dryRunWarnings collects the warnings returned by the API server, e.g. by policies in audit or warn mode
+type dryRunWarnings struct {
+	lock     sync.Mutex
+	warnings []string
+}
+
+func (w *dryRunWarnings) HandleWarningHeader(_ int, _ string, text string) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.warnings = append(w.warnings, text)
+}
+
+// dryRunResources submits the resources with a server-side dry-run, nothing is created in the cluster
+func (c *KubernetesResourceChecker) dryRunResources(ctx context.Context, check v1.KubernetesResourceCheck, results pkg.Results) pkg.Results {
+	result := results[0]
+	mapper, err := ctx.KubernetesClient().GetRestMapper()
+	if err != nil {
+		return results.Failf("failed to get rest mapper: %v", err)
+	}
+
+	var dryRuns []KubernetesDryRunResult
+	display := map[string]string{}
+	for _, resource := range append(check.StaticResources, check.Resources...) {
+		dryRun, err := dryRunResource(ctx, mapper, resource)
+		if err != nil {
+			return results.Failf("failed to dry-run resource (%s/%s/%s): %v", resource.GetKind(), resource.GetNamespace(), resource.GetName(), err)
+		}
+		dryRuns = append(dryRuns, *dryRun)
+		display[getName(resource)] = dryRun.String()
+	}
+
+	data, err := asMaps(dryRuns)
+	if err != nil {
+		return results.Failf("%v", err)
+	}
+	result.AddData(map[string]any{
+		"dryRun":  data,
+		"display": display,
+	})
+
+	if check.Test.IsEmpty() {
+		for _, dryRun := range dryRuns {
+			if !dryRun.Allowed {
+				return results.Failf("%s/%s/%s was denied: %s", dryRun.Kind, dryRun.Namespace, dryRun.Name, dryRun.Message)
+			}
+		}
+	}
+	return results
+}
+
+func dryRunResource(ctx context.Context, mapper meta.RESTMapper, resource unstructured.Unstructured) (*KubernetesDryRunResult, error) {
+	gvk := resource.GroupVersionKind()
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	// a client per resource so that warnings are attributed to the resource that caused them
+	warnings := &dryRunWarnings{}
+	config := rest.CopyConfig(ctx.KubernetesRestConfig())
+	config.WarningHandler = warnings
+	client, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	var rc dynamic.ResourceInterface = client.Resource(mapping.Resource)
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		rc = client.Resource(mapping.Resource).Namespace(resource.GetNamespace())
+	}
+
+	dryRun := &KubernetesDryRunResult{
+		Kind:      resource.GetKind(),
+		Namespace: resource.GetNamespace(),
+		Name:      resource.GetName(),
+	}
+
+	live, err := rc.Get(ctx, resource.GetName(), metav1.GetOptions{})
+	if err != nil && !apiErrors.IsNotFound(err) {
+		return nil, err
+	}
+	dryRun.Exists = err == nil
+
+	admitted, err := rc.Apply(ctx, resource.GetName(), &resource, metav1.ApplyOptions{
+		DryRun:       []string{metav1.DryRunAll},
+		FieldManager: dryRunFieldManager,
+		Force:        true,
+	})
+	dryRun.Warnings = warnings.warnings
+	if err != nil {
+		if !isAdmissionDenial(err) {
+			return nil, err
+		}
+		// authorization errors are also forbidden, but are returned before the resource is admitted
+		if apiErrors.IsForbidden(err) {
+			if allowed, sarErr := canApply(ctx, ctx.Kubernetes(), mapping, resource); sarErr != nil || !allowed {
+				return nil, err
+			}
+		}
+		dryRun.Reason = string(apiErrors.ReasonForError(err))
+		dryRun.Message = err.Error()
+		return dryRun, nil
+	}
+
+	dryRun.Allowed = true
+	dryRun.Object = stripServerFields(*admitted).Object
+	if dryRun.Diff, err = mutationDiff(resource, *admitted); err != nil {
+		return nil, err
+	}
+	dryRun.Mutated = dryRun.Diff != ""
+	if dryRun.Exists {
+		if dryRun.Drift, err = diffResources(stripServerFields(*live), stripServerFields(*admitted)); err != nil {
+			return nil, err
+		}
+	}
+	return dryRun, nil
+}
+
+// isAdmissionDenial returns true if the API server rejected the resource, rather than failing to process it.
+// Webhooks that cannot be called fail closed with an internal or timeout error, which are not denials.
+func isAdmissionDenial(err error) bool {
+	return apiErrors.IsInvalid(err) || apiErrors.IsBadRequest(err) || apiErrors.IsForbidden(err)
+}
+
+// canApply returns true if the client is authorized to apply the resource
+func canApply(ctx context.Context, client kubernetes.Interface, mapping *meta.RESTMapping, resource unstructured.Unstructured) (bool, error) {
+	var namespace string
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		namespace = resource.GetNamespace()
+	}
+	review := &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      "patch",
+				Group:     mapping.Resource.Group,
+				Resource:  mapping.Resource.Resource,
+				Name:      resource.GetName(),
+			},
+		},
+	}
+	review, err := client.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, review, metav1.CreateOptions{})
+	if err != nil {
+		return false, err
+	}
+	return review.Status.Allowed, nil
+}
+
+// derived is the default of the fields that are allocated or derived from other fields by the API server, e.g. the
+// cluster IP of a service, it matches any value
+type derived struct{}
+
+// defaultedItem returns an identifier of the list items that are added by built-in admission plugins, or "" for
+// items that are not
+type defaultedItem func(item any) string
+
+// fieldDefaults are the values set by the API server defaulting and its built-in admission plugins when the fields
+// are omitted, keyed by the path of the field with [] for the items of a list
+type fieldDefaults map[string]any
+
+const serviceAccountMountPath = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+func containerDefaults(path string) fieldDefaults {
+	defaults := fieldDefaults{
+		path + ".imagePullPolicy":                     derived{},
+		path + ".terminationMessagePath":              "/dev/termination-log",
+		path + ".terminationMessagePolicy":            "File",
+		path + ".resources":                           map[string]any{},
+		path + ".ports[].protocol":                    "TCP",
+		path + ".env[].valueFrom.fieldRef.apiVersion": "v1",
+	}
+	for _, probe := range []string{"livenessProbe", "readinessProbe", "startupProbe"} {
+		defaults[path+"."+probe+".timeoutSeconds"] = int64(1)
+		defaults[path+"."+probe+".periodSeconds"] = int64(10)
+		defaults[path+"."+probe+".successThreshold"] = int64(1)
+		defaults[path+"."+probe+".failureThreshold"] = int64(3)
+		defaults[path+"."+probe+".httpGet.scheme"] = "HTTP"
+	}
+	return defaults
+}
+
+func podSpecDefaults(path string) fieldDefaults {
+	return lo.Assign(fieldDefaults{
+		path + ".dnsPolicy":                         "ClusterFirst",
+		path + ".enableServiceLinks":                true,
+		path + ".restartPolicy":                     "Always",
+		path + ".schedulerName":                     "default-scheduler",
+		path + ".securityContext":                   map[string]any{},
+		path + ".terminationGracePeriodSeconds":     int64(30),
+		path + ".volumes[].configMap.defaultMode":   int64(420),
+		path + ".volumes[].secret.defaultMode":      int64(420),
+		path + ".volumes[].projected.defaultMode":   int64(420),
+		path + ".volumes[].downwardAPI.defaultMode": int64(420),
+	}, containerDefaults(path+".containers[]"), containerDefaults(path+".initContainers[]"))
+}
+
+func podTemplateDefaults(path string) fieldDefaults {
+	return lo.Assign(fieldDefaults{path + ".metadata.creationTimestamp": nil}, podSpecDefaults(path+".spec"))
+}
+
+// podAdmissionDefaults are set on pods, but not on pod templates, by the ServiceAccount, Priority and
+// DefaultTolerationSeconds admission plugins
+var podAdmissionDefaults = fieldDefaults{
+	"spec.serviceAccountName": "default",
+	"spec.serviceAccount":     "default",
+	"spec.priority":           int64(0),
+	"spec.preemptionPolicy":   "PreemptLowerPriority",
+	"spec.volumes[]": defaultedItem(func(item any) string {
+		m, _ := item.(map[string]any)
+		name, _ := m["name"].(string)
+		return lo.Ternary(strings.HasPrefix(name, "kube-api-access-"), name, "")
+	}),
+	"spec.containers[].volumeMounts[]":     defaultedItem(serviceAccountMount),
+	"spec.initContainers[].volumeMounts[]": defaultedItem(serviceAccountMount),
+	"spec.tolerations[]": defaultedItem(func(item any) string {
+		m, _ := item.(map[string]any)
+		key, _ := m["key"].(string)
+		return lo.Ternary(key == "node.kubernetes.io/not-ready" || key == "node.kubernetes.io/unreachable", key, "")
+	}),
+}
+
+func serviceAccountMount(item any) string {
+	m, _ := item.(map[string]any)
+	path, _ := m["mountPath"].(string)
+	return lo.Ternary(path == serviceAccountMountPath, path, "")
+}
+
+// resourceDefaults are the defaults of the built-in kinds, the fields of other kinds e.g. custom resources are
+// always compared
+var resourceDefaults = map[schema.GroupKind]fieldDefaults{
+	{Kind: "Pod"}: lo.Assign(podSpecDefaults("spec"), podAdmissionDefaults),
+	{Kind: "Service"}: {
+		"spec.clusterIP":                     derived{},
+		"spec.clusterIPs":                    derived{},
+		"spec.ipFamilies":                    derived{},
+		"spec.ipFamilyPolicy":                derived{},
+		"spec.internalTrafficPolicy":         "Cluster",
+		"spec.externalTrafficPolicy":         "Cluster",
+		"spec.allocateLoadBalancerNodePorts": true,
+		"spec.sessionAffinity":               "None",
+		"spec.type":                          "ClusterIP",
+		"spec.ports[].protocol":              "TCP",
+		"spec.ports[].targetPort":            derived{},
+		"spec.ports[].nodePort":              derived{},
+	},
+	{Kind: "Namespace"}: {
+		"metadata.labels.kubernetes.io/metadata.name": derived{},
+		"spec.finalizers": []any{"kubernetes"},
+	},
+	{Group: "apps", Kind: "Deployment"}: lo.Assign(fieldDefaults{
+		"spec.replicas":                              int64(1),
+		"spec.revisionHistoryLimit":                  int64(10),
+		"spec.progressDeadlineSeconds":               int64(600),
+		"spec.strategy.type":                         "RollingUpdate",
+		"spec.strategy.rollingUpdate.maxSurge":       "25%",
+		"spec.strategy.rollingUpdate.maxUnavailable": "25%",
+	}, podTemplateDefaults("spec.template")),
+	{Group: "apps", Kind: "StatefulSet"}: lo.Assign(fieldDefaults{
+		"spec.replicas":                                          int64(1),
+		"spec.revisionHistoryLimit":                              int64(10),
+		"spec.podManagementPolicy":                               "OrderedReady",
+		"spec.updateStrategy.type":                               "RollingUpdate",
+		"spec.updateStrategy.rollingUpdate.partition":            int64(0),
+		"spec.persistentVolumeClaimRetentionPolicy.whenDeleted":  "Retain",
+		"spec.persistentVolumeClaimRetentionPolicy.whenScaled":   "Retain",
+		"spec.volumeClaimTemplates[].apiVersion":                 "v1",
+		"spec.volumeClaimTemplates[].kind":                       "PersistentVolumeClaim",
+		"spec.volumeClaimTemplates[].metadata.creationTimestamp": nil,
+		"spec.volumeClaimTemplates[].spec.volumeMode":            "Filesystem",
+		"spec.volumeClaimTemplates[].status":                     derived{},
+	}, podTemplateDefaults("spec.template")),
+	{Group: "apps", Kind: "DaemonSet"}: lo.Assign(fieldDefaults{
+		"spec.revisionHistoryLimit":                        int64(10),
+		"spec.updateStrategy.type":                         "RollingUpdate",
+		"spec.updateStrategy.rollingUpdate.maxSurge":       int64(0),
+		"spec.updateStrategy.rollingUpdate.maxUnavailable": int64(1),
+	}, podTemplateDefaults("spec.template")),
+	{Group: "apps", Kind: "ReplicaSet"}: lo.Assign(fieldDefaults{
+		"spec.replicas": int64(1),
+	}, podTemplateDefaults("spec.template")),
+	{Group: "batch", Kind: "Job"}: lo.Assign(fieldDefaults{
+		"spec.backoffLimit":         int64(6),
+		"spec.completionMode":       "NonIndexed",
+		"spec.completions":          int64(1),
+		"spec.parallelism":          int64(1),
+		"spec.suspend":              false,
+		"spec.podReplacementPolicy": "TerminatingOrFailed",
+		"spec.selector":             derived{},
+		"spec.template.metadata.labels.batch.kubernetes.io/controller-uid": derived{},
+		"spec.template.metadata.labels.batch.kubernetes.io/job-name":       derived{},
+		"spec.template.metadata.labels.controller-uid":                     derived{},
+		"spec.template.metadata.labels.job-name":                           derived{},
+	}, podTemplateDefaults("spec.template")),
+	{Group: "batch", Kind: "CronJob"}: lo.Assign(fieldDefaults{
+		"spec.concurrencyPolicy":                      "Allow",
+		"spec.suspend":                                false,
+		"spec.successfulJobsHistoryLimit":             int64(3),
+		"spec.failedJobsHistoryLimit":                 int64(1),
+		"spec.jobTemplate.metadata.creationTimestamp": nil,
+	}, podTemplateDefaults("spec.jobTemplate.spec.template")),
+}
+
+// isDefault returns true if the value of an omitted field is its default
+func isDefault(d, value any) bool {
+	switch d.(type) {
+	case derived:
+		return true
+	case defaultedItem:
+		return false
+	}
+	return reflect.DeepEqual(d, value)
+}
+
+// size returns the number of fields or items of objects and lists, and -1 for other values
+func size(value any) int {
+	switch v := value.(type) {
+	case map[string]any:
+		return len(v)
+	case []any:
+		return len(v)
+	}
+	return -1
+}
+
+// submittedItem returns the submitted item of the admitted list item, by name or else by index
+func submittedItem(submitted []any, i int, item any) any {
+	m, _ := item.(map[string]any)
+	if name, ok := m["name"]; ok {
+		s, _ := lo.Find(submitted, func(s any) bool {
+			sm, _ := s.(map[string]any)
+			return sm["name"] == name
+		})
+		return s
+	}
+	if i < len(submitted) {
+		return submitted[i]
+	}
+	return nil
+}
+
+// pruneDefaults removes the fields and list items of the admitted resource at the path that were omitted from the
+// submitted resource and have their default value, so that only the mutations by webhooks remain
+func pruneDefaults(defaults fieldDefaults, path string, submitted, admitted any) any {
+	switch a := admitted.(type) {
+	case map[string]any:
+		s, _ := submitted.(map[string]any)
+		for key, value := range a {
+			fieldPath := lo.Ternary(path == "", key, path+"."+key)
+			if sv, ok := s[key]; ok {
+				a[key] = pruneDefaults(defaults, fieldPath, sv, value)
+				continue
+			}
+			if d, ok := defaults[fieldPath]; ok && isDefault(d, value) {
+				delete(a, key)
+				continue
+			}
+			// the fields of omitted objects and the items of omitted lists may also be defaulted
+			n := size(value)
+			if pruned := pruneDefaults(defaults, fieldPath, nil, value); n > 0 && size(pruned) == 0 {
+				delete(a, key)
+			} else {
+				a[key] = pruned
+			}
+		}
+		return a
+	case []any:
+		s, _ := submitted.([]any)
+		itemPath := path + "[]"
+		if id, ok := defaults[itemPath].(defaultedItem); ok {
+			submittedItems := lo.Map(s, func(item any, _ int) string { return id(item) })
+			a = lo.Filter(a, func(item any, _ int) bool {
+				return id(item) == "" || lo.Contains(submittedItems, id(item))
+			})
+		}
+		for i, item := range a {
+			a[i] = pruneDefaults(defaults, itemPath, submittedItem(s, i, item), item)
+		}
+		return a
+	}
+	return admitted
+}
+
+// mutationDiff returns the diff from the submitted to the admitted resource, without the fields defaulted by the
+// API server and, for existing resources, the fields that are only managed by other field managers
+func mutationDiff(submitted, admitted unstructured.Unstructured) (string, error) {
+	mutated, err := removeFieldsOfOtherManagers(admitted.DeepCopy().Object, admitted.GetManagedFields())
+	if err != nil {
+		return "", err
+	}
+	mutated = pruneDefaults(resourceDefaults[submitted.GroupVersionKind().GroupKind()], "", submitted.Object, mutated).(map[string]any)
+	return diffResources(stripServerFields(submitted), stripServerFields(unstructured.Unstructured{Object: mutated}))
+}
+
+// removeFieldsOfOtherManagers removes the fields that are managed by other field managers and not by the dry-run,
+// e.g. the replicas of a deployment that was scaled. The fields that are not managed at all, i.e. the defaults and
+// the mutations by webhooks, are kept.
+func removeFieldsOfOtherManagers(obj map[string]any, managedFields []metav1.ManagedFieldsEntry) (map[string]any, error) {
+	applied, others := &fieldpath.Set{}, &fieldpath.Set{}
+	for _, entry := range managedFields {
+		if entry.FieldsV1 == nil {
+			continue
+		}
+		fields := &fieldpath.Set{}
+		if err := fields.FromJSON(bytes.NewReader(entry.FieldsV1.Raw)); err != nil {
+			return nil, fmt.Errorf("failed to parse the fields managed by %s: %w", entry.Manager, err)
+		}
+		if entry.Manager == dryRunFieldManager && entry.Operation == metav1.ManagedFieldsOperationApply {
+			applied = applied.Union(fields)
+		} else {
+			others = others.Union(fields)
+		}
+	}
+	var paths []fieldpath.Path
+	others.Difference(applied).Leaves().Iterate(func(path fieldpath.Path) {
+		// objects and lists that other managers own, but that contain applied fields, are kept
+		if !managesChildren(applied, path) {
+			paths = append(paths, path.Copy())
+		}
+	})
+	obj = removeFields(obj, fieldpath.NewSet(paths...)).(map[string]any)
+	return obj, nil
+}
+
+// managesChildren returns true if the set contains fields below the path
+func managesChildren(set *fieldpath.Set, path fieldpath.Path) bool {
+	for _, pe := range path {
+		child, ok := set.Children.Get(pe)
+		if !ok {
+			return false
+		}
+		set = child
+	}
+	return !set.Empty()
+}
+
+// removeFields removes the fields and list items of the set from the value, and the objects and lists that are
+// left empty
+func removeFields(v any, fields *fieldpath.Set) any {
+	switch o := v.(type) {
+	case map[string]any:
+		for key, child := range o {
+			if child, keep := removeElement(child, fieldpath.PathElement{FieldName: &key}, fields); keep {
+				o[key] = child
+			} else {
+				delete(o, key)
+			}
+		}
+		return o
+	case []any:
+		var items []any
+		for i, item := range o {
+			pe, ok := listElement(fields, i, item)
+			if !ok {
+				items = append(items, item)
+			} else if item, keep := removeElement(item, pe, fields); keep {
+				items = append(items, item)
+			}
+		}
+		return items
+	}
+	return v
+}
+
+// removeElement removes the fields of the set below the path element from the value, it returns false if the
+// value itself is removed
+func removeElement(v any, pe fieldpath.PathElement, fields *fieldpath.Set) (any, bool) {
+	if children, ok := fields.Children.Get(pe); ok {
+		n := size(v)
+		v = removeFields(v, children)
+		return v, n <= 0 || size(v) > 0
+	}
+	return v, !fields.Members.Has(pe)
+}
+
+// listElement returns the path element of the set that selects the list item, by its keys, value or index
+func listElement(fields *fieldpath.Set, i int, item any) (fieldpath.PathElement, bool) {
+	var elements []fieldpath.PathElement
+	fields.Members.Iterate(func(pe fieldpath.PathElement) { elements = append(elements, pe) })
+	fields.Children.Iterate(func(pe fieldpath.PathElement) { elements = append(elements, pe) })
+	return lo.Find(elements, func(pe fieldpath.PathElement) bool {
+		switch {
+		case pe.Key != nil:
+			m, ok := item.(map[string]any)
+			return ok && lo.EveryBy(*pe.Key, func(key value.Field) bool {
+				v, ok := m[key.Name]
+				return ok && value.Equals(key.Value, value.NewValueInterface(v))
+			})
+		case pe.Value != nil:
+			return value.Equals(*pe.Value, value.NewValueInterface(item))
+		case pe.Index != nil:
+			return *pe.Index == i
+		}
+		return false
+	})
+}
+
+// stripServerFields removes the fields that are set by the API server on every write
+func stripServerFields(obj unstructured.Unstructured) unstructured.Unstructured {
+	obj = *obj.DeepCopy()
+	for _, f := range []string{"uid", "resourceVersion", "generation", "creationTimestamp", "managedFields", "selfLink"} {
+		unstructured.RemoveNestedField(obj.Object, "metadata", f)
+	}
+	unstructured.RemoveNestedField(obj.Object, "status")
+	return obj
+}
+
+// diffResources returns a unified diff between the resources
+func diffResources(from, to unstructured.Unstructured) (string, error) {
+	before, err := json.Marshal(from.Object)
+	if err != nil {
+		return "", err
+	}
+	after, err := json.Marshal(to.Object)
+	if err != nil {
+		return "", err
+	}
+	d, err := cDiff.JSONCompare(string(after), string(before))
+	if err != nil {
+		return "", fmt.Errorf("failed to diff %s: %w", getName(to), err)
+	}
+	return d, nil
+}
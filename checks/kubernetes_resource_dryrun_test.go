@@ -0,0 +1,269 @@
+package checks
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/flanksource/canary-checker/api/context"
+	v1 "github.com/flanksource/canary-checker/api/v1"
+	dutyCtx "github.com/flanksource/duty/context"
+	. "github.com/onsi/gomega"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8sTesting "k8s.io/client-go/testing"
+)
+
+func TestDiffResources(t *testing.T) {
+	RegisterTestingT(t)
+	submitted := newTestPod("web", "")
+	admitted := *submitted.DeepCopy()
+	admitted.SetUID("uid")
+	admitted.SetResourceVersion("1")
+	unstructured.RemoveNestedField(admitted.Object, "status")
+
+	d, err := diffResources(stripServerFields(submitted), stripServerFields(admitted))
+	Expect(err).ToNot(HaveOccurred())
+	Expect(d).To(BeEmpty())
+
+	// the live resource is compared to the admitted resource without the fields set on every write
+	live := *admitted.DeepCopy()
+	live.SetResourceVersion("2")
+	live.SetGeneration(2)
+	live.SetManagedFields([]metav1.ManagedFieldsEntry{{Manager: "kubectl", Operation: metav1.ManagedFieldsOperationUpdate}})
+	d, err = diffResources(stripServerFields(live), stripServerFields(admitted))
+	Expect(err).ToNot(HaveOccurred())
+	Expect(d).To(BeEmpty())
+
+	admitted.SetLabels(map[string]string{"injected": "true"})
+	d, err = diffResources(stripServerFields(submitted), stripServerFields(admitted))
+	Expect(err).ToNot(HaveOccurred())
+	Expect(d).To(ContainSubstring(`+		"labels": {`))
+	Expect(d).To(ContainSubstring(`+			"injected": "true"`))
+
+	stripped := stripServerFields(admitted)
+	Expect(stripped.GetUID()).To(BeEmpty())
+	Expect(admitted.GetUID()).ToNot(BeEmpty())
+}
+
+func TestPruneDefaults(t *testing.T) {
+	RegisterTestingT(t)
+	pod := schema.GroupKind{Kind: "Pod"}
+	submitted := newTestPod("web", "")
+	unstructured.RemoveNestedField(submitted.Object, "status")
+	Expect(unstructured.SetNestedSlice(submitted.Object, []any{
+		map[string]any{"name": "nginx", "image": "nginx"},
+	}, "spec", "containers")).To(Succeed())
+
+	admitted := *submitted.DeepCopy()
+	Expect(unstructured.SetNestedMap(admitted.Object, map[string]any{
+		"dnsPolicy":     "ClusterFirst",
+		"restartPolicy": "Always",
+		"tolerations": []any{
+			map[string]any{"key": "node.kubernetes.io/not-ready", "operator": "Exists", "effect": "NoExecute", "tolerationSeconds": int64(300)},
+		},
+		"volumes": []any{map[string]any{"name": "kube-api-access-x7k2p"}},
+		"containers": []any{map[string]any{
+			"name":                     "nginx",
+			"image":                    "nginx",
+			"imagePullPolicy":          "Always",
+			"resources":                map[string]any{},
+			"terminationMessagePath":   "/dev/termination-log",
+			"terminationMessagePolicy": "File",
+			"volumeMounts":             []any{map[string]any{"name": "kube-api-access-x7k2p", "mountPath": "/var/run/secrets/kubernetes.io/serviceaccount"}},
+		}},
+	}, "spec")).To(Succeed())
+
+	pruned := unstructured.Unstructured{Object: pruneDefaults(resourceDefaults[pod], "", submitted.Object, admitted.DeepCopy().Object).(map[string]any)}
+	d, err := diffResources(stripServerFields(submitted), stripServerFields(pruned))
+	Expect(err).ToNot(HaveOccurred())
+	Expect(d).To(BeEmpty())
+
+	// fields and items added by webhooks are kept
+	containers, _, _ := unstructured.NestedSlice(admitted.Object, "spec", "containers")
+	Expect(unstructured.SetNestedSlice(admitted.Object, append(containers, map[string]any{"name": "sidecar", "image": "envoy"}), "spec", "containers")).To(Succeed())
+	admitted.SetAnnotations(map[string]string{"injected": "true"})
+	Expect(unstructured.SetNestedField(admitted.Object, "vault-agent", "spec", "serviceAccountName")).To(Succeed())
+	pruned = unstructured.Unstructured{Object: pruneDefaults(resourceDefaults[pod], "", submitted.Object, admitted.DeepCopy().Object).(map[string]any)}
+	d, err = diffResources(stripServerFields(submitted), stripServerFields(pruned))
+	Expect(err).ToNot(HaveOccurred())
+	Expect(d).To(ContainSubstring(`"sidecar"`))
+	Expect(d).To(ContainSubstring(`"injected": "true"`))
+	Expect(d).To(ContainSubstring(`"serviceAccountName": "vault-agent"`))
+	Expect(d).ToNot(ContainSubstring("imagePullPolicy"))
+	Expect(d).ToNot(ContainSubstring("tolerations"))
+}
+
+func TestPruneDefaultsByKind(t *testing.T) {
+	RegisterTestingT(t)
+	deployment := func(spec map[string]any) unstructured.Unstructured {
+		return unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata":   map[string]any{"name": "web", "namespace": "default"},
+			"spec":       spec,
+		}}
+	}
+	template := func(podSpec map[string]any) map[string]any {
+		return map[string]any{"metadata": map[string]any{"labels": map[string]any{"app": "web"}}, "spec": podSpec}
+	}
+	submitted := deployment(map[string]any{
+		"template": template(map[string]any{"containers": []any{map[string]any{"name": "nginx", "image": "nginx"}}}),
+	})
+	admitted := deployment(map[string]any{
+		"replicas":                int64(1),
+		"revisionHistoryLimit":    int64(10),
+		"progressDeadlineSeconds": int64(600),
+		"strategy":                map[string]any{"type": "RollingUpdate", "rollingUpdate": map[string]any{"maxSurge": "25%", "maxUnavailable": "25%"}},
+		"template": template(map[string]any{
+			"dnsPolicy":                     "ClusterFirst",
+			"restartPolicy":                 "Always",
+			"schedulerName":                 "default-scheduler",
+			"securityContext":               map[string]any{},
+			"terminationGracePeriodSeconds": int64(30),
+			"containers": []any{map[string]any{
+				"name":                     "nginx",
+				"image":                    "nginx",
+				"imagePullPolicy":          "Always",
+				"resources":                map[string]any{},
+				"terminationMessagePath":   "/dev/termination-log",
+				"terminationMessagePolicy": "File",
+			}},
+		}),
+	})
+	apps := schema.GroupKind{Group: "apps", Kind: "Deployment"}
+	prune := func(gk schema.GroupKind, submitted, admitted unstructured.Unstructured) string {
+		pruned := unstructured.Unstructured{Object: pruneDefaults(resourceDefaults[gk], "", submitted.Object, admitted.DeepCopy().Object).(map[string]any)}
+		d, err := diffResources(stripServerFields(submitted), stripServerFields(pruned))
+		Expect(err).ToNot(HaveOccurred())
+		return d
+	}
+	Expect(prune(apps, submitted, admitted)).To(BeEmpty())
+
+	// values other than the defaults are set by webhooks
+	Expect(unstructured.SetNestedField(admitted.Object, int64(3), "spec", "replicas")).To(Succeed())
+	Expect(unstructured.SetNestedField(admitted.Object, "Recreate", "spec", "strategy", "type")).To(Succeed())
+	Expect(unstructured.SetNestedField(admitted.Object, "vault-agent", "spec", "template", "spec", "serviceAccountName")).To(Succeed())
+	d := prune(apps, submitted, admitted)
+	Expect(d).To(ContainSubstring(`"replicas": 3`))
+	Expect(d).To(ContainSubstring(`"Recreate"`))
+	Expect(d).To(ContainSubstring(`"vault-agent"`))
+	Expect(d).ToNot(ContainSubstring("dnsPolicy"))
+
+	// service accounts are only defaulted on pods, not on pod templates
+	Expect(unstructured.SetNestedField(admitted.Object, "default", "spec", "template", "spec", "serviceAccountName")).To(Succeed())
+	Expect(prune(apps, submitted, admitted)).To(ContainSubstring(`"serviceAccountName": "default"`))
+	Expect(prune(schema.GroupKind{Kind: "Pod"}, submitted, admitted)).To(ContainSubstring(`"dnsPolicy"`))
+
+	// the fields of custom resources are always compared
+	crd := schema.GroupKind{Group: "example.com", Kind: "Deployment"}
+	Expect(prune(crd, submitted, admitted)).To(ContainSubstring(`"terminationMessagePath"`))
+}
+
+func TestMutationDiffOfExistingResource(t *testing.T) {
+	RegisterTestingT(t)
+	submitted := unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]any{"name": "web", "namespace": "default", "labels": map[string]any{"app": "web"}},
+		"spec": map[string]any{
+			"template": map[string]any{
+				"metadata": map[string]any{"labels": map[string]any{"app": "web"}},
+				"spec":     map[string]any{"containers": []any{map[string]any{"name": "nginx", "image": "nginx"}}},
+			},
+		},
+	}}
+
+	// the live deployment was scaled and labelled with kubectl and has a debug container added by another manager
+	admitted := *submitted.DeepCopy()
+	admitted.SetLabels(map[string]string{"app": "web", "team": "platform"})
+	admitted.SetAnnotations(map[string]string{"deployment.kubernetes.io/revision": "2"})
+	Expect(unstructured.SetNestedField(admitted.Object, int64(3), "spec", "replicas")).To(Succeed())
+	Expect(unstructured.SetNestedSlice(admitted.Object, []any{
+		map[string]any{"name": "nginx", "image": "nginx"},
+		map[string]any{"name": "debug", "image": "busybox", "command": []any{"sleep", "infinity"}},
+	}, "spec", "template", "spec", "containers")).To(Succeed())
+	managedFields := func(manager string, operation metav1.ManagedFieldsOperationType, fields string) metav1.ManagedFieldsEntry {
+		return metav1.ManagedFieldsEntry{Manager: manager, Operation: operation, FieldsType: "FieldsV1", FieldsV1: &metav1.FieldsV1{Raw: []byte(fields)}}
+	}
+	admitted.SetManagedFields([]metav1.ManagedFieldsEntry{
+		managedFields(dryRunFieldManager, metav1.ManagedFieldsOperationApply, `{"f:metadata":{"f:labels":{"f:app":{}}},"f:spec":{"f:template":{"f:metadata":{"f:labels":{"f:app":{}}},"f:spec":{"f:containers":{"k:{\"name\":\"nginx\"}":{".":{},"f:image":{},"f:name":{}}}}}}}`),
+		managedFields("kubectl", metav1.ManagedFieldsOperationUpdate, `{"f:metadata":{"f:labels":{".":{},"f:app":{},"f:team":{}}},"f:spec":{"f:replicas":{}}}`),
+		managedFields("kube-controller-manager", metav1.ManagedFieldsOperationUpdate, `{"f:metadata":{"f:annotations":{".":{},"f:deployment.kubernetes.io/revision":{}}}}`),
+		managedFields("debugger", metav1.ManagedFieldsOperationUpdate, `{"f:spec":{"f:template":{"f:spec":{"f:containers":{"k:{\"name\":\"debug\"}":{".":{},"f:command":{},"f:image":{},"f:name":{}}}}}}}`),
+	})
+
+	d, err := mutationDiff(submitted, admitted)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(d).To(BeEmpty())
+
+	// fields that are not managed by any manager are mutations, e.g. a sidecar injected by a webhook
+	containers, _, _ := unstructured.NestedSlice(admitted.Object, "spec", "template", "spec", "containers")
+	Expect(unstructured.SetNestedSlice(admitted.Object, append(containers, map[string]any{"name": "sidecar", "image": "envoy"}), "spec", "template", "spec", "containers")).To(Succeed())
+	d, err = mutationDiff(submitted, admitted)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(d).To(ContainSubstring(`"sidecar"`))
+	Expect(d).ToNot(ContainSubstring(`"debug"`))
+	Expect(d).ToNot(ContainSubstring("replicas"))
+	Expect(d).ToNot(ContainSubstring("team"))
+	Expect(d).ToNot(ContainSubstring("revision"))
+}
+
+func TestIsAdmissionDenial(t *testing.T) {
+	RegisterTestingT(t)
+	pods := schema.GroupResource{Resource: "pods"}
+
+	Expect(isAdmissionDenial(apiErrors.NewForbidden(pods, "web", fmt.Errorf(`violates PodSecurity "restricted:latest": privileged`)))).To(BeTrue())
+	Expect(isAdmissionDenial(apiErrors.NewInvalid(schema.GroupKind{Kind: "Pod"}, "web", nil))).To(BeTrue())
+	// webhooks that deny the request set the code, but not always the reason
+	denied := &apiErrors.StatusError{ErrStatus: metav1.Status{Code: http.StatusForbidden, Reason: "PolicyViolation", Message: "privileged containers are not allowed"}}
+	Expect(isAdmissionDenial(denied)).To(BeTrue())
+	denied.ErrStatus = metav1.Status{Code: http.StatusBadRequest, Message: "privileged containers are not allowed"}
+	Expect(isAdmissionDenial(denied)).To(BeTrue())
+
+	// webhooks that cannot be called fail closed with an internal or timeout error
+	Expect(isAdmissionDenial(apiErrors.NewInternalError(fmt.Errorf(`failed calling webhook "policy.example.com": context deadline exceeded`)))).To(BeFalse())
+	Expect(isAdmissionDenial(apiErrors.NewTimeoutError("request did not complete within requested timeout", 0))).To(BeFalse())
+	Expect(isAdmissionDenial(fmt.Errorf("admission webhook denied the request: connection refused"))).To(BeFalse())
+}
+
+func TestCanApply(t *testing.T) {
+	RegisterTestingT(t)
+	ctx := context.New(dutyCtx.New(), v1.Canary{})
+	mapping := &meta.RESTMapping{Resource: schema.GroupVersionResource{Version: "v1", Resource: "pods"}, Scope: meta.RESTScopeNamespace}
+
+	client := fake.NewSimpleClientset()
+	var attributes *authorizationv1.ResourceAttributes
+	client.PrependReactor("create", "selfsubjectaccessreviews", func(action k8sTesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8sTesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		attributes = review.Spec.ResourceAttributes
+		review.Status.Allowed = attributes.Namespace == "default"
+		return true, review, nil
+	})
+
+	allowed, err := canApply(*ctx, client, mapping, newTestPod("web", ""))
+	Expect(err).ToNot(HaveOccurred())
+	Expect(allowed).To(BeTrue())
+	Expect(*attributes).To(Equal(authorizationv1.ResourceAttributes{Namespace: "default", Verb: "patch", Resource: "pods", Name: "web"}))
+
+	// forbidden errors of resources that cannot be applied are authorization errors
+	pod := newTestPod("web", "")
+	pod.SetNamespace("kube-system")
+	allowed, err = canApply(*ctx, client, mapping, pod)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(allowed).To(BeFalse())
+}
+
+func TestKubernetesDryRunResultString(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(KubernetesDryRunResult{Allowed: true}.String()).To(Equal("allowed"))
+	Expect(KubernetesDryRunResult{Allowed: true, Mutated: true, Warnings: []string{"latest tag"}}.String()).To(Equal("allowed, mutated, warnings: latest tag"))
+	Expect(KubernetesDryRunResult{Message: "privileged containers are not allowed"}.String()).To(Equal("denied: privileged containers are not allowed"))
+}
@@ -0,0 +1,154 @@
+package checks
+
+import (
+	"testing"
+	"time"
+
+	v1 "github.com/flanksource/canary-checker/api/v1"
+	"github.com/flanksource/canary-checker/pkg"
+	"github.com/flanksource/is-healthy/pkg/health"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/rest"
+)
+
+func newTestPod(name, phase string) unstructured.Unstructured {
+	return unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata":   map[string]any{"name": name, "namespace": "default"},
+		"status":     map[string]any{"phase": phase},
+	}}
+}
+
+func TestKubernetesResourceResult(t *testing.T) {
+	RegisterTestingT(t)
+	check := v1.KubernetesCheck{
+		Description: v1.Description{Name: "pods", Labels: v1.Labels{"team": "platform"}, TransformDeleteStrategy: v1.OnTransformMarkHealthy},
+		Kind:        "Pod",
+		Healthy:     true,
+	}
+
+	pod := newTestPod("web", "Failed")
+	podHealth, err := health.GetResourceHealth(&pod, nil)
+	Expect(err).ToNot(HaveOccurred())
+	failures := resourceHealthFailures(check, pod, podHealth)
+	Expect(failures).To(HaveLen(1))
+	Expect(failures[0]).To(HavePrefix("Pod/default/web is not healthy"))
+
+	parent := pkg.Success(check, v1.Canary{})
+	result := newTransformedResult(parent, kubernetesResourceResult(check, pod, podHealth, failures))
+	Expect(result.Check.GetName()).To(Equal("Pod/default/web"))
+	Expect(result.Check.GetType()).To(Equal("kubernetes"))
+	Expect(result.Check.GetTransformDeleteStrategy()).To(Equal(v1.OnTransformMarkHealthy))
+	Expect(result.Labels).To(Equal(map[string]string{"team": "platform", "kind": "Pod"}))
+	Expect(result.Canary.Namespace).To(Equal("default"))
+	Expect(result.Transformed).To(BeTrue())
+	Expect(result.Pass).To(BeFalse())
+	Expect(result.Error).To(Equal(failures[0]))
+
+	pod = newTestPod("api", "Succeeded")
+	podHealth, err = health.GetResourceHealth(&pod, nil)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(resourceHealthFailures(check, pod, podHealth)).To(BeEmpty())
+	result = newTransformedResult(parent, kubernetesResourceResult(check, pod, podHealth, nil))
+	Expect(result.Pass).To(BeTrue())
+	Expect(result.Data["results"]).To(Equal(pod.Object))
+}
+
+func TestKubernetesClusterLabel(t *testing.T) {
+	RegisterTestingT(t)
+	check := v1.KubernetesCheck{
+		Description:          v1.Description{Name: "pods", Labels: v1.Labels{"team": "platform"}},
+		KubernetesConnection: v1.KubernetesConnection{Cluster: "eu-west-1"},
+		Kind:                 "Pod",
+	}
+
+	parent := pkg.Success(check, v1.Canary{})
+	Expect(parent.Labels).To(Equal(map[string]string{"cluster": "eu-west-1"}))
+	Expect(pkg.FromV1(v1.Canary{}, check).Labels).To(HaveKeyWithValue("cluster", "eu-west-1"))
+
+	pod := newTestPod("web", "Succeeded")
+	podHealth, err := health.GetResourceHealth(&pod, nil)
+	Expect(err).ToNot(HaveOccurred())
+	result := newTransformedResult(parent, kubernetesResourceResult(check, pod, podHealth, nil))
+	Expect(result.Labels).To(Equal(map[string]string{"team": "platform", "kind": "Pod", "cluster": "eu-west-1"}))
+	Expect(result.Check.GetLabels()).To(HaveKeyWithValue("cluster", "eu-west-1"))
+
+	Expect(pkg.Success(v1.KubernetesCheck{Kind: "Pod"}, v1.Canary{}).Labels).To(BeEmpty())
+}
+
+func TestKubernetesWatchTransitions(t *testing.T) {
+	RegisterTestingT(t)
+	watch := newKubernetesWatch(nil)
+	start := time.Now()
+
+	pod := newTestPod("web", "Succeeded")
+	pod.SetUID("web-uid")
+	watch.observe(&pod, start)
+	Expect(watch.transitionsSince("canary/pods", start)).To(BeEmpty())
+
+	failed := newTestPod("web", "Failed")
+	failed.SetUID("web-uid")
+	watch.observe(&failed, start.Add(10*time.Second))
+	watch.observe(&failed, start.Add(15*time.Second))
+	watch.observe(&pod, start.Add(30*time.Second))
+
+	transitions := watch.transitionsSince("canary/pods", start.Add(time.Minute))
+	Expect(transitions).To(HaveLen(2))
+	Expect(transitions[0].From).To(Equal(health.HealthHealthy))
+	Expect(transitions[0].To).To(Equal(health.HealthUnhealthy))
+	Expect(transitions[1].From).To(Equal(health.HealthUnhealthy))
+	Expect(transitions[1].Duration).To(Equal(20.0))
+	Expect(transitions[1].String()).To(Equal("Pod/default/web was unhealthy (Failed) for 20s"))
+
+	Expect(watch.transitionsSince("canary/pods", start.Add(2*time.Minute))).To(BeEmpty())
+	// a check using the same watch for the first time does not see earlier transitions
+	Expect(watch.transitionsSince("other/pods", start.Add(2*time.Minute))).To(BeEmpty())
+	// checks that have not run within the idle timeout are forgotten
+	Expect(watch.transitionsSince("other/pods", start.Add(2*kubernetesWatchIdleTimeout))).To(BeEmpty())
+	Expect(watch.lastRun).To(HaveLen(1))
+	Expect(watch.lastRun).To(HaveKey("other/pods"))
+
+	filtered, err := filterTransitions(transitions, []string{"kube-system"}, "", nil)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(filtered).To(BeEmpty())
+	filtered, err = filterTransitions(transitions, []string{""}, "", []string{"web*"})
+	Expect(err).ToNot(HaveOccurred())
+	Expect(filtered).To(BeEmpty())
+	filtered, err = filterTransitions(transitions, []string{"default"}, "web", nil)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(filtered).To(HaveLen(2))
+}
+
+func TestKubernetesIdentity(t *testing.T) {
+	RegisterTestingT(t)
+	config := &rest.Config{Host: "https://cluster:6443", BearerToken: "a"}
+	Expect(kubernetesIdentity(config)).To(HavePrefix("https://cluster:6443/"))
+	Expect(kubernetesIdentity(config)).To(Equal(kubernetesIdentity(&rest.Config{Host: "https://cluster:6443", BearerToken: "a"})))
+	Expect(kubernetesIdentity(config)).ToNot(Equal(kubernetesIdentity(&rest.Config{Host: "https://cluster:6443", BearerToken: "b"})))
+	Expect(kubernetesIdentity(config)).ToNot(Equal(kubernetesIdentity(&rest.Config{
+		Host: "https://cluster:6443", BearerToken: "a", Impersonate: rest.ImpersonationConfig{UserName: "viewer"},
+	})))
+}
+
+func TestStopKubernetesWatch(t *testing.T) {
+	RegisterTestingT(t)
+	watch := newKubernetesWatch(nil)
+	replaced := newKubernetesWatch(nil)
+
+	kubernetesWatches.Lock()
+	defer kubernetesWatches.Unlock()
+	kubernetesWatches.items["test"] = watch
+	defer delete(kubernetesWatches.items, "test")
+
+	stopKubernetesWatch("test", watch)
+	Expect(watch.stop).To(BeClosed())
+	Expect(kubernetesWatches.items).ToNot(HaveKey("test"))
+
+	// a watch that was already stopped and replaced is not stopped again
+	kubernetesWatches.items["test"] = replaced
+	stopKubernetesWatch("test", watch)
+	Expect(replaced.stop).ToNot(BeClosed())
+	Expect(kubernetesWatches.items).To(HaveKeyWithValue("test", replaced))
+}
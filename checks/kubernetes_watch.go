@@ -0,0 +1,328 @@
+package checks
+
+import (
+	gocontext "context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/flanksource/canary-checker/api/context"
+	v1 "github.com/flanksource/canary-checker/api/v1"
+	"github.com/flanksource/is-healthy/pkg/health"
+	"github.com/gobwas/glob"
+	"github.com/samber/lo"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8sTypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	kubernetesWatchResync = 10 * time.Minute
+	// kubernetesWatchIdleTimeout stops watches that have not been used by any check
+	kubernetesWatchIdleTimeout = time.Hour
+	// kubernetesWatchSyncTimeout is how long a check waits for the initial list of a new watch
+	kubernetesWatchSyncTimeout = time.Minute
+	kubernetesTransitionsLimit = 1000
+)
+
+var kubernetesWatches = struct {
+	sync.Mutex
+	items map[string]*kubernetesWatch
+}{items: make(map[string]*kubernetesWatch)}
+
+// KubernetesTransition is a change in the health or status of a resource observed by a watch
+type KubernetesTransition struct {
+	Kind       string                  `json:"kind"`
+	Namespace  string                  `json:"namespace,omitempty"`
+	Name       string                  `json:"name"`
+	From       health.Health           `json:"from"`
+	FromStatus health.HealthStatusCode `json:"fromStatus,omitempty"`
+	To         health.Health           `json:"to"`
+	ToStatus   health.HealthStatusCode `json:"toStatus,omitempty"`
+	Message    string                  `json:"message,omitempty"`
+	Time       time.Time               `json:"time"`
+	// Duration in seconds that the resource was in the previous state
+	Duration float64 `json:"duration"`
+}
+
+func (t KubernetesTransition) String() string {
+	name := t.Kind + "/" + t.Name
+	if t.Namespace != "" {
+		name = t.Kind + "/" + t.Namespace + "/" + t.Name
+	}
+	return fmt.Sprintf("%s was %s (%s) for %s", name, t.From, t.FromStatus, (time.Duration(t.Duration) * time.Second).String())
+}
+
+type resourceState struct {
+	health health.Health
+	status health.HealthStatusCode
+	since  time.Time
+}
+
+// kubernetesWatch keeps a cache of the resources of a kind and the health transitions
+// between runs, it is shared by all checks with the same kind and selectors
+type kubernetesWatch struct {
+	informer cache.SharedIndexInformer
+	stop     chan struct{}
+
+	mu          sync.Mutex
+	states      map[k8sTypes.UID]resourceState
+	transitions []KubernetesTransition
+	lastUsed    time.Time
+	// lastRun of each check using the watch, so that every check sees all the transitions since its last run
+	lastRun map[string]time.Time
+}
+
+func newKubernetesWatch(informer cache.SharedIndexInformer) *kubernetesWatch {
+	return &kubernetesWatch{
+		informer: informer,
+		stop:     make(chan struct{}),
+		states:   make(map[k8sTypes.UID]resourceState),
+		lastRun:  make(map[string]time.Time),
+		lastUsed: time.Now(),
+	}
+}
+
+// observe evaluates the health of the resource and records a transition if it changed
+func (w *kubernetesWatch) observe(obj any, now time.Time) {
+	resource, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return
+	}
+	resourceHealth, err := health.GetResourceHealth(resource, nil)
+	if err != nil {
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	prev, exists := w.states[resource.GetUID()]
+	if exists && prev.health == resourceHealth.Health && prev.status == resourceHealth.Status {
+		return
+	}
+	w.states[resource.GetUID()] = resourceState{health: resourceHealth.Health, status: resourceHealth.Status, since: now}
+	if !exists {
+		return
+	}
+
+	w.transitions = append(w.transitions, KubernetesTransition{
+		Kind:       resource.GetKind(),
+		Namespace:  resource.GetNamespace(),
+		Name:       resource.GetName(),
+		From:       prev.health,
+		FromStatus: prev.status,
+		To:         resourceHealth.Health,
+		ToStatus:   resourceHealth.Status,
+		Message:    resourceHealth.Message,
+		Time:       now,
+		Duration:   now.Sub(prev.since).Round(time.Second).Seconds(),
+	})
+
+	cutoff := now.Add(-kubernetesWatchIdleTimeout)
+	for len(w.transitions) > 0 && (len(w.transitions) > kubernetesTransitionsLimit || w.transitions[0].Time.Before(cutoff)) {
+		w.transitions = w.transitions[1:]
+	}
+}
+
+func (w *kubernetesWatch) forget(obj any) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	if resource, ok := obj.(*unstructured.Unstructured); ok {
+		w.mu.Lock()
+		delete(w.states, resource.GetUID())
+		w.mu.Unlock()
+	}
+}
+
+// transitionsSince returns the transitions since the last time the check ran, the first run only
+// starts tracking transitions
+func (w *kubernetesWatch) transitionsSince(checkKey string, now time.Time) []KubernetesTransition {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	last, ok := w.lastRun[checkKey]
+	w.lastRun[checkKey] = now
+	w.lastUsed = now
+
+	// forget checks that were deleted, transitions older than the idle timeout are dropped anyway
+	cutoff := now.Add(-kubernetesWatchIdleTimeout)
+	for key, lastRun := range w.lastRun {
+		if lastRun.Before(cutoff) {
+			delete(w.lastRun, key)
+		}
+	}
+	if !ok {
+		return nil
+	}
+
+	var transitions []KubernetesTransition
+	for _, t := range w.transitions {
+		if t.Time.After(last) && !t.Time.After(now) {
+			transitions = append(transitions, t)
+		}
+	}
+	return transitions
+}
+
+// list returns copies of the cached resources in the namespace, or all namespaces if empty
+func (w *kubernetesWatch) list(namespace, name string) []unstructured.Unstructured {
+	var resources []unstructured.Unstructured
+	for _, obj := range w.informer.GetStore().List() {
+		resource, ok := obj.(*unstructured.Unstructured)
+		if !ok {
+			continue
+		}
+		if namespace != "" && resource.GetNamespace() != namespace {
+			continue
+		}
+		if name != "" && resource.GetName() != name {
+			continue
+		}
+		resources = append(resources, *resource.DeepCopy())
+	}
+	return resources
+}
+
+// getKubernetesWatch returns the shared watch for the kind and selectors of the check, starting it if needed
+func getKubernetesWatch(ctx context.Context, check v1.KubernetesCheck) (*kubernetesWatch, error) {
+	rm, err := ctx.KubernetesClient().GetRestMapper()
+	if err != nil {
+		return nil, err
+	}
+	gvk, err := rm.KindFor(schema.GroupVersionResource{Resource: check.Kind})
+	if err != nil {
+		return nil, err
+	}
+	mapping, err := rm.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	// namespace label selectors can match new namespaces, so those watch all namespaces
+	namespace := check.Namespace.Name
+	key := strings.Join([]string{kubernetesIdentity(ctx.KubernetesRestConfig()), mapping.Resource.String(), namespace, check.Resource.LabelSelector, check.Resource.FieldSelector}, "|")
+
+	kubernetesWatches.Lock()
+	stopIdleKubernetesWatches(time.Now())
+	watch, ok := kubernetesWatches.items[key]
+	if !ok {
+		client, err := ctx.KubernetesClient().GetDynamicClient()
+		if err != nil {
+			kubernetesWatches.Unlock()
+			return nil, err
+		}
+		factory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(client, kubernetesWatchResync, namespace, func(options *metav1.ListOptions) {
+			options.LabelSelector = check.Resource.LabelSelector
+			options.FieldSelector = check.Resource.FieldSelector
+		})
+		watch = newKubernetesWatch(factory.ForResource(mapping.Resource).Informer())
+		if _, err := watch.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    func(obj any) { watch.observe(obj, time.Now()) },
+			UpdateFunc: func(_, obj any) { watch.observe(obj, time.Now()) },
+			DeleteFunc: watch.forget,
+		}); err != nil {
+			kubernetesWatches.Unlock()
+			return nil, err
+		}
+		go watch.informer.Run(watch.stop)
+		kubernetesWatches.items[key] = watch
+		ctx.Debugf("started watching %s with labels=%s fields=%s", mapping.Resource, check.Resource.LabelSelector, check.Resource.FieldSelector)
+	}
+	watch.mu.Lock()
+	watch.lastUsed = time.Now()
+	watch.mu.Unlock()
+	kubernetesWatches.Unlock()
+
+	timeout := ctx.Properties().Duration("checks.kubernetes.watch.syncTimeout", kubernetesWatchSyncTimeout)
+	syncCtx, cancel := gocontext.WithTimeout(ctx, timeout)
+	defer cancel()
+	if !cache.WaitForCacheSync(syncCtx.Done(), watch.informer.HasSynced) {
+		// stop the watch so that the next run starts a new one, rather than waiting on one that may never sync
+		kubernetesWatches.Lock()
+		stopKubernetesWatch(key, watch)
+		kubernetesWatches.Unlock()
+		return nil, fmt.Errorf("timed out waiting for %s to sync", mapping.Resource)
+	}
+	return watch, nil
+}
+
+// stopKubernetesWatch stops the watch if it is still registered under the key, the lock must be held
+func stopKubernetesWatch(key string, watch *kubernetesWatch) {
+	if kubernetesWatches.items[key] != watch {
+		return
+	}
+	close(watch.stop)
+	delete(kubernetesWatches.items, key)
+}
+
+// kubernetesIdentity identifies the API server and credentials of the config, so that watches are
+// only shared by checks that list resources with the same permissions
+func kubernetesIdentity(config *rest.Config) string {
+	hash := sha256.New()
+	for _, v := range []string{
+		config.Username, config.Password, config.BearerToken, config.BearerTokenFile,
+		config.CertFile, config.KeyFile, string(config.CertData), string(config.KeyData),
+		config.Impersonate.UserName, config.Impersonate.UID, strings.Join(config.Impersonate.Groups, ","),
+	} {
+		hash.Write([]byte(v))
+		hash.Write([]byte{0})
+	}
+	if config.ExecProvider != nil {
+		fmt.Fprintf(hash, "%v", *config.ExecProvider)
+	}
+	if config.AuthProvider != nil {
+		fmt.Fprintf(hash, "%v", *config.AuthProvider)
+	}
+	return config.Host + "/" + hex.EncodeToString(hash.Sum(nil))
+}
+
+// stopIdleKubernetesWatches stops the watches that no check has used recently, the lock must be held
+func stopIdleKubernetesWatches(now time.Time) {
+	for key, watch := range kubernetesWatches.items {
+		watch.mu.Lock()
+		idle := now.Sub(watch.lastUsed) > kubernetesWatchIdleTimeout
+		watch.mu.Unlock()
+		if idle {
+			stopKubernetesWatch(key, watch)
+		}
+	}
+}
+
+// filterTransitions keeps the transitions of resources in the namespaces that are not ignored
+func filterTransitions(transitions []KubernetesTransition, namespaces []string, name string, ignore []string) ([]KubernetesTransition, error) {
+	var globs []glob.Glob
+	for _, filter := range ignore {
+		g, err := glob.Compile(filter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile glob: %v", err)
+		}
+		globs = append(globs, g)
+	}
+
+	var filtered []KubernetesTransition
+outer:
+	for _, t := range transitions {
+		if name != "" && t.Name != name {
+			continue
+		}
+		if !(len(namespaces) == 1 && namespaces[0] == "") && !lo.Contains(namespaces, t.Namespace) {
+			continue
+		}
+		for _, g := range globs {
+			if g.Match(t.Name) {
+				continue outer
+			}
+		}
+		filtered = append(filtered, t)
+	}
+	return filtered, nil
+}
@@ -2,6 +2,7 @@ package checks
 
 import (
 	"crypto/tls"
+	"fmt"
 
 	"github.com/flanksource/canary-checker/api/context"
 
@@ -14,6 +15,16 @@ import (
 type LdapChecker struct {
 }
 
+type LDAPEntry struct {
+	DN         string              `json:"dn"`
+	Attributes map[string][]string `json:"attributes"`
+}
+
+type LDAPDetails struct {
+	Entries []LDAPEntry `json:"entries"`
+	Count   int         `json:"count"`
+}
+
 // Type: returns checker type
 func (c *LdapChecker) Type() string {
 	return "ldap"
@@ -47,28 +58,85 @@ func (c *LdapChecker) Check(ctx *context.Context, extConfig external.Check) pkg.
 		return results.Failf("Must specify a connection or URL")
 	}
 
-	ld, err := ldap.DialURL(connection.URL, ldap.DialWithTLSConfig(&tls.Config{InsecureSkipVerify: check.SkipTLSVerify}))
+	scope, err := getLdapScope(check.Scope)
+	if err != nil {
+		return results.Invalidf("%v", err)
+	}
+
+	tlsConfig := &tls.Config{InsecureSkipVerify: check.SkipTLSVerify}
+	ld, err := ldap.DialURL(connection.URL, ldap.DialWithTLSConfig(tlsConfig))
 	if err != nil {
 		return results.Failf("Failed to connect %v", err)
 	}
+	defer ld.Close()
+
+	if check.StartTLS {
+		if err := ld.StartTLS(tlsConfig); err != nil {
+			return results.Failf("Failed to start TLS %v", err)
+		}
+	}
 
 	if err := ld.Bind(connection.Username, connection.Password); err != nil {
 		return results.Failf("Failed to bind using %s %v", connection.Username, err)
 	}
 
 	req := &ldap.SearchRequest{
-		Scope:  ldap.ScopeWholeSubtree,
-		BaseDN: check.BindDN,
-		Filter: check.UserSearch,
+		Scope:      scope,
+		BaseDN:     check.BindDN,
+		Filter:     check.UserSearch,
+		Attributes: check.Attributes,
+	}
+
+	var res *ldap.SearchResult
+	if check.PageSize > 0 {
+		res, err = ld.SearchWithPaging(req, check.PageSize)
+	} else {
+		res, err = ld.Search(req)
 	}
-	res, err := ld.Search(req)
 	if err != nil {
 		return results.Failf("Failed to search host %v error: %v", connection.URL, err)
 	}
 
+	details := LDAPDetails{Count: len(res.Entries)}
+	for _, entry := range res.Entries {
+		e := LDAPEntry{DN: entry.DN, Attributes: make(map[string][]string)}
+		for _, attr := range entry.Attributes {
+			e.Attributes[attr.Name] = attr.Values
+		}
+		details.Entries = append(details.Entries, e)
+	}
+	result.AddDetails(details)
+
 	if len(res.Entries) == 0 {
 		return results.Failf("no results returned")
 	}
 
+	if check.UserBind != nil {
+		if len(res.Entries) != 1 {
+			return results.Failf("expected a single entry to bind as, found %d", len(res.Entries))
+		}
+
+		password, err := ctx.GetEnvValueFromCache(check.UserBind.Password, ctx.GetNamespace())
+		if err != nil {
+			return results.Failf("failed to get user password: %v", err)
+		}
+
+		if err := ld.Bind(res.Entries[0].DN, password); err != nil {
+			return results.Failf("Failed to bind as %s %v", res.Entries[0].DN, err)
+		}
+	}
+
 	return results
 }
+
+func getLdapScope(scope string) (int, error) {
+	switch scope {
+	case "base":
+		return ldap.ScopeBaseObject, nil
+	case "one":
+		return ldap.ScopeSingleLevel, nil
+	case "", "sub":
+		return ldap.ScopeWholeSubtree, nil
+	}
+	return 0, fmt.Errorf("unknown scope %s, expected one of: base, one, sub", scope)
+}
@@ -2,6 +2,8 @@ package checks
 
 import (
 	gocontext "context"
+	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/flanksource/canary-checker/api/context"
@@ -9,6 +11,7 @@ import (
 
 	v1 "github.com/flanksource/canary-checker/api/v1"
 	"github.com/flanksource/canary-checker/pkg"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
@@ -60,5 +63,173 @@ func (c *MongoDBChecker) Check(ctx *context.Context, extConfig external.Check) p
 		return results.ErrorMessage(err)
 	}
 
+	if !check.HasQuery() {
+		return results
+	}
+
+	if ctx.CanTemplate() {
+		for _, field := range []*string{&check.Filter, &check.Pipeline} {
+			if *field == "" {
+				continue
+			}
+			if *field, err = template(ctx.WithCheck(check), v1.Template{Template: *field}); err != nil {
+				return results.ErrorMessage(err)
+			}
+		}
+	}
+
+	var details SQLDetails
+	switch {
+	case check.Query == v1.MongoDBReplicaSetStatusQuery:
+		details, err = mongoReplicaSetStatus(ctx, client)
+	case check.Query != "":
+		return results.Invalidf("unknown query %s, expected one of: %s", check.Query, v1.MongoDBReplicaSetStatusQuery)
+	case check.Collection == "":
+		return results.Invalidf("collection is required when database, filter or pipeline is set")
+	case check.Database == "":
+		return results.Invalidf("database is required when querying a collection")
+	case check.Pipeline != "" && check.Filter != "":
+		return results.Invalidf("filter and pipeline are mutually exclusive")
+	case check.Pipeline != "":
+		details, err = mongoAggregate(ctx, client.Database(check.Database).Collection(check.Collection), check.Pipeline)
+	default:
+		details, err = mongoFind(ctx, client.Database(check.Database).Collection(check.Collection), check.Filter, check.Limit)
+	}
+	result.AddDetails(details)
+	if err != nil {
+		return results.ErrorMessage(err)
+	}
+
+	if details.Count < check.Result {
+		return results.Failf("Query returned %d documents, expected %d", details.Count, check.Result)
+	}
+
 	return results
 }
+
+// mongoFilter parses an extended JSON find filter, an empty filter matches all documents
+func mongoFilter(filter string) (any, error) {
+	var query any = bson.D{}
+	if filter == "" {
+		return query, nil
+	}
+	if err := bson.UnmarshalExtJSON([]byte(filter), false, &query); err != nil {
+		return nil, fmt.Errorf("invalid filter: %w", err)
+	}
+	return query, nil
+}
+
+// mongoPipeline parses an extended JSON array of aggregation stages
+func mongoPipeline(pipeline string) (bson.A, error) {
+	var stages bson.A
+	if err := bson.UnmarshalExtJSON([]byte(pipeline), false, &stages); err != nil {
+		return nil, fmt.Errorf("invalid pipeline: %w", err)
+	}
+	return stages, nil
+}
+
+func mongoFind(ctx *context.Context, collection *mongo.Collection, filter string, limit int64) (SQLDetails, error) {
+	query, err := mongoFilter(filter)
+	if err != nil {
+		return SQLDetails{}, err
+	}
+
+	opts := options.Find()
+	if limit > 0 {
+		opts.SetLimit(limit)
+	}
+
+	cursor, err := collection.Find(ctx, query, opts)
+	if err != nil {
+		return SQLDetails{}, fmt.Errorf("failed to query collection: %w", err)
+	}
+	return mongoDocuments(ctx, cursor)
+}
+
+func mongoAggregate(ctx *context.Context, collection *mongo.Collection, pipeline string) (SQLDetails, error) {
+	stages, err := mongoPipeline(pipeline)
+	if err != nil {
+		return SQLDetails{}, err
+	}
+
+	cursor, err := collection.Aggregate(ctx, stages)
+	if err != nil {
+		return SQLDetails{}, fmt.Errorf("failed to run aggregation: %w", err)
+	}
+	return mongoDocuments(ctx, cursor)
+}
+
+// mongoDocuments reads all documents from the cursor and converts them into relaxed extended JSON maps
+func mongoDocuments(ctx *context.Context, cursor *mongo.Cursor) (SQLDetails, error) {
+	defer cursor.Close(ctx) //nolint: errcheck
+
+	result := SQLDetails{}
+	for cursor.Next(ctx) {
+		row, err := bsonToMap(cursor.Current)
+		if err != nil {
+			return result, err
+		}
+		result.Rows = append(result.Rows, row)
+	}
+	result.Count = len(result.Rows)
+	return result, cursor.Err()
+}
+
+func bsonToMap(doc bson.Raw) (map[string]any, error) {
+	data, err := bson.MarshalExtJSON(doc, false, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert document: %w", err)
+	}
+	var out map[string]any
+	return out, json.Unmarshal(data, &out)
+}
+
+type mongoReplicaSetMember struct {
+	Name       string    `bson:"name"`
+	StateStr   string    `bson:"stateStr"`
+	Health     float64   `bson:"health"`
+	OptimeDate time.Time `bson:"optimeDate"`
+	Uptime     int64     `bson:"uptime"`
+}
+
+// mongoReplicaSetStatus returns the members of the replica set from replSetGetStatus (rs.status())
+func mongoReplicaSetStatus(ctx *context.Context, client *mongo.Client) (SQLDetails, error) {
+	var status struct {
+		Set     string                  `bson:"set"`
+		Members []mongoReplicaSetMember `bson:"members"`
+	}
+
+	if err := client.Database("admin").RunCommand(ctx, bson.D{{Key: "replSetGetStatus", Value: 1}}).Decode(&status); err != nil {
+		return SQLDetails{}, fmt.Errorf("failed to get replica set status: %w", err)
+	}
+	return mongoReplicaSetRows(status.Set, status.Members), nil
+}
+
+// mongoReplicaSetRows returns one row per member with the lag in seconds behind the primary
+func mongoReplicaSetRows(set string, members []mongoReplicaSetMember) SQLDetails {
+	var primary time.Time
+	for _, member := range members {
+		if member.StateStr == "PRIMARY" {
+			primary = member.OptimeDate
+		}
+	}
+
+	result := SQLDetails{}
+	for _, member := range members {
+		var lag float64
+		if !primary.IsZero() && !member.OptimeDate.IsZero() {
+			lag = primary.Sub(member.OptimeDate).Seconds()
+		}
+		result.Rows = append(result.Rows, map[string]any{
+			"set":        set,
+			"name":       member.Name,
+			"state":      member.StateStr,
+			"health":     member.Health,
+			"optimeDate": member.OptimeDate,
+			"uptime":     member.Uptime,
+			"lag":        lag,
+		})
+	}
+	result.Count = len(result.Rows)
+	return result
+}
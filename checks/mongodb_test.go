@@ -0,0 +1,65 @@
+package checks
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestMongoFilter(t *testing.T) {
+	RegisterTestingT(t)
+
+	query, err := mongoFilter("")
+	Expect(err).ToNot(HaveOccurred())
+	Expect(query).To(Equal(bson.D{}))
+
+	query, err = mongoFilter(`{"status": "active", "count": {"$gt": 1}}`)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(query).To(Equal(bson.D{
+		{Key: "status", Value: "active"},
+		{Key: "count", Value: bson.D{{Key: "$gt", Value: int32(1)}}},
+	}))
+
+	_, err = mongoFilter(`{"status": `)
+	Expect(err).To(MatchError(ContainSubstring("invalid filter")))
+}
+
+func TestMongoPipeline(t *testing.T) {
+	RegisterTestingT(t)
+
+	stages, err := mongoPipeline(`[{"$match": {"status": "active"}}, {"$count": "total"}]`)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(stages).To(Equal(bson.A{
+		bson.D{{Key: "$match", Value: bson.D{{Key: "status", Value: "active"}}}},
+		bson.D{{Key: "$count", Value: "total"}},
+	}))
+
+	_, err = mongoPipeline(`{"$match": {}}`)
+	Expect(err).To(MatchError(ContainSubstring("invalid pipeline")))
+}
+
+func TestMongoReplicaSetRows(t *testing.T) {
+	RegisterTestingT(t)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	details := mongoReplicaSetRows("rs0", []mongoReplicaSetMember{
+		{Name: "mongo-0:27017", StateStr: "SECONDARY", Health: 1, OptimeDate: now.Add(-5 * time.Second)},
+		{Name: "mongo-1:27017", StateStr: "PRIMARY", Health: 1, OptimeDate: now},
+		{Name: "mongo-2:27017", StateStr: "(not reachable/healthy)", Health: 0},
+	})
+
+	Expect(details.Count).To(Equal(3))
+	Expect(details.Rows[0]).To(HaveKeyWithValue("set", "rs0"))
+	Expect(details.Rows[0]).To(HaveKeyWithValue("lag", float64(5)))
+	Expect(details.Rows[1]).To(HaveKeyWithValue("lag", float64(0)))
+	Expect(details.Rows[2]).To(HaveKeyWithValue("health", float64(0)))
+	Expect(details.Rows[2]).To(HaveKeyWithValue("lag", float64(0)))
+
+	// without a primary the lag cannot be calculated
+	details = mongoReplicaSetRows("rs0", []mongoReplicaSetMember{
+		{Name: "mongo-0:27017", StateStr: "SECONDARY", OptimeDate: now},
+	})
+	Expect(details.Rows[0]).To(HaveKeyWithValue("lag", float64(0)))
+}
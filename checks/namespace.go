@@ -13,7 +13,6 @@ import (
 
 	"github.com/flanksource/canary-checker/api/external"
 	networkingv1 "k8s.io/api/networking/v1"
-	"k8s.io/client-go/kubernetes"
 
 	"k8s.io/apimachinery/pkg/util/intstr"
 
@@ -34,8 +33,6 @@ import (
 type NamespaceChecker struct {
 	lock *semaphore.Weighted
 	ng   *NameGenerator
-	ctx  *context.Context
-	k8s  kubernetes.Interface
 }
 
 func NewNamespaceChecker() *NamespaceChecker {
@@ -51,11 +48,7 @@ func (c *NamespaceChecker) Run(ctx *context.Context) pkg.Results {
 	logger.Warnf("namespace check is deprecated. Please use the kubernetes resource check")
 	var results pkg.Results
 	for _, conf := range ctx.Canary.Spec.Namespace {
-		if c.k8s == nil {
-			c.k8s = ctx.Kubernetes()
-			c.ctx = ctx
-		}
-		results = append(results, c.Check(c.ctx, conf)...)
+		results = append(results, c.Check(ctx, conf)...)
 	}
 	return results
 }
@@ -86,10 +79,10 @@ func (c *NamespaceChecker) newPod(check canaryv1.NamespaceCheck, ns *v1.Namespac
 	return pod, nil
 }
 
-func (c *NamespaceChecker) getConditionTimes(ns *v1.Namespace, pod *v1.Pod) (times map[v1.PodConditionType]metav1.Time, err error) {
-	pods := c.k8s.CoreV1().Pods(ns.Name)
+func (c *NamespaceChecker) getConditionTimes(ctx *context.Context, ns *v1.Namespace, pod *v1.Pod) (times map[v1.PodConditionType]metav1.Time, err error) {
+	pods := ctx.Kubernetes().CoreV1().Pods(ns.Name)
 	times = make(map[v1.PodConditionType]metav1.Time)
-	pod, err = pods.Get(c.ctx, pod.Name, metav1.GetOptions{})
+	pod, err = pods.Get(ctx, pod.Name, metav1.GetOptions{})
 	if err != nil {
 		return nil, err
 	}
@@ -111,16 +104,24 @@ func (c *NamespaceChecker) Check(ctx *context.Context, extConfig external.Check)
 		return nil
 	}
 	defer func() { c.lock.Release(1) }()
+
+	kctx, err := ctx.WithKubernetesConnection(check.KubernetesConnection)
+	if err != nil {
+		return results.WithError(err).Invalidf("Cannot connect to kubernetes")
+	}
+	ctx = &kctx
+	k8s := ctx.Kubernetes()
+
 	startTimer := NewTimer()
 
 	logger.Debugf("Running namespace check %s", check.Name)
 	five := int64(5)
-	if _, err := c.k8s.CoreV1().Nodes().List(ctx, metav1.ListOptions{TimeoutSeconds: &five}); err != nil {
+	if _, err := k8s.CoreV1().Nodes().List(ctx, metav1.ListOptions{TimeoutSeconds: &five}); err != nil {
 		return results.Failf("cannot connect to API server: %v", err)
 	}
 
 	namespaceName := c.ng.NamespaceName(check.NamespaceNamePrefix)
-	namespaces := c.k8s.CoreV1().Namespaces()
+	namespaces := k8s.CoreV1().Namespaces()
 	ns := &v1.Namespace{
 		TypeMeta: metav1.TypeMeta{Kind: "Namespace", APIVersion: "v1"},
 		ObjectMeta: metav1.ObjectMeta{
@@ -133,7 +134,7 @@ func (c *NamespaceChecker) Check(ctx *context.Context, extConfig external.Check)
 		return results.Failf("unable to create namespace: %v", err)
 	}
 	defer func() {
-		c.Cleanup(ns) // nolint: errcheck
+		c.Cleanup(ctx, ns) // nolint: errcheck
 	}()
 
 	pod, err := c.newPod(check, ns)
@@ -141,15 +142,15 @@ func (c *NamespaceChecker) Check(ctx *context.Context, extConfig external.Check)
 		return results.Failf("invalid pod spec: %v", err)
 	}
 
-	pods := c.k8s.CoreV1().Pods(ns.Name)
+	pods := k8s.CoreV1().Pods(ns.Name)
 
 	if _, err := pods.Create(ctx, pod, metav1.CreateOptions{}); err != nil {
 		return results.Failf("unable to create pod: %v", err)
 	}
-	pod, _ = c.WaitForPod(ns.Name, pod.Name, time.Millisecond*time.Duration(check.ScheduleTimeout), v1.PodRunning)
+	pod, _ = c.WaitForPod(ctx, ns.Name, pod.Name, time.Millisecond*time.Duration(check.ScheduleTimeout), v1.PodRunning)
 	created := pod.GetCreationTimestamp()
 
-	conditions, err := c.getConditionTimes(ns, pod)
+	conditions, err := c.getConditionTimes(ctx, ns, pod)
 	if err != nil {
 		return results.Failf("could not list conditions: %v", err)
 	}
@@ -161,7 +162,7 @@ func (c *NamespaceChecker) Check(ctx *context.Context, extConfig external.Check)
 	logger.Debugf("%s created=%s, scheduled=%d, started=%d, running=%d wall=%s", pod.Name, created, scheduled, started, running, startTimer)
 	logger.Tracef("%v", conditions)
 
-	if err := c.createServiceAndIngress(check, ns, pod); err != nil {
+	if err := c.createServiceAndIngress(ctx, check, ns, pod); err != nil {
 		return results.Failf("failed to create ingress and service: %v", err)
 	}
 
@@ -171,7 +172,7 @@ func (c *NamespaceChecker) Check(ctx *context.Context, extConfig external.Check)
 
 	deleteOk := true
 	deletion := NewTimer()
-	if err := pods.Delete(c.ctx, pod.Name, metav1.DeleteOptions{}); err != nil {
+	if err := pods.Delete(ctx, pod.Name, metav1.DeleteOptions{}); err != nil {
 		return results.Failf("failed to delete pod: %v", err)
 	}
 	result.Pass = ingressResult.Pass && deleteOk
@@ -211,8 +212,9 @@ func (c *NamespaceChecker) Check(ctx *context.Context, extConfig external.Check)
 	return results
 }
 
-func (c *NamespaceChecker) Cleanup(ns *v1.Namespace) error {
-	if err := c.k8s.CoreV1().Namespaces().Delete(c.ctx, ns.Name, metav1.DeleteOptions{}); err != nil {
+// Cleanup deletes the namespace created by the check in the cluster of the context
+func (c *NamespaceChecker) Cleanup(ctx *context.Context, ns *v1.Namespace) error {
+	if err := ctx.Kubernetes().CoreV1().Namespaces().Delete(ctx, ns.Name, metav1.DeleteOptions{}); err != nil {
 		return perrors.Wrapf(err, "Failed to delete namespace %s", ns.Name)
 	}
 	return nil
@@ -279,7 +281,7 @@ func (c *NamespaceChecker) httpCheck(check canaryv1.NamespaceCheck, deadline tim
 	}
 }
 
-func (c *NamespaceChecker) createServiceAndIngress(check canaryv1.NamespaceCheck, ns *v1.Namespace, pod *v1.Pod) error {
+func (c *NamespaceChecker) createServiceAndIngress(ctx *context.Context, check canaryv1.NamespaceCheck, ns *v1.Namespace, pod *v1.Pod) error {
 	if check.Port == 0 {
 		return perrors.Errorf("Pod cannot be empty for pod %s in namespace %s", pod.Name, ns.Name)
 	}
@@ -311,11 +313,12 @@ func (c *NamespaceChecker) createServiceAndIngress(check canaryv1.NamespaceCheck
 		},
 	}
 
-	if _, err := c.k8s.CoreV1().Services(svc.Namespace).Create(c.ctx, svc, metav1.CreateOptions{}); err != nil {
+	k8s := ctx.Kubernetes()
+	if _, err := k8s.CoreV1().Services(svc.Namespace).Create(ctx, svc, metav1.CreateOptions{}); err != nil {
 		return perrors.Wrapf(err, "Failed to create service for pod %s in namespace %s", pod.Name, pod.Namespace)
 	}
 
-	ingress, err := c.k8s.NetworkingV1().Ingresses(ns.Name).Get(c.ctx, check.IngressName, metav1.GetOptions{})
+	ingress, err := k8s.NetworkingV1().Ingresses(ns.Name).Get(ctx, check.IngressName, metav1.GetOptions{})
 	if err != nil && !errors.IsNotFound(err) {
 		return perrors.Wrapf(err, "Failed to get ingress %s in namespace %s", check.IngressName, ns.Name)
 	} else if err == nil {
@@ -324,13 +327,13 @@ func (c *NamespaceChecker) createServiceAndIngress(check canaryv1.NamespaceCheck
 		ingress.Spec.Rules[0].IngressRuleValue.HTTP.Paths[0].Backend.Service.Name = svc.Name
 		ingress.Spec.Rules[0].IngressRuleValue.HTTP.Paths[0].Backend.Service.Port.Number = int32(check.Port)
 		ingress.Spec.Rules[0].IngressRuleValue.HTTP.Paths[0].PathType = &pathType
-		if _, err := c.k8s.NetworkingV1().Ingresses(ns.Name).Update(c.ctx, ingress, metav1.UpdateOptions{}); err != nil {
+		if _, err := k8s.NetworkingV1().Ingresses(ns.Name).Update(ctx, ingress, metav1.UpdateOptions{}); err != nil {
 			return perrors.Wrapf(err, "failed to update ingress %s in namespace %s", check.IngressName, ns.Name)
 		}
 	} else {
 		logger.Debugf("Creating ingress: %s", check.IngressName)
 		ingress := c.newIngress(check, ns, svc.Name)
-		if _, err := c.k8s.NetworkingV1().Ingresses(ns.Name).Create(c.ctx, ingress, metav1.CreateOptions{}); err != nil {
+		if _, err := k8s.NetworkingV1().Ingresses(ns.Name).Create(ctx, ingress, metav1.CreateOptions{}); err != nil {
 			return perrors.Wrapf(err, "failed to create ingress %s in namespace %s", check.IngressName, ns.Name)
 		}
 	}
@@ -413,11 +416,11 @@ func (c *NamespaceChecker) podCheckSelectorValue(check canaryv1.NamespaceCheck,
 
 // WaitForPod waits for a pod to be in the specified phase, or returns an
 // error if the timeout is exceeded
-func (c *NamespaceChecker) WaitForPod(ns, name string, timeout time.Duration, phases ...v1.PodPhase) (*v1.Pod, error) {
-	pods := c.k8s.CoreV1().Pods(ns)
+func (c *NamespaceChecker) WaitForPod(ctx *context.Context, ns, name string, timeout time.Duration, phases ...v1.PodPhase) (*v1.Pod, error) {
+	pods := ctx.Kubernetes().CoreV1().Pods(ns)
 	start := time.Now()
 	for {
-		pod, err := pods.Get(c.ctx, name, metav1.GetOptions{})
+		pod, err := pods.Get(ctx, name, metav1.GetOptions{})
 		if start.Add(timeout).Before(time.Now()) {
 			return pod, fmt.Errorf("timeout exceeded waiting for %s is %s, error: %v", name, pod.Status.Phase, err)
 		}
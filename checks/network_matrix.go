@@ -0,0 +1,324 @@
+package checks
+
+import (
+	gocontext "context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/flanksource/canary-checker/api/context"
+	"github.com/flanksource/canary-checker/api/external"
+	v1 "github.com/flanksource/canary-checker/api/v1"
+	"github.com/flanksource/canary-checker/pkg"
+	"github.com/gobwas/glob"
+	"github.com/samber/lo"
+	"golang.org/x/sync/errgroup"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/rand"
+)
+
+const (
+	networkMatrixSelector    = "canary-checker.flanksource.com/networkMatrix"
+	networkMatrixImage       = "busybox"
+	networkMatrixContainer   = "probe"
+	networkMatrixConcurrency = 10
+)
+
+type NetworkMatrixChecker struct{}
+
+func (c *NetworkMatrixChecker) Type() string {
+	return "networkMatrix"
+}
+
+func (c *NetworkMatrixChecker) Run(ctx *context.Context) pkg.Results {
+	var results pkg.Results
+	for _, conf := range ctx.Canary.Spec.NetworkMatrix {
+		results = append(results, c.Check(ctx, conf)...)
+	}
+	return results
+}
+
+// NetworkEdge is the result of a connection from a probe pod to another probe pod or a service
+type NetworkEdge struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Protocol string `json:"protocol"`
+	Expected bool   `json:"expected"`
+	Allowed  bool   `json:"allowed"`
+	Error    string `json:"error,omitempty"`
+	// Duration of the connection in milliseconds
+	Duration int64 `json:"duration"`
+
+	source *networkProbe
+	// target is the probe pod the connection is made to, address is used for services
+	target  *networkProbe
+	address string
+}
+
+func (e NetworkEdge) Matches() bool {
+	return e.Expected == e.Allowed
+}
+
+func (e NetworkEdge) String() string {
+	return fmt.Sprintf("%s -> %s (%s): expected %s, got %s", e.From, e.To, e.Protocol, allowOrDeny(e.Expected), allowOrDeny(e.Allowed))
+}
+
+func allowOrDeny(allow bool) string {
+	if allow {
+		return "allow"
+	}
+	return "deny"
+}
+
+// networkProbe is a probe pod that serves http and runs the connections to the other probes
+type networkProbe struct {
+	Namespace string
+	Node      string
+	pod       *corev1.Pod
+}
+
+func (p networkProbe) String() string {
+	if p.Node != "" {
+		return p.Namespace + "@" + p.Node
+	}
+	return p.Namespace
+}
+
+func (c *NetworkMatrixChecker) Check(ctx *context.Context, extConfig external.Check) pkg.Results {
+	check := extConfig.(v1.NetworkMatrixCheck)
+	result := pkg.Success(check, ctx.Canary)
+	results := pkg.Results{result}
+
+	kctx, err := ctx.WithKubernetesConnection(check.KubernetesConnection)
+	if err != nil {
+		return results.WithError(err).Invalidf("Cannot connect to kubernetes")
+	}
+	ctx = &kctx
+
+	if ctx.KubernetesClient() == nil {
+		return results.Failf("Kubernetes is not initialized")
+	}
+	if len(check.Namespaces) == 0 {
+		return results.Invalidf("at least one namespace is required")
+	}
+
+	scheduleTimeout, err := durationOrDefault(check.ScheduleTimeout, 2*time.Minute)
+	if err != nil {
+		return results.Invalidf("invalid scheduleTimeout: %v", err)
+	}
+	timeout, err := durationOrDefault(check.Timeout, 5*time.Second)
+	if err != nil {
+		return results.Invalidf("invalid timeout: %v", err)
+	}
+
+	var probes []*networkProbe
+	for _, namespace := range check.Namespaces {
+		for _, node := range lo.Ternary(len(check.Nodes) > 0, check.Nodes, []string{""}) {
+			probes = append(probes, &networkProbe{Namespace: namespace, Node: node})
+		}
+	}
+
+	edges, err := planNetworkMatrix(check, probes)
+	if err != nil {
+		return results.Invalidf("%v", err)
+	}
+
+	k8s := ctx.Kubernetes()
+	selectorValue := networkMatrixSelectorValue(ctx.Canary.Namespace, check.Name)
+	selector := fmt.Sprintf("%s=%s", networkMatrixSelector, selectorValue)
+	for _, namespace := range check.Namespaces {
+		cleanupPods(ctx, k8s, check.Name, namespace, selector)       // cleanup existing resources
+		defer cleanupPods(ctx, k8s, check.Name, namespace, selector) // cleanup resources created during test
+	}
+
+	for _, probe := range probes {
+		probe.pod = newNetworkProbePod(check, *probe, selectorValue)
+		if _, err := k8s.CoreV1().Pods(probe.Namespace).Create(ctx, probe.pod, metav1.CreateOptions{}); err != nil {
+			return results.Failf("failed to create probe pod in %s: %v", probe, err)
+		}
+	}
+	for _, probe := range probes {
+		pod, err := waitForPod(k8s, probe.Namespace, probe.pod.Name, scheduleTimeout, corev1.PodRunning)
+		if err != nil {
+			return results.Failf("probe pod in %s did not start: %v", probe, err)
+		}
+		if pod.Status.Phase != corev1.PodRunning {
+			return results.Failf("probe pod in %s is %s", probe, pod.Status.Phase)
+		}
+		probe.pod = pod
+	}
+
+	eg := errgroup.Group{}
+	eg.SetLimit(networkMatrixConcurrency)
+	for i := range edges {
+		edge := &edges[i]
+		eg.Go(func() error {
+			runNetworkEdge(ctx, check, edge, timeout)
+			return nil
+		})
+	}
+	_ = eg.Wait()
+
+	result.AddDetails(edges)
+	for _, edge := range edges {
+		if !edge.Matches() {
+			results.Failf("%s", edge)
+		}
+	}
+	return results
+}
+
+// planNetworkMatrix returns the edges to test from every probe, with the expected result of each edge
+func planNetworkMatrix(check v1.NetworkMatrixCheck, probes []*networkProbe) ([]NetworkEdge, error) {
+	type rule struct {
+		v1.NetworkMatrixRule
+		from, to glob.Glob
+	}
+	var rules []rule
+	for _, r := range check.Expected {
+		from, err := glob.Compile(r.From)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rule from %s: %w", r.From, err)
+		}
+		to, err := glob.Compile(r.To)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rule to %s: %w", r.To, err)
+		}
+		rules = append(rules, rule{NetworkMatrixRule: r, from: from, to: to})
+	}
+	expected := func(from, to, protocol string) bool {
+		for _, r := range rules {
+			if r.from.Match(from) && r.to.Match(to) && (r.Protocol == "" || r.Protocol == protocol) {
+				return r.Allow
+			}
+		}
+		return true
+	}
+
+	var edges []NetworkEdge
+	for _, source := range probes {
+		for _, target := range probes {
+			if source == target {
+				continue
+			}
+			for _, protocol := range check.GetProtocols() {
+				if protocol != v1.NetworkMatrixTCP && protocol != v1.NetworkMatrixHTTP {
+					return nil, fmt.Errorf("unsupported protocol %s between probes, only tcp and http are supported", protocol)
+				}
+				edges = append(edges, NetworkEdge{
+					From:     source.String(),
+					To:       target.String(),
+					Protocol: protocol,
+					Expected: expected(source.Namespace, target.Namespace, protocol),
+					source:   source,
+					target:   target,
+				})
+			}
+		}
+
+		for _, svc := range check.Services {
+			host := fmt.Sprintf("%s.%s.svc.cluster.local", svc.Name, svc.Namespace)
+			protocol := lo.CoalesceOrEmpty(svc.Protocol, v1.NetworkMatrixTCP)
+			if protocol != v1.NetworkMatrixTCP && protocol != v1.NetworkMatrixHTTP {
+				return nil, fmt.Errorf("unsupported protocol %s for service %s, only tcp and http are supported", protocol, svc)
+			}
+			edges = append(edges,
+				NetworkEdge{
+					From:     source.String(),
+					To:       svc.String(),
+					Protocol: v1.NetworkMatrixDNS,
+					Expected: expected(source.Namespace, svc.String(), v1.NetworkMatrixDNS),
+					source:   source,
+					address:  host,
+				},
+				NetworkEdge{
+					From:     source.String(),
+					To:       svc.String(),
+					Protocol: protocol,
+					Expected: expected(source.Namespace, svc.String(), protocol),
+					source:   source,
+					address:  fmt.Sprintf("%s:%d%s", host, svc.Port, lo.Ternary(protocol == v1.NetworkMatrixHTTP, svc.Path, "")),
+				},
+			)
+		}
+	}
+	return edges, nil
+}
+
+// networkEdgeCommand returns the shell command that succeeds if the connection is allowed
+func networkEdgeCommand(protocol, address string, timeout time.Duration) string {
+	seconds := int(lo.Max([]float64{1, timeout.Seconds()}))
+	switch protocol {
+	case v1.NetworkMatrixDNS:
+		return fmt.Sprintf("nslookup %s", shellQuote(address))
+	case v1.NetworkMatrixHTTP:
+		return fmt.Sprintf("wget -q -O /dev/null -T %d %s", seconds, shellQuote("http://"+address))
+	default:
+		host, port, _ := strings.Cut(address, ":")
+		port, _, _ = strings.Cut(port, "/")
+		return fmt.Sprintf("nc -z -w %d %s %s", seconds, shellQuote(host), shellQuote(port))
+	}
+}
+
+func runNetworkEdge(ctx *context.Context, check v1.NetworkMatrixCheck, edge *NetworkEdge, timeout time.Duration) {
+	address := edge.address
+	if edge.target != nil {
+		address = fmt.Sprintf("%s:%d", edge.target.pod.Status.PodIP, check.GetPort())
+	}
+
+	execCtx, cancel := gocontext.WithTimeout(ctx, timeout+5*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	pod := edge.source.pod
+	_, _, err := ctx.KubernetesClient().ExecutePodf(execCtx, pod.Namespace, pod.Name, networkMatrixContainer, "sh", "-c", networkEdgeCommand(edge.Protocol, address, timeout))
+	edge.Duration = time.Since(start).Milliseconds()
+	edge.Allowed = err == nil
+	if err != nil {
+		edge.Error = strings.TrimSpace(err.Error())
+	}
+}
+
+// networkMatrixSelectorValue returns a valid label value for the check, as check names can contain
+// spaces and other characters that are not allowed in labels
+func networkMatrixSelectorValue(namespace, name string) string {
+	hash := sha256.Sum256([]byte(namespace + "/" + name))
+	return hex.EncodeToString(hash[:])[:16]
+}
+
+func newNetworkProbePod(check v1.NetworkMatrixCheck, probe networkProbe, selectorValue string) *corev1.Pod {
+	pod := &corev1.Pod{}
+	pod.APIVersion = corev1.SchemeGroupVersion.Version
+	pod.Kind = podKind
+	pod.Name = "network-matrix-" + strings.ToLower(rand.String(5))
+	pod.Namespace = probe.Namespace
+	pod.Labels = map[string]string{
+		networkMatrixSelector: selectorValue,
+		podGeneralSelector:    "true",
+	}
+	pod.Spec.RestartPolicy = corev1.RestartPolicyNever
+	pod.Spec.TerminationGracePeriodSeconds = lo.ToPtr(int64(0))
+	pod.Spec.Containers = []corev1.Container{{
+		Name:  networkMatrixContainer,
+		Image: lo.CoalesceOrEmpty(check.Image, networkMatrixImage),
+		Command: []string{"sh", "-c", fmt.Sprintf(
+			"mkdir -p /tmp/www && echo ok > /tmp/www/index.html && exec httpd -f -p %d -h /tmp/www", check.GetPort())},
+		Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: int32(check.GetPort())}},
+	}}
+	schedulePod(pod, probe.Node, check.PriorityClass)
+	return pod
+}
+
+func durationOrDefault(d v1.Duration, def time.Duration) (time.Duration, error) {
+	if d == "" {
+		return def, nil
+	}
+	parsed, err := d.GetDuration()
+	if err != nil {
+		return 0, err
+	}
+	return *parsed, nil
+}
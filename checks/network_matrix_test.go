@@ -0,0 +1,75 @@
+package checks
+
+import (
+	"testing"
+	"time"
+
+	v1 "github.com/flanksource/canary-checker/api/v1"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+func TestPlanNetworkMatrix(t *testing.T) {
+	RegisterTestingT(t)
+	check := v1.NetworkMatrixCheck{
+		Namespaces: []string{"frontend", "backend"},
+		Services:   []v1.NetworkMatrixService{{Name: "db", Namespace: "data", Port: 5432}},
+		Expected: []v1.NetworkMatrixRule{
+			{From: "backend", To: "frontend", Allow: false},
+			{From: "frontend", To: "data/*", Protocol: "tcp", Allow: false},
+		},
+	}
+	probes := []*networkProbe{{Namespace: "frontend"}, {Namespace: "backend"}}
+
+	edges, err := planNetworkMatrix(check, probes)
+	Expect(err).ToNot(HaveOccurred())
+	// 2 protocols to the other probe and dns + tcp to the service, from each probe
+	Expect(edges).To(HaveLen(8))
+
+	expected := map[string]bool{}
+	for _, edge := range edges {
+		expected[edge.From+" "+edge.To+" "+edge.Protocol] = edge.Expected
+	}
+	Expect(expected).To(Equal(map[string]bool{
+		"frontend backend tcp":  true,
+		"frontend backend http": true,
+		"frontend data/db dns":  true,
+		"frontend data/db tcp":  false,
+		"backend frontend tcp":  false,
+		"backend frontend http": false,
+		"backend data/db dns":   true,
+		"backend data/db tcp":   true,
+	}))
+
+	edge := NetworkEdge{From: "backend", To: "frontend", Protocol: "tcp", Expected: false, Allowed: true}
+	Expect(edge.Matches()).To(BeFalse())
+	Expect(edge.String()).To(Equal("backend -> frontend (tcp): expected deny, got allow"))
+
+	check.Protocols = []string{"udp"}
+	_, err = planNetworkMatrix(check, probes)
+	Expect(err).To(MatchError(ContainSubstring("unsupported protocol udp")))
+}
+
+func TestNetworkEdgeCommand(t *testing.T) {
+	RegisterTestingT(t)
+	Expect(networkEdgeCommand("tcp", "10.0.0.1:8080", 5*time.Second)).To(Equal("nc -z -w 5 '10.0.0.1' '8080'"))
+	Expect(networkEdgeCommand("http", "db.data.svc.cluster.local:80/health", 500*time.Millisecond)).
+		To(Equal("wget -q -O /dev/null -T 1 'http://db.data.svc.cluster.local:80/health'"))
+	Expect(networkEdgeCommand("dns", "db.data.svc.cluster.local", time.Second)).To(Equal("nslookup 'db.data.svc.cluster.local'"))
+}
+
+func TestNewNetworkProbePod(t *testing.T) {
+	RegisterTestingT(t)
+	check := v1.NetworkMatrixCheck{PriorityClass: "low", Port: 9000}
+	selectorValue := networkMatrixSelectorValue("canaries", "Network matrix: frontend to backend")
+	Expect(validation.IsValidLabelValue(selectorValue)).To(BeEmpty())
+	Expect(selectorValue).ToNot(Equal(networkMatrixSelectorValue("canaries", "Network matrix: backend to frontend")))
+
+	pod := newNetworkProbePod(check, networkProbe{Namespace: "frontend", Node: "node-1"}, selectorValue)
+	Expect(pod.Namespace).To(Equal("frontend"))
+	Expect(pod.Labels[networkMatrixSelector]).To(Equal(selectorValue))
+	Expect(pod.Spec.PriorityClassName).To(Equal("low"))
+	Expect(pod.Spec.NodeSelector).To(Equal(map[string]string{"kubernetes.io/hostname": "node-1"}))
+	Expect(pod.Spec.Containers[0].Image).To(Equal("busybox"))
+	Expect(pod.Spec.Containers[0].Command[2]).To(ContainSubstring("httpd -f -p 9000"))
+}
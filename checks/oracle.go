@@ -0,0 +1,30 @@
+package checks
+
+import (
+	"github.com/flanksource/canary-checker/api/context"
+
+	"github.com/flanksource/canary-checker/api/external"
+	v1 "github.com/flanksource/canary-checker/api/v1"
+	"github.com/flanksource/canary-checker/pkg"
+	_ "github.com/sijms/go-ora/v2" // Necessary for oracle
+)
+
+type OracleChecker struct{}
+
+func (c *OracleChecker) Type() string {
+	return "oracle"
+}
+
+// Run: Check every entry from config according to Checker interface
+// Returns check result and metrics
+func (c *OracleChecker) Run(ctx *context.Context) pkg.Results {
+	var results pkg.Results
+	for _, conf := range ctx.Canary.Spec.Oracle {
+		results = append(results, c.Check(ctx, conf)...)
+	}
+	return results
+}
+
+func (c *OracleChecker) Check(ctx *context.Context, extConfig external.Check) pkg.Results {
+	return CheckSQL(ctx, extConfig.(v1.OracleCheck))
+}
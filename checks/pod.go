@@ -42,16 +42,17 @@ const (
 
 type PodChecker struct {
 	lock *semaphore.Weighted
-	k8s  kubernetes.Interface
 	ng   *NameGenerator
 
-	latestNodeIndex int
+	// latestNodeIndex is the node the last pod was scheduled on in each cluster
+	latestNodeIndex map[string]int
 }
 
 func NewPodChecker() *PodChecker {
 	pc := &PodChecker{
-		lock: semaphore.NewWeighted(1),
-		ng:   &NameGenerator{PodsCount: 20},
+		lock:            semaphore.NewWeighted(1),
+		ng:              &NameGenerator{PodsCount: 20},
+		latestNodeIndex: make(map[string]int),
 	}
 	return pc
 }
@@ -62,13 +63,8 @@ func (c *PodChecker) Run(ctx *context.Context) pkg.Results {
 	logger.Warnf("pod check is deprecated. Please use the kubernetes resource check")
 
 	var results pkg.Results
-	if len(ctx.Canary.Spec.Pod) > 0 {
-		if c.k8s == nil {
-			c.k8s = ctx.Kubernetes()
-		}
-		for _, conf := range ctx.Canary.Spec.Pod {
-			results = append(results, c.Check(ctx, conf)...)
-		}
+	for _, conf := range ctx.Canary.Spec.Pod {
+		results = append(results, c.Check(ctx, conf)...)
 	}
 	return results
 }
@@ -94,20 +90,28 @@ func (c *PodChecker) newPod(podCheck canaryv1.PodCheck, nodeName string) (*v1.Po
 	pod.Labels[podCheckSelector] = c.podCheckSelectorValue(podCheck)
 	pod.Labels[podGeneralSelector] = "true"
 
-	if podCheck.RoundRobinNodes {
+	if !podCheck.RoundRobinNodes {
+		nodeName = ""
+	}
+	schedulePod(pod, nodeName, podCheck.PriorityClass)
+	return pod, nil
+}
+
+// schedulePod pins the pod to the node and sets its priority class, if specified
+func schedulePod(pod *v1.Pod, nodeName, priorityClass string) {
+	if nodeName != "" {
 		pod.Spec.NodeSelector = map[string]string{
 			"kubernetes.io/hostname": nodeName,
 		}
 	}
 
-	if podCheck.PriorityClass != "" {
-		pod.Spec.PriorityClassName = podCheck.PriorityClass
+	if priorityClass != "" {
+		pod.Spec.PriorityClassName = priorityClass
 	}
-	return pod, nil
 }
 
-func (c *PodChecker) getConditionTimes(podCheck canaryv1.PodCheck, pod *v1.Pod) (times map[v1.PodConditionType]metav1.Time, err error) {
-	pods := c.k8s.CoreV1().Pods(podCheck.Namespace)
+func (c *PodChecker) getConditionTimes(k8s kubernetes.Interface, podCheck canaryv1.PodCheck, pod *v1.Pod) (times map[v1.PodConditionType]metav1.Time, err error) {
+	pods := k8s.CoreV1().Pods(podCheck.Namespace)
 	times = make(map[v1.PodConditionType]metav1.Time)
 	pod, err = pods.Get(gocontext.TODO(), pod.Name, metav1.GetOptions{})
 	if err != nil {
@@ -146,10 +150,18 @@ func (c *PodChecker) Check(ctx *context.Context, extConfig external.Check) pkg.R
 	result := pkg.Success(podCheck, ctx.Canary)
 	var results pkg.Results
 	results = append(results, result)
+
+	kctx, err := ctx.WithKubernetesConnection(podCheck.KubernetesConnection)
+	if err != nil {
+		return results.WithError(err).Invalidf("Cannot connect to kubernetes")
+	}
+	ctx = &kctx
+	k8s := ctx.Kubernetes()
+
 	startTimer := NewTimer()
-	pods := c.k8s.CoreV1().Pods(podCheck.Namespace)
+	pods := k8s.CoreV1().Pods(podCheck.Namespace)
 
-	if skip, err := cleanupExistingPods(ctx, c.k8s, c.podCheckSelector(podCheck)); err != nil {
+	if skip, err := cleanupExistingPods(ctx, k8s, c.podCheckSelector(podCheck)); err != nil {
 		return results.ErrorMessage(err)
 	} else if skip {
 		return nil
@@ -159,29 +171,30 @@ func (c *PodChecker) Check(ctx *context.Context, extConfig external.Check) pkg.R
 	defer c.Cleanup(ctx, podCheck) // cleanup resources created during test
 
 	five := int64(5)
-	nodes, err := c.k8s.CoreV1().Nodes().List(ctx, metav1.ListOptions{TimeoutSeconds: &five})
+	nodes, err := k8s.CoreV1().Nodes().List(ctx, metav1.ListOptions{TimeoutSeconds: &five})
 	if err != nil {
 		return results.Failf("cannot connect to API server: %v", err)
 	}
-	nextNode, newIndex := c.nextNode(nodes, c.latestNodeIndex)
-	c.latestNodeIndex = newIndex
+	cluster := kubernetesIdentity(ctx.KubernetesRestConfig())
+	nextNode, newIndex := c.nextNode(nodes, c.latestNodeIndex[cluster])
+	c.latestNodeIndex[cluster] = newIndex
 
 	pod, err := c.newPod(podCheck, nextNode)
 	if err != nil {
 		return results.Failf("invalid pod spec: %v", err)
 	}
 
-	if _, err := c.k8s.CoreV1().Pods(podCheck.Namespace).Create(ctx, pod, metav1.CreateOptions{}); err != nil {
+	if _, err := pods.Create(ctx, pod, metav1.CreateOptions{}); err != nil {
 		return results.ErrorMessage(err)
 	}
 
-	pod, err = c.WaitForPod(podCheck.Namespace, pod.Name, time.Millisecond*time.Duration(podCheck.ScheduleTimeout), v1.PodRunning)
+	pod, err = waitForPod(k8s, podCheck.Namespace, pod.Name, time.Millisecond*time.Duration(podCheck.ScheduleTimeout), v1.PodRunning)
 	if err != nil {
 		return results.Failf("unable to fetch pod details: %v", err)
 	}
 	created := pod.GetCreationTimestamp()
 
-	conditions, err := c.getConditionTimes(podCheck, pod)
+	conditions, err := c.getConditionTimes(k8s, podCheck, pod)
 	if err != nil {
 		return results.Failf("could not list conditions: %v", err)
 	}
@@ -207,7 +220,7 @@ func (c *PodChecker) Check(ctx *context.Context, extConfig external.Check) pkg.R
 	message := ingressResult.Message
 
 	if !ingressResult.Pass {
-		podFailMessage, err := c.podFailMessage(pod)
+		podFailMessage, err := c.podFailMessage(k8s, pod)
 		if err != nil {
 			ctx.Error(err, "failed to get pod fail message")
 		}
@@ -259,25 +272,29 @@ func (c *PodChecker) Check(ctx *context.Context, extConfig external.Check) pkg.R
 	return results
 }
 
+// Cleanup deletes the pods and services of the check in the cluster of the context
 func (c *PodChecker) Cleanup(ctx *context.Context, podCheck canaryv1.PodCheck) {
-	listOptions := metav1.ListOptions{LabelSelector: c.podCheckSelector(podCheck)}
+	cleanupPods(ctx, ctx.Kubernetes(), podCheck.Name, podCheck.Namespace, c.podCheckSelector(podCheck))
+}
 
-	if c.k8s == nil {
-		ctx.Warnf("connection to k8s not established")
-	}
-	err := c.k8s.CoreV1().Pods(podCheck.Namespace).DeleteCollection(ctx, metav1.DeleteOptions{}, listOptions)
+// cleanupPods deletes the pods and services created by a check
+func cleanupPods(ctx *context.Context, k8s kubernetes.Interface, name, namespace, selector string) {
+	listOptions := metav1.ListOptions{LabelSelector: selector}
+
+	err := k8s.CoreV1().Pods(namespace).DeleteCollection(ctx, metav1.DeleteOptions{}, listOptions)
 	if err != nil && !errors.IsNotFound(err) {
-		ctx.Warnf("Failed to delete pods for check %s in namespace %s : %v", podCheck.Name, podCheck.Namespace, err)
+		ctx.Warnf("Failed to delete pods for check %s in namespace %s : %v", name, namespace, err)
 	}
 
-	services, err := c.k8s.CoreV1().Services(podCheck.Namespace).List(ctx, listOptions)
+	services, err := k8s.CoreV1().Services(namespace).List(ctx, listOptions)
 	if err != nil {
-		ctx.Warnf("Failed to get services to cleanup %s in namespace %s : %v", podCheck.Name, podCheck.Namespace, err)
+		ctx.Warnf("Failed to get services to cleanup %s in namespace %s : %v", name, namespace, err)
+		return
 	}
 
 	for _, s := range services.Items {
-		if err := ctx.Kubernetes().CoreV1().Services(podCheck.Namespace).Delete(ctx, s.Name, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
-			ctx.Warnf("Failed delete services %s in namespace %s : %v", s.Name, podCheck.Namespace, err)
+		if err := k8s.CoreV1().Services(namespace).Delete(ctx, s.Name, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+			ctx.Warnf("Failed delete services %s in namespace %s : %v", s.Name, namespace, err)
 		}
 	}
 }
@@ -385,14 +402,15 @@ func (c *PodChecker) createServiceAndIngress(ctx *context.Context, podCheck cana
 		},
 	}
 
-	if _, err := c.k8s.CoreV1().Services(svc.Namespace).Create(gocontext.TODO(), svc, metav1.CreateOptions{}); err != nil {
+	k8s := ctx.Kubernetes()
+	if _, err := k8s.CoreV1().Services(svc.Namespace).Create(gocontext.TODO(), svc, metav1.CreateOptions{}); err != nil {
 		return perrors.Wrapf(err, "Failed to create service for pod %s in namespace %s", pod.Name, pod.Namespace)
 	}
 
 	if podCheck.IngressHost == "" || podCheck.IngressName == "" {
 		return nil
 	}
-	ingress, err := c.k8s.NetworkingV1().Ingresses(podCheck.Namespace).Get(gocontext.TODO(), podCheck.IngressName, metav1.GetOptions{})
+	ingress, err := k8s.NetworkingV1().Ingresses(podCheck.Namespace).Get(gocontext.TODO(), podCheck.IngressName, metav1.GetOptions{})
 	if err != nil && !errors.IsNotFound(err) {
 		return perrors.Wrapf(err, "Failed to get ingress %s in namespace %s", podCheck.IngressName, podCheck.Namespace)
 	} else if err == nil {
@@ -404,13 +422,13 @@ func (c *PodChecker) createServiceAndIngress(ctx *context.Context, podCheck cana
 		if podCheck.IngressClass != "" {
 			ingress.Spec.IngressClassName = &podCheck.IngressClass
 		}
-		if _, err := c.k8s.NetworkingV1().Ingresses(podCheck.Namespace).Update(gocontext.TODO(), ingress, metav1.UpdateOptions{}); err != nil {
+		if _, err := k8s.NetworkingV1().Ingresses(podCheck.Namespace).Update(gocontext.TODO(), ingress, metav1.UpdateOptions{}); err != nil {
 			return perrors.Wrapf(err, "failed to update ingress %s in namespace %s", podCheck.IngressName, podCheck.Namespace)
 		}
 	} else {
 		ctx.Debugf("Creating ingress: %s", podCheck.IngressName)
 		ingress := c.newIngress(podCheck, svc.Name)
-		if _, err := c.k8s.NetworkingV1().Ingresses(podCheck.Namespace).Create(gocontext.TODO(), ingress, metav1.CreateOptions{}); err != nil {
+		if _, err := k8s.NetworkingV1().Ingresses(podCheck.Namespace).Create(gocontext.TODO(), ingress, metav1.CreateOptions{}); err != nil {
 			return perrors.Wrapf(err, "failed to create ingress %s in namespace %s", podCheck.IngressName, podCheck.Namespace)
 		}
 	}
@@ -502,8 +520,8 @@ func (c *PodChecker) podCheckSelector(podCheck canaryv1.PodCheck) string {
 	return fmt.Sprintf("%s=%s", podCheckSelector, c.podCheckSelectorValue(podCheck))
 }
 
-func (c *PodChecker) podFailMessage(pod *v1.Pod) (string, error) {
-	pods := c.k8s.CoreV1().Pods(pod.Namespace)
+func (c *PodChecker) podFailMessage(k8s kubernetes.Interface, pod *v1.Pod) (string, error) {
+	pods := k8s.CoreV1().Pods(pod.Namespace)
 	p, err := pods.Get(gocontext.Background(), pod.Name, metav1.GetOptions{})
 	if err != nil {
 		return "", perrors.Wrapf(err, "failed to get pod %s in namespace %s", pod.Name, pod.Namespace)
@@ -520,10 +538,10 @@ func (c *PodChecker) podFailMessage(pod *v1.Pod) (string, error) {
 	return "", nil
 }
 
-// WaitForPod waits for a pod to be in the specified phase, or returns an
+// waitForPod waits for a pod to be in the specified phase, or returns an
 // error if the timeout is exceeded
-func (c *PodChecker) WaitForPod(ns, name string, timeout time.Duration, phases ...v1.PodPhase) (*v1.Pod, error) {
-	pods := c.k8s.CoreV1().Pods(ns)
+func waitForPod(k8s kubernetes.Interface, ns, name string, timeout time.Duration, phases ...v1.PodPhase) (*v1.Pod, error) {
+	pods := k8s.CoreV1().Pods(ns)
 	start := time.Now()
 	for {
 		pod, err := pods.Get(gocontext.TODO(), name, metav1.GetOptions{})
@@ -1,23 +1,55 @@
 package checks
 
 import (
+	gocontext "context"
+	"fmt"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/flanksource/canary-checker/api/context"
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/flanksource/canary-checker/api/external"
 	v1 "github.com/flanksource/canary-checker/api/v1"
 	"github.com/flanksource/canary-checker/pkg"
+	"github.com/flanksource/canary-checker/pkg/metrics"
 	"github.com/go-redis/redis/v8"
 )
 
+var redisReplicationLag = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "canary_check_redis_replication_lag_seconds",
+		Help: "Seconds since a redis replica last heard from its master",
+	},
+	[]string{"key", "addr", "role"},
+)
+
 func init() {
-	//register metrics here
+	metrics.RegisterCheckGauge(redisReplicationLag)
 }
 
 type RedisChecker struct {
 }
 
+// RedisNode is the state of a single redis server as seen by the check
+type RedisNode struct {
+	Addr             string            `json:"addr"`
+	Role             string            `json:"role,omitempty"`
+	MasterLinkStatus string            `json:"masterLinkStatus,omitempty"`
+	Lag              int64             `json:"lag"`
+	Offset           int64             `json:"offset"`
+	Replication      map[string]string `json:"replication,omitempty"`
+	Error            string            `json:"error,omitempty"`
+}
+
+type RedisDetails struct {
+	Mode     string         `json:"mode"`
+	Nodes    []RedisNode    `json:"nodes"`
+	Commands map[string]any `json:"commands,omitempty"`
+}
+
 // Type: returns checker type
 func (c *RedisChecker) Type() string {
 	return "redis"
@@ -39,8 +71,6 @@ func (c *RedisChecker) Check(ctx *context.Context, extConfig external.Check) pkg
 	var results pkg.Results
 	results = append(results, result)
 
-	var redisOpts *redis.Options
-
 	//nolint:staticcheck
 	if check.Addr != "" && check.URL == "" {
 		check.URL = check.Addr
@@ -51,29 +81,259 @@ func (c *RedisChecker) Check(ctx *context.Context, extConfig external.Check) pkg
 		return results.Failf("error getting connection: %v", err)
 	}
 
-	redisOpts = &redis.Options{
-		Addr:     connection.URL,
-		Username: connection.Username,
-		Password: connection.Password,
+	db := 0
+	if check.DB != nil {
+		db = *check.DB
+	} else if _db, ok := connection.Properties["db"]; ok {
+		if dbInt, err := strconv.Atoi(_db); nil == err {
+			db = dbInt
+		}
 	}
 
-	if check.DB != nil {
-		redisOpts.DB = *check.DB
-	} else if db, ok := connection.Properties["db"]; ok {
-		if dbInt, err := strconv.Atoi(db); nil == err {
-			redisOpts.DB = dbInt
+	details := RedisDetails{Mode: check.GetMode()}
+	var client redis.UniversalClient
+
+	switch details.Mode {
+	case "sentinel":
+		sentinelPassword, err := ctx.GetEnvValueFromCache(check.Sentinel.Password, ctx.GetNamespace())
+		if err != nil {
+			return results.Failf("error getting sentinel password: %v", err)
+		}
+		client = redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:       check.Sentinel.MasterName,
+			SentinelAddrs:    check.Sentinel.Addrs,
+			SentinelPassword: sentinelPassword,
+			Username:         connection.Username,
+			Password:         connection.Password,
+			DB:               db,
+		})
+		details.Nodes, err = inspectSentinel(ctx, check.Sentinel, sentinelPassword, &redis.Options{
+			Username: connection.Username,
+			Password: connection.Password,
+			DB:       db,
+		})
+		if err != nil {
+			_ = client.Close()
+			return results.Failf("error querying sentinels: %v", err)
 		}
+
+	case "cluster":
+		addrs := check.Cluster.Addrs
+		if len(addrs) == 0 {
+			addrs = []string{connection.URL}
+		}
+		cluster := redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:    addrs,
+			Username: connection.Username,
+			Password: connection.Password,
+		})
+		client = cluster
+
+		var lock sync.Mutex
+		err := cluster.ForEachShard(ctx, func(_ gocontext.Context, shard *redis.Client) error {
+			node := inspectRedisNode(ctx, shard)
+			lock.Lock()
+			details.Nodes = append(details.Nodes, node)
+			lock.Unlock()
+			return nil
+		})
+		if err != nil {
+			_ = client.Close()
+			return results.Failf("error listing cluster shards: %v", err)
+		}
+
+	default:
+		rdb := redis.NewClient(&redis.Options{
+			Addr:     connection.URL,
+			Username: connection.Username,
+			Password: connection.Password,
+			DB:       db,
+		})
+		client = rdb
+		details.Nodes = []RedisNode{inspectRedisNode(ctx, rdb)}
 	}
+	defer client.Close()
 
-	rdb := redis.NewClient(redisOpts)
-	queryResult, err := rdb.Ping(ctx).Result()
+	result.AddDetails(details)
+
+	maxLag, err := getRedisMaxLag(check)
 	if err != nil {
-		return results.Failf("failed to execute query %v", err)
+		return results.Invalidf("invalid maxReplicationLag: %v", err)
+	}
+
+	key := ctx.Canary.GetCheckID(check.GetName())
+	for _, node := range details.Nodes {
+		redisReplicationLag.WithLabelValues(key, node.Addr, node.Role).Set(float64(node.Lag))
+		if node.Error != "" {
+			result.Failf("%s: %s", node.Addr, node.Error)
+			continue
+		}
+		if node.Role == "slave" && node.MasterLinkStatus != "up" {
+			result.Failf("%s: replica link to master is %s", node.Addr, node.MasterLinkStatus)
+		} else if maxLag != nil && node.Role == "slave" && time.Duration(node.Lag)*time.Second > *maxLag {
+			result.Failf("%s: replication lag of %ds exceeds %s", node.Addr, node.Lag, check.MaxReplicationLag)
+		}
+	}
+
+	if !result.Pass {
+		return results
 	}
 
-	if queryResult != "PONG" {
-		return results.Failf("expected PONG as result, got %s", result)
+	if len(check.Commands) > 0 {
+		details.Commands = make(map[string]any)
+		for _, command := range check.Commands {
+			reply, err := runRedisCommand(ctx, client, command)
+			if err != nil {
+				result.AddDetails(details)
+				return results.Failf("%s failed: %v", command.GetName(), err)
+			}
+			details.Commands[command.GetName()] = reply
+		}
+		result.AddDetails(details)
 	}
 
 	return results
 }
+
+func getRedisMaxLag(check v1.RedisCheck) (*time.Duration, error) {
+	if check.MaxReplicationLag == "" {
+		return nil, nil
+	}
+	return check.MaxReplicationLag.GetDuration()
+}
+
+// runRedisCommand executes a single command, INFO replies are parsed into a map
+func runRedisCommand(ctx *context.Context, client redis.UniversalClient, command v1.RedisCommand) (any, error) {
+	if len(command.Command) == 0 {
+		return nil, fmt.Errorf("command is empty")
+	}
+	var args []any
+	for _, arg := range command.Command {
+		args = append(args, arg)
+	}
+
+	reply, err := client.Do(ctx, args...).Result()
+	if err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	if command.Expect != "" && fmt.Sprint(reply) != command.Expect {
+		return nil, fmt.Errorf("expected %s, got %v", command.Expect, reply)
+	}
+
+	if s, ok := reply.(string); ok && strings.EqualFold(command.Command[0], "info") {
+		return parseRedisInfo(s), nil
+	}
+	return reply, nil
+}
+
+func inspectRedisNode(ctx *context.Context, client *redis.Client) RedisNode {
+	node := RedisNode{Addr: client.Options().Addr}
+
+	pong, err := client.Ping(ctx).Result()
+	if err != nil {
+		node.Error = err.Error()
+		return node
+	}
+	if pong != "PONG" {
+		node.Error = fmt.Sprintf("expected PONG as result, got %s", pong)
+		return node
+	}
+
+	info, err := client.Info(ctx, "replication").Result()
+	if err != nil {
+		node.Error = fmt.Sprintf("failed to get replication info: %v", err)
+		return node
+	}
+
+	node.Replication = parseRedisInfo(info)
+	node.Role = node.Replication["role"]
+	node.MasterLinkStatus = node.Replication["master_link_status"]
+	if node.Role == "slave" {
+		node.Lag, _ = strconv.ParseInt(node.Replication["master_last_io_seconds_ago"], 10, 64)
+		node.Offset, _ = strconv.ParseInt(node.Replication["slave_repl_offset"], 10, 64)
+	} else {
+		node.Offset, _ = strconv.ParseInt(node.Replication["master_repl_offset"], 10, 64)
+	}
+	return node
+}
+
+func inspectRedisAddr(ctx *context.Context, addr string, opts redis.Options) RedisNode {
+	opts.Addr = addr
+	client := redis.NewClient(&opts)
+	defer client.Close()
+	return inspectRedisNode(ctx, client)
+}
+
+// inspectSentinel checks the master and every replica known to the sentinels
+func inspectSentinel(ctx *context.Context, sentinel *v1.RedisSentinel, sentinelPassword string, opts *redis.Options) ([]RedisNode, error) {
+	var lastErr error
+	for _, addr := range sentinel.Addrs {
+		client := redis.NewSentinelClient(&redis.Options{Addr: addr, Password: sentinelPassword})
+		nodes, err := inspectSentinelReplicas(ctx, client, sentinel.MasterName, *opts)
+		_ = client.Close()
+		if err == nil {
+			return nodes, nil
+		}
+		lastErr = err
+	}
+	if lastErr == nil {
+		lastErr = fmt.Errorf("no sentinel addresses specified")
+	}
+	return nil, lastErr
+}
+
+func inspectSentinelReplicas(ctx *context.Context, sentinel *redis.SentinelClient, masterName string, opts redis.Options) ([]RedisNode, error) {
+	master, err := sentinel.GetMasterAddrByName(ctx, masterName).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(master) != 2 {
+		return nil, fmt.Errorf("unexpected master address %v", master)
+	}
+
+	nodes := []RedisNode{inspectRedisAddr(ctx, master[0]+":"+master[1], opts)}
+
+	replicas, err := sentinel.Slaves(ctx, masterName).Result()
+	if err != nil {
+		return nil, err
+	}
+	for _, replica := range replicas {
+		fields := sentinelReplyToMap(replica)
+		addr := fields["ip"] + ":" + fields["port"]
+		if strings.Contains(fields["flags"], "disconnected") || strings.Contains(fields["flags"], "s_down") {
+			nodes = append(nodes, RedisNode{Addr: addr, Role: "slave", Error: "replica is " + fields["flags"]})
+			continue
+		}
+		nodes = append(nodes, inspectRedisAddr(ctx, addr, opts))
+	}
+	return nodes, nil
+}
+
+// sentinelReplyToMap converts a flat [key, value, key, value...] sentinel reply into a map
+func sentinelReplyToMap(reply any) map[string]string {
+	out := make(map[string]string)
+	fields, ok := reply.([]any)
+	if !ok {
+		return out
+	}
+	for i := 0; i+1 < len(fields); i += 2 {
+		out[fmt.Sprint(fields[i])] = fmt.Sprint(fields[i+1])
+	}
+	return out
+}
+
+// parseRedisInfo parses the key:value lines returned by INFO, ignoring section headers
+func parseRedisInfo(info string) map[string]string {
+	out := make(map[string]string)
+	for _, line := range strings.Split(info, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if key, value, ok := strings.Cut(line, ":"); ok {
+			out[key] = value
+		}
+	}
+	return out
+}
@@ -0,0 +1,30 @@
+package checks
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestParseRedisInfo(t *testing.T) {
+	RegisterTestingT(t)
+	info := parseRedisInfo("# Replication\r\nrole:slave\r\nmaster_host:10.0.0.1\r\nmaster_link_status:up\r\nmaster_last_io_seconds_ago:3\r\n\r\n")
+
+	Expect(info).To(HaveKeyWithValue("role", "slave"))
+	Expect(info).To(HaveKeyWithValue("master_host", "10.0.0.1"))
+	Expect(info).To(HaveKeyWithValue("master_link_status", "up"))
+	Expect(info).To(HaveKeyWithValue("master_last_io_seconds_ago", "3"))
+	Expect(info).To(HaveLen(4))
+}
+
+func TestSentinelReplyToMap(t *testing.T) {
+	RegisterTestingT(t)
+	fields := sentinelReplyToMap([]any{"ip", "10.0.0.2", "port", "6379", "flags", "slave,s_down"})
+
+	Expect(fields).To(Equal(map[string]string{
+		"ip":    "10.0.0.2",
+		"port":  "6379",
+		"flags": "slave,s_down",
+	}))
+	Expect(sentinelReplyToMap("unexpected")).To(BeEmpty())
+}
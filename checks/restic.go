@@ -3,7 +3,6 @@ package checks
 import (
 	"encoding/json"
 	"fmt"
-	osExec "os/exec"
 	"strconv"
 	"time"
 
@@ -100,17 +99,64 @@ func (c *ResticChecker) Check(ctx *context.Context, extConfig external.Check) pk
 		}
 	}
 
-	if check.CheckIntegrity {
-		if err := checkIntegrity(check.Repository, check.CaCert, envVars); err != nil {
-			return results.Failf("integrity check failed %v", err)
-		}
+	repo := resticRepository{repository: check.Repository, caCert: check.CaCert, env: envVars}
+	return checkBackupRepository(ctx, result, repo, v1.BackupRepository{
+		MaxAge:         v1.Duration(check.MaxAge),
+		CheckIntegrity: check.CheckIntegrity,
+	})
+}
+
+type resticRepository struct {
+	repository, caCert string
+	env                map[string]string
+}
+
+type resticSnapshot struct {
+	ID       string    `json:"id"`
+	ShortID  string    `json:"short_id"`
+	Time     time.Time `json:"time"`
+	Hostname string    `json:"hostname"`
+	Paths    []string  `json:"paths"`
+	Tags     []string  `json:"tags"`
+	Summary  *struct {
+		TotalBytesProcessed int64 `json:"total_bytes_processed"`
+	} `json:"summary,omitempty"`
+}
+
+func (r resticRepository) Snapshots(ctx *context.Context) ([]BackupSnapshot, error) {
+	args := []string{"-r", r.repository, "--no-lock", "snapshots", "--json"}
+	if r.caCert != "" {
+		args = append([]string{"--cacert", r.caCert}, args...)
+	}
+	output, err := runBackupCommand(ctx, r.env, "restic", args...)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := checkBackupFreshness(check.Repository, check.MaxAge, check.CaCert, envVars); err != nil {
-		return results.Failf("backup freshness check failed: %v", err)
+	var snapshots []resticSnapshot
+	if err := json.Unmarshal(output, &snapshots); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshots: %w", err)
 	}
 
-	return results
+	var result []BackupSnapshot
+	for _, snapshot := range snapshots {
+		s := BackupSnapshot{
+			ID:    snapshot.ShortID,
+			Time:  snapshot.Time,
+			Host:  snapshot.Hostname,
+			Paths: snapshot.Paths,
+			Tags:  snapshot.Tags,
+		}
+		if snapshot.Summary != nil {
+			s.Size = snapshot.Summary.TotalBytesProcessed
+		}
+		result = append(result, s)
+	}
+	return result, nil
+}
+
+func (r resticRepository) Verify(ctx *context.Context) error {
+	return checkIntegrity(r.repository, r.caCert, r.env)
 }
 
 func checkIntegrity(repository, caCert string, envVars map[string]string) error {
@@ -122,36 +168,3 @@ func checkIntegrity(repository, caCert string, envVars map[string]string) error
 	}
 	return exec.ExecfWithEnv(resticCmd, envVars)
 }
-
-func checkBackupFreshness(repository, maxAge, caCert string, envVars map[string]string) error {
-	envString := ""
-	resticCmd := ""
-	for k, v := range envVars {
-		envString += fmt.Sprintf("%s=%s ", k, v)
-	}
-	if caCert != "" {
-		resticCmd = fmt.Sprintf("%[1]v restic --cacert %[3]s -r %[2]s --no-lock cat snapshot -q $(%[1]v restic --cacert %[3]s -q --no-lock -r %[2]s snapshots latest  | awk 'FNR == 3 {print $1}')", envString, repository, caCert)
-	} else {
-		resticCmd = fmt.Sprintf("%[1]v restic -r %[2]s --no-lock cat snapshot -q $(%[1]v restic --no-lock -r %[2]s -q snapshots latest | awk 'FNR == 3 {print $1}')", envString, repository)
-	}
-	cmd := osExec.Command("bash", "-c", resticCmd)
-	latestSnapshotInfo, err := cmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("error checking backup freshness : %v", string(latestSnapshotInfo))
-	}
-	var parsedLatestSnapshotInfo = make(map[string]interface{})
-	if err := json.Unmarshal(latestSnapshotInfo, &parsedLatestSnapshotInfo); err != nil {
-		return err
-	}
-	timeCreated := parsedLatestSnapshotInfo["time"]
-	backupTime, _ := time.Parse(time.RFC3339, timeCreated.(string))
-	backupDuration := time.Since(backupTime)
-	maxAllowedBackupDuration, err := time.ParseDuration(maxAge)
-	if err != nil {
-		return fmt.Errorf("error parsing the max age: %v", err)
-	}
-	if backupDuration > maxAllowedBackupDuration {
-		return fmt.Errorf("backup is %s older than allowd maxAge for backup", (backupDuration - maxAllowedBackupDuration).String())
-	}
-	return nil
-}
@@ -5,16 +5,43 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/flanksource/canary-checker/api/context"
 	"github.com/flanksource/canary-checker/api/external"
 	v1 "github.com/flanksource/canary-checker/api/v1"
 	"github.com/flanksource/canary-checker/pkg"
+	"github.com/flanksource/canary-checker/pkg/metrics"
 	"github.com/flanksource/commons/logger"
 	"github.com/flanksource/duty/types"
 	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
+var (
+	sqlConnectTime = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "canary_check_sql_connect_time",
+			Help:    "Time in milliseconds taken to get a connection from the pool, including any connection handshake",
+			Buckets: []float64{1, 5, 10, 25, 50, 100, 200, 500, 1000, 3000},
+		},
+		[]string{"key", "driver", "endpoint"},
+	)
+	sqlQueryTime = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "canary_check_sql_query_time",
+			Help:    "Time in milliseconds taken to run the query and read its results",
+			Buckets: []float64{5, 10, 25, 50, 200, 500, 1000, 3000, 10000, 30000},
+		},
+		[]string{"key", "driver", "endpoint"},
+	)
+)
+
+func init() {
+	metrics.RegisterCheckHistogram(sqlConnectTime)
+	metrics.RegisterCheckHistogram(sqlQueryTime)
+}
+
 type SQLChecker interface {
 	GetCheck() external.Check
 	GetDriver() string
@@ -27,24 +54,56 @@ type SQLDetails struct {
 	Count int                      `json:"count"`
 }
 
-// Package contains common function used by SQL Checks (Currently Postgresql and Mssql)
+// Package contains common function used by SQL Checks (Postgresql, Mssql, Mysql, SQLite, ClickHouse and Oracle)
 
 // Connects to a db using the specified `driver` and `connectionstring`
 // Performs the test query given in `query`.
 // Gives the single row test query result as result.
 func querySQL(driver string, connection string, query string) (SQLDetails, error) {
-	result := SQLDetails{}
 	db, err := sql.Open(driver, connection)
 	if err != nil {
-		return result, fmt.Errorf("failed to connect to db: %w", err)
+		return SQLDetails{}, fmt.Errorf("failed to connect to db: %w", err)
 	}
 	defer db.Close()
 
 	rows, err := db.Query(query)
+	if err != nil {
+		return SQLDetails{}, fmt.Errorf("failed to query db: %w", err)
+	}
+	return scanSQLRows(rows)
+}
 
-	if err != nil || rows.Err() != nil {
-		return result, fmt.Errorf("failed to query db: %w", err)
+// queryPooledSQL runs the query on a connection from the pool returned by sqlPools,
+// timing how long it took to get a (healthy) connection separately from the query itself
+func queryPooledSQL(ctx *context.Context, owner, driver, connection, query string) (details SQLDetails, connectTime, queryTime time.Duration, err error) {
+	start := time.Now()
+	db, err := sqlPools.Get(ctx, owner, driver, connection)
+	if err != nil {
+		return details, 0, 0, fmt.Errorf("failed to connect to db: %w", err)
+	}
+
+	conn, err := db.Conn(ctx)
+	if err == nil {
+		defer conn.Close()
+		err = conn.PingContext(ctx)
+	}
+	connectTime = time.Since(start)
+	if err != nil {
+		return details, connectTime, 0, fmt.Errorf("failed to connect to db: %w", err)
+	}
+
+	start = time.Now()
+	rows, err := conn.QueryContext(ctx, query)
+	if err != nil {
+		return details, connectTime, time.Since(start), fmt.Errorf("failed to query db: %w", err)
 	}
+	details, err = scanSQLRows(rows)
+	return details, connectTime, time.Since(start), err
+}
+
+// scanSQLRows reads and closes the rows, converting each row into a map of column name to value
+func scanSQLRows(rows *sql.Rows) (SQLDetails, error) {
+	result := SQLDetails{}
 	defer rows.Close()
 
 	columns, err := rows.Columns()
@@ -117,6 +176,12 @@ func querySQL(driver string, connection string, query string) (SQLDetails, error
 				} else {
 					return result, fmt.Errorf("failed to parse json column: %s", *v)
 				}
+			case *any:
+				if b, ok := (*v).([]byte); ok {
+					row[columns[i]] = string(b)
+				} else {
+					row[columns[i]] = *v
+				}
 			default:
 				row[columns[i]] = fmt.Sprint(val)
 			}
@@ -126,6 +191,9 @@ func querySQL(driver string, connection string, query string) (SQLDetails, error
 	}
 
 	result.Count = len(result.Rows)
+	if err := rows.Err(); err != nil {
+		return result, fmt.Errorf("failed to query db: %w", err)
+	}
 	return result, nil
 }
 
@@ -164,7 +232,22 @@ func CheckSQL(ctx *context.Context, checker SQLChecker) pkg.Results { // nolint:
 		}
 	}
 
-	details, err := querySQL(checker.GetDriver(), connection.URL, query)
+	var details SQLDetails
+	if ctx.Properties().On(false, "sql.pool.disabled") {
+		details, err = querySQL(checker.GetDriver(), connection.URL, query)
+	} else {
+		var connectTime, queryTime time.Duration
+		owner := ctx.Canary.GetKey(checker.GetCheck())
+		details, connectTime, queryTime, err = queryPooledSQL(ctx, owner, checker.GetDriver(), connection.URL, query)
+
+		result.AddData(map[string]any{
+			"connectTime": connectTime.Milliseconds(),
+			"queryTime":   queryTime.Milliseconds(),
+		})
+		key := ctx.Canary.GetCheckID(check.GetName())
+		sqlConnectTime.WithLabelValues(key, checker.GetDriver(), check.GetEndpoint()).Observe(float64(connectTime.Milliseconds()))
+		sqlQueryTime.WithLabelValues(key, checker.GetDriver(), check.GetEndpoint()).Observe(float64(queryTime.Milliseconds()))
+	}
 	result.AddDetails(details)
 
 	if err != nil {
@@ -178,25 +261,40 @@ func CheckSQL(ctx *context.Context, checker SQLChecker) pkg.Results { // nolint:
 	return results
 }
 
+// normalizeColumnType strips type parameters and wrappers such as Nullable(...) and
+// LowCardinality(...) used by clickhouse, e.g. Nullable(DateTime64(3)) -> DATETIME64
+func normalizeColumnType(name string) string {
+	name = strings.ToUpper(name)
+	for _, wrapper := range []string{"NULLABLE(", "LOWCARDINALITY("} {
+		if strings.HasPrefix(name, wrapper) {
+			name = strings.TrimSuffix(strings.TrimPrefix(name, wrapper), ")")
+		}
+	}
+	if i := strings.Index(name, "("); i > 0 {
+		name = name[:i]
+	}
+	return name
+}
+
 // Note: maybe move to duty
 func getRowValues(columnTypes []*sql.ColumnType) []interface{} {
 	rowValues := make([]interface{}, len(columnTypes))
 
 	for i, columnType := range columnTypes {
-		switch columnType.DatabaseTypeName() {
-		case "INT4", "INT8", "INT", "INT16", "INT32":
+		switch normalizeColumnType(columnType.DatabaseTypeName()) {
+		case "INT4", "INT8", "INT", "INT16", "INT32", "UINT8", "UINT16":
 			var v sql.NullInt32
 			rowValues[i] = &v
-		case "BIGINT", "INT64":
+		case "BIGINT", "INT64", "INTEGER", "UINT32":
 			var v sql.NullInt64
 			rowValues[i] = &v
-		case "FLOAT", "FLOAT4", "FLOAT8", "DOUBLE", "DECIMAL", "NUMERIC":
+		case "FLOAT", "FLOAT4", "FLOAT8", "DOUBLE", "DECIMAL", "NUMERIC", "REAL", "NUMBER", "FLOAT32", "FLOAT64":
 			var v sql.NullFloat64
 			rowValues[i] = &v
-		case "BOOL":
+		case "BOOL", "BOOLEAN":
 			var v sql.NullBool
 			rowValues[i] = &v
-		case "DATETIME", "TIMESTAMP", "TIMESTAMPTZ":
+		case "DATETIME", "TIMESTAMP", "TIMESTAMPTZ", "DATETIME64":
 			var v sql.NullTime
 			rowValues[i] = &v
 		case "JSONB", "JSON":
@@ -205,9 +303,14 @@ func getRowValues(columnTypes []*sql.ColumnType) []interface{} {
 		case "UUID":
 			var v uuid.UUID
 			rowValues[i] = &v
-		case "TEXT", "VARCHAR", "CHAR", "NAME", "NVARCHAR", "NTEXT":
+		case "TEXT", "VARCHAR", "CHAR", "NAME", "NVARCHAR", "NTEXT", "STRING", "VARCHAR2", "NVARCHAR2", "CLOB":
 			var v sql.NullString
 			rowValues[i] = &v
+		case "UINT64", "":
+			// the driver type is used as is, UINT64 values can overflow an int64 and
+			// expression columns e.g. count(*) in sqlite have no declared type
+			var v any
+			rowValues[i] = &v
 		default:
 			logger.Warnf("unhandled database column type: %s", columnType.DatabaseTypeName())
 			var v sql.NullString
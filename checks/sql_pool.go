@@ -0,0 +1,113 @@
+package checks
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/flanksource/canary-checker/api/context"
+	"github.com/flanksource/commons/logger"
+	gocache "github.com/patrickmn/go-cache"
+)
+
+const (
+	defaultSQLPoolIdleTimeout = 10 * time.Minute
+	defaultSQLPoolMaxOpen     = 5
+	defaultSQLPoolMaxIdle     = 2
+)
+
+// sqlPools caches *sql.DB handles across check runs, keyed by driver and connection string.
+// A pool is closed once none of the checks that use it have run within the idle timeout.
+var sqlPools = newSQLPoolCache()
+
+type sqlPool struct {
+	db *sql.DB
+	// owners is the number of checks currently using the pool
+	owners int
+}
+
+type sqlPoolCache struct {
+	lock  sync.Mutex
+	pools map[string]*sqlPool
+	// owners maps a check to the pool key it last used, so that a pool can be released
+	// when the credentials of the connection change, or the check stops running
+	owners *gocache.Cache
+}
+
+func newSQLPoolCache() *sqlPoolCache {
+	p := &sqlPoolCache{
+		pools:  make(map[string]*sqlPool),
+		owners: gocache.New(defaultSQLPoolIdleTimeout, time.Minute),
+	}
+	p.owners.OnEvicted(func(owner string, key any) {
+		p.lock.Lock()
+		defer p.lock.Unlock()
+		p.release(key.(string))
+	})
+	return p
+}
+
+func sqlPoolKey(driver, connection string) string {
+	hash := sha256.Sum256([]byte(connection))
+	return driver + "/" + hex.EncodeToString(hash[:])
+}
+
+// Get returns a pooled database handle for the given owner (check), opening a new one if required
+func (p *sqlPoolCache) Get(ctx *context.Context, owner, driver, connection string) (*sql.DB, error) {
+	key := sqlPoolKey(driver, connection)
+	idleTimeout := ctx.Properties().Duration("sql.pool.idleTimeout", defaultSQLPoolIdleTimeout)
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	previous, found := p.owners.Get(owner)
+	if found && previous != key {
+		ctx.Debugf("connection for %s changed, releasing previous pool", owner)
+		p.release(previous.(string))
+	}
+
+	pool, ok := p.pools[key]
+	if !ok {
+		db, err := sql.Open(driver, connection)
+		if err != nil {
+			return nil, err
+		}
+		db.SetMaxOpenConns(ctx.Properties().Int("sql.pool.maxOpen", defaultSQLPoolMaxOpen))
+		db.SetMaxIdleConns(ctx.Properties().Int("sql.pool.maxIdle", defaultSQLPoolMaxIdle))
+		db.SetConnMaxIdleTime(idleTimeout)
+		pool = &sqlPool{db: db}
+		p.pools[key] = pool
+	}
+	if !found || previous != key {
+		pool.owners++
+	}
+
+	// extend the expiry so that only owners that stopped running are released
+	p.owners.Set(owner, key, idleTimeout)
+	return pool.db, nil
+}
+
+// release removes an owner from the pool, closing it when it was the last one.
+// The caller must hold the lock.
+func (p *sqlPoolCache) release(key string) {
+	pool, ok := p.pools[key]
+	if !ok {
+		return
+	}
+	if pool.owners--; pool.owners > 0 {
+		return
+	}
+	delete(p.pools, key)
+	if err := pool.db.Close(); err != nil {
+		logger.Warnf("error closing sql pool %s: %v", key, err)
+	}
+}
+
+// Len returns the number of open pools
+func (p *sqlPoolCache) Len() int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return len(p.pools)
+}
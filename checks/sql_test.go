@@ -0,0 +1,86 @@
+package checks
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/flanksource/canary-checker/api/context"
+	v1 "github.com/flanksource/canary-checker/api/v1"
+	dutyCtx "github.com/flanksource/duty/context"
+	. "github.com/onsi/gomega"
+)
+
+func TestQuerySQLite(t *testing.T) {
+	RegisterTestingT(t)
+	path := filepath.Join(t.TempDir(), "canary.db")
+
+	db, err := sql.Open("sqlite", path)
+	Expect(err).ToNot(HaveOccurred())
+	_, err = db.Exec(`CREATE TABLE exports (id INTEGER PRIMARY KEY, name TEXT, size REAL);
+		INSERT INTO exports (name, size) VALUES ('daily', 10.5), ('weekly', 20);`)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(db.Close()).To(Succeed())
+
+	details, err := querySQL("sqlite", path, "SELECT id, name, size FROM exports ORDER BY id")
+	Expect(err).ToNot(HaveOccurred())
+	Expect(details.Count).To(Equal(2))
+	Expect(details.Rows[0]).To(Equal(map[string]any{"id": int64(1), "name": "daily", "size": 10.5}))
+	Expect(details.Rows[1]["name"]).To(Equal("weekly"))
+
+	details, err = querySQL("sqlite", path, "SELECT count(*) AS count, max(size) AS max, group_concat(name) AS names FROM exports")
+	Expect(err).ToNot(HaveOccurred())
+	Expect(details.Rows[0]).To(Equal(map[string]any{"count": int64(2), "max": float64(20), "names": "daily,weekly"}))
+}
+
+func TestNormalizeColumnType(t *testing.T) {
+	RegisterTestingT(t)
+	Expect(normalizeColumnType("Nullable(DateTime64(3))")).To(Equal("DATETIME64"))
+	Expect(normalizeColumnType("LowCardinality(String)")).To(Equal("STRING"))
+	Expect(normalizeColumnType("UInt64")).To(Equal("UINT64"))
+	Expect(normalizeColumnType("VARCHAR2")).To(Equal("VARCHAR2"))
+}
+
+func TestSQLPoolInvalidatesOnConnectionChange(t *testing.T) {
+	RegisterTestingT(t)
+	ctx := context.New(dutyCtx.New(), v1.Canary{})
+	dir := t.TempDir()
+	pools := newSQLPoolCache()
+
+	first, err := pools.Get(ctx, "check", "sqlite", filepath.Join(dir, "a.db"))
+	Expect(err).ToNot(HaveOccurred())
+	again, err := pools.Get(ctx, "check", "sqlite", filepath.Join(dir, "a.db"))
+	Expect(err).ToNot(HaveOccurred())
+	Expect(again).To(BeIdenticalTo(first))
+	Expect(pools.Len()).To(Equal(1))
+
+	second, err := pools.Get(ctx, "check", "sqlite", filepath.Join(dir, "b.db"))
+	Expect(err).ToNot(HaveOccurred())
+	Expect(second).ToNot(BeIdenticalTo(first))
+	Expect(pools.Len()).To(Equal(1))
+	Expect(first.Ping()).To(MatchError(ContainSubstring("closed")))
+}
+
+func TestSQLPoolSharedByOwners(t *testing.T) {
+	RegisterTestingT(t)
+	ctx := context.New(dutyCtx.New(), v1.Canary{})
+	dir := t.TempDir()
+	pools := newSQLPoolCache()
+
+	shared, err := pools.Get(ctx, "a", "sqlite", filepath.Join(dir, "a.db"))
+	Expect(err).ToNot(HaveOccurred())
+	again, err := pools.Get(ctx, "b", "sqlite", filepath.Join(dir, "a.db"))
+	Expect(err).ToNot(HaveOccurred())
+	Expect(again).To(BeIdenticalTo(shared))
+
+	// the pool stays open while another check still uses it
+	_, err = pools.Get(ctx, "a", "sqlite", filepath.Join(dir, "b.db"))
+	Expect(err).ToNot(HaveOccurred())
+	Expect(pools.Len()).To(Equal(2))
+	Expect(shared.Ping()).To(Succeed())
+
+	// and is closed once the last check stops running
+	pools.owners.Delete("b")
+	Expect(pools.Len()).To(Equal(1))
+	Expect(shared.Ping()).To(MatchError(ContainSubstring("closed")))
+}
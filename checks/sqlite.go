@@ -0,0 +1,30 @@
+package checks
+
+import (
+	"github.com/flanksource/canary-checker/api/context"
+
+	"github.com/flanksource/canary-checker/api/external"
+	v1 "github.com/flanksource/canary-checker/api/v1"
+	"github.com/flanksource/canary-checker/pkg"
+	_ "modernc.org/sqlite" // Necessary for sqlite
+)
+
+type SQLiteChecker struct{}
+
+func (c *SQLiteChecker) Type() string {
+	return "sqlite"
+}
+
+// Run: Check every entry from config according to Checker interface
+// Returns check result and metrics
+func (c *SQLiteChecker) Run(ctx *context.Context) pkg.Results {
+	var results pkg.Results
+	for _, conf := range ctx.Canary.Spec.SQLite {
+		results = append(results, c.Check(ctx, conf)...)
+	}
+	return results
+}
+
+func (c *SQLiteChecker) Check(ctx *context.Context, extConfig external.Check) pkg.Results {
+	return CheckSQL(ctx, extConfig.(v1.SQLiteCheck))
+}
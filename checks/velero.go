@@ -0,0 +1,158 @@
+package checks
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/flanksource/canary-checker/api/context"
+	"github.com/flanksource/canary-checker/api/external"
+	v1 "github.com/flanksource/canary-checker/api/v1"
+	"github.com/flanksource/canary-checker/pkg"
+	"github.com/samber/lo"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+var (
+	veleroBackups                = schema.GroupVersionResource{Group: "velero.io", Version: "v1", Resource: "backups"}
+	veleroSchedules              = schema.GroupVersionResource{Group: "velero.io", Version: "v1", Resource: "schedules"}
+	veleroBackupStorageLocations = schema.GroupVersionResource{Group: "velero.io", Version: "v1", Resource: "backupstoragelocations"}
+
+	// veleroPendingPhases are backups that have not finished yet and are excluded from the snapshots
+	veleroPendingPhases = []string{"", "New", "InProgress", "WaitingForPluginOperations", "WaitingForPluginOperationsPartiallyFailed", "Finalizing", "FinalizingPartiallyFailed"}
+)
+
+type VeleroChecker struct {
+}
+
+func (c *VeleroChecker) Type() string {
+	return "velero"
+}
+
+func (c *VeleroChecker) Run(ctx *context.Context) pkg.Results {
+	var results pkg.Results
+	for _, conf := range ctx.Canary.Spec.Velero {
+		results = append(results, c.Check(ctx, conf)...)
+	}
+	return results
+}
+
+func (c *VeleroChecker) Check(ctx *context.Context, extConfig external.Check) pkg.Results {
+	check := extConfig.(v1.VeleroCheck)
+	result := pkg.Success(check, ctx.Canary)
+	var results pkg.Results
+	results = append(results, result)
+
+	kctx, err := ctx.WithKubernetesConnection(check.KubernetesConnection)
+	if err != nil {
+		return results.WithError(err).Invalidf("Cannot connect to kubernetes")
+	}
+	ctx = &kctx
+
+	if ctx.KubernetesRestConfig() == nil {
+		return results.Failf("Kubernetes is not initialized")
+	}
+
+	client, err := ctx.KubernetesDynamicClient().GetDynamicClient()
+	if err != nil {
+		return results.Failf("failed to get kubernetes client: %v", err)
+	}
+
+	selector, err := labels.Parse(check.Selector)
+	if err != nil {
+		return results.Invalidf("invalid selector: %v", err)
+	}
+
+	if check.Schedule != "" {
+		schedule, err := client.Resource(veleroSchedules).Namespace(check.GetVeleroNamespace()).Get(ctx, check.Schedule, metav1.GetOptions{})
+		if err != nil {
+			return results.Failf("failed to get schedule %s: %v", check.Schedule, err)
+		}
+		if phase, _, _ := unstructured.NestedString(schedule.Object, "status", "phase"); phase != "Enabled" {
+			return results.Failf("schedule %s is %s", check.Schedule, phase)
+		}
+		requirement, err := labels.NewRequirement("velero.io/schedule-name", "=", []string{check.Schedule})
+		if err != nil {
+			return results.Invalidf("invalid schedule: %v", err)
+		}
+		selector = selector.Add(*requirement)
+	}
+
+	repo := veleroRepository{client: client, namespace: check.GetVeleroNamespace(), selector: selector.String()}
+	return checkBackupRepository(ctx, result, repo, check.BackupRepository)
+}
+
+type veleroRepository struct {
+	client    dynamic.Interface
+	namespace string
+	selector  string
+}
+
+func (r veleroRepository) Snapshots(ctx *context.Context) ([]BackupSnapshot, error) {
+	list, err := r.client.Resource(veleroBackups).Namespace(r.namespace).List(ctx, metav1.ListOptions{LabelSelector: r.selector})
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshots []BackupSnapshot
+	for _, backup := range list.Items {
+		if snapshot, ok := veleroBackupToSnapshot(backup); ok {
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+	return snapshots, nil
+}
+
+// veleroBackupToSnapshot converts a finished Backup, returning false for backups that are still in progress
+func veleroBackupToSnapshot(backup unstructured.Unstructured) (BackupSnapshot, bool) {
+	phase, _, _ := unstructured.NestedString(backup.Object, "status", "phase")
+	if lo.Contains(veleroPendingPhases, phase) {
+		return BackupSnapshot{}, false
+	}
+
+	snapshot := BackupSnapshot{
+		ID:     backup.GetName(),
+		Time:   backup.GetCreationTimestamp().Time,
+		Status: phase,
+		Failed: phase != "Completed",
+		Labels: backup.GetLabels(),
+	}
+	for _, field := range []string{"completionTimestamp", "startTimestamp"} {
+		if ts, ok, _ := unstructured.NestedString(backup.Object, "status", field); ok {
+			if t, err := time.Parse(time.RFC3339, ts); err == nil {
+				snapshot.Time = t
+				break
+			}
+		}
+	}
+	if reason, _, _ := unstructured.NestedString(backup.Object, "status", "failureReason"); reason != "" {
+		snapshot.Messages = append(snapshot.Messages, reason)
+	}
+	if errors, _, _ := unstructured.NestedInt64(backup.Object, "status", "errors"); errors > 0 {
+		snapshot.Messages = append(snapshot.Messages, fmt.Sprintf("%d errors", errors))
+	}
+	if warnings, _, _ := unstructured.NestedInt64(backup.Object, "status", "warnings"); warnings > 0 {
+		snapshot.Messages = append(snapshot.Messages, fmt.Sprintf("%d warnings", warnings))
+	}
+	return snapshot, true
+}
+
+// Verify checks that the backup storage locations are available
+func (r veleroRepository) Verify(ctx *context.Context) error {
+	list, err := r.client.Resource(veleroBackupStorageLocations).Namespace(r.namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	if len(list.Items) == 0 {
+		return fmt.Errorf("no backup storage locations found")
+	}
+	for _, location := range list.Items {
+		if phase, _, _ := unstructured.NestedString(location.Object, "status", "phase"); phase != "Available" {
+			return fmt.Errorf("backup storage location %s is %s", location.GetName(), phase)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/flanksource/canary-checker/pkg/validation"
+	"github.com/flanksource/commons/logger"
+	"github.com/spf13/cobra"
+)
+
+var Lint = &cobra.Command{
+	Use:   "lint <canary.yaml>",
+	Short: "Validate the schedules, expressions and checks of canaries and topologies without running them",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, configFiles []string) {
+		invalid := 0
+		for _, configFile := range configFiles {
+			data, err := os.ReadFile(configFile)
+			if err != nil {
+				logger.Fatalf("Could not read %s: %v", configFile, err)
+			}
+
+			for _, doc := range validation.ValidateYAML(string(data)) {
+				if doc.Valid() {
+					continue
+				}
+				invalid++
+
+				name := configFile
+				if doc.Name != "" {
+					name = fmt.Sprintf("%s (%s/%s)", configFile, doc.Kind, doc.Name)
+				}
+				if doc.ParseError != nil {
+					logger.Errorf("%s: %v", name, doc.ParseError)
+				}
+				for _, err := range doc.Errors {
+					logger.Errorf("%s: %s", name, err)
+				}
+			}
+		}
+
+		if invalid > 0 {
+			logger.Errorf("%d invalid documents found", invalid)
+			os.Exit(1)
+		}
+		logger.Infof("%d files are valid", len(configFiles))
+	},
+}
+
+func init() {
+	Root.AddCommand(Lint)
+}
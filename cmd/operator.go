@@ -1,8 +1,10 @@
 package cmd
 
 import (
+	gocontext "context"
 	"errors"
 	"fmt"
+	"os"
 	"time"
 
 	apicontext "github.com/flanksource/canary-checker/api/context"
@@ -10,14 +12,18 @@ import (
 	"github.com/flanksource/canary-checker/pkg/jobs"
 	canaryJobs "github.com/flanksource/canary-checker/pkg/jobs/canary"
 	"github.com/flanksource/canary-checker/pkg/runner"
+	"github.com/flanksource/canary-checker/pkg/shard"
 	"github.com/flanksource/canary-checker/pkg/utils"
 	"github.com/go-logr/logr"
+	"github.com/samber/lo"
 	gocache "github.com/patrickmn/go-cache"
 
 	canaryv1 "github.com/flanksource/canary-checker/api/v1"
+	"github.com/flanksource/canary-checker/pkg"
 	"github.com/flanksource/canary-checker/pkg/controllers"
 	"github.com/flanksource/canary-checker/pkg/labels"
 	"github.com/flanksource/commons/logger"
+	dutyContext "github.com/flanksource/duty/context"
 	"github.com/flanksource/duty/job"
 	dutyKubernetes "github.com/flanksource/duty/kubernetes"
 	"github.com/flanksource/duty/leader"
@@ -29,12 +35,19 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	ctrlCache "sigs.k8s.io/controller-runtime/pkg/cache"
 	ctrlMetrics "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
 var (
 	webhookPort          int
+	webhookCertDir       string
+	enableDiscovery      bool
+	discoveryTemplate    string
 	k8sLogLevel          int
 	enableLeaderElection bool
+	enableSharding       bool
+	shardNamespace       string
 	Operator             = &cobra.Command{
 		Use:   "operator",
 		Short: "Start the kubernetes operator",
@@ -53,8 +66,13 @@ func init() {
 	Operator.Flags().StringVarP(&runner.WatchNamespace, "namespace", "n", "", "Watch only specified namespace, otherwise watch all")
 	Operator.Flags().BoolVar(&runner.OperatorExecutor, "executor", true, "If false, only serve the UI and sync the configs")
 	Operator.Flags().IntVar(&webhookPort, "webhookPort", 8082, "Port for webhooks ")
+	Operator.Flags().StringVar(&webhookCertDir, "webhook-cert-dir", "", "Directory with the tls.crt and tls.key of the validating webhook, the webhook is disabled when empty")
+	Operator.Flags().BoolVar(&enableDiscovery, "discovery", false, "Generate canaries for Ingresses, HTTPRoutes and annotated Services")
+	Operator.Flags().StringVar(&discoveryTemplate, "discovery-template", "", "Canary to base the discovered canaries on, the first http check is used for every URL")
 	Operator.Flags().IntVar(&k8sLogLevel, "k8s-log-level", -1, "Kubernetes controller log level")
 	Operator.Flags().BoolVar(&enableLeaderElection, "enable-leader-election", false, "Enabling this will ensure there is only one active controller manager")
+	Operator.Flags().BoolVar(&enableSharding, "shard", false, "Distribute the canaries across the replicas of the operator, the replicas are tracked with Lease objects")
+	Operator.Flags().StringVar(&shardNamespace, "shard-namespace", "", "Namespace of the Leases used for sharding, defaults to the watched namespace or the namespace of the pod")
 	// +kubebuilder:scaffold:scheme
 }
 
@@ -81,14 +99,27 @@ func run() error {
 	if enableLeaderElection {
 		job.DisableCronStartOnSchedule()
 
+		var onStoppedLead func()
+		if enableSharding {
+			// every replica runs the canaries it is assigned, only the other jobs are run by the leader
+			canaryJobs.CanaryScheduler.Start()
+			onStoppedLead = canaryJobs.CanaryScheduler.Start
+		}
+
 		go func() {
-			err := leader.Register(ctx, app, runner.WatchNamespace, nil, nil, nil)
+			err := leader.Register(ctx, app, runner.WatchNamespace, nil, onStoppedLead, nil)
 			if err != nil {
 				shutdown.ShutdownAndExit(1, fmt.Sprintf("leader election failed: %v", err))
 			}
 		}()
 	}
 
+	if enableSharding {
+		if err := startSharding(ctx); err != nil {
+			return fmt.Errorf("failed to start sharding: %w", err)
+		}
+	}
+
 	if runner.OperatorExecutor {
 		logger.Infof("Starting executors")
 
@@ -102,10 +133,47 @@ func run() error {
 	return startControllers()
 }
 
+// startSharding registers this replica and rebalances the canaries whenever a replica joins or leaves
+func startSharding(ctx dutyContext.Context) error {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return err
+	}
+	shard.Identity = hostname
+
+	membership := shard.Membership{
+		Client:    ctx.Kubernetes(),
+		Namespace: lo.CoalesceOrEmpty(shardNamespace, runner.WatchNamespace, os.Getenv("POD_NAMESPACE"), "default"),
+		Group:     app,
+	}
+	// the initial members are known before the canaries are first synced
+	if err := membership.Sync(ctx); err != nil {
+		return err
+	}
+	membership.OnChange = func(members []string) {
+		go canaryJobs.SyncCanaryJobs.Run()
+	}
+
+	shardCtx, cancel := gocontext.WithCancel(ctx)
+	shutdown.AddHook(func() {
+		cancel()
+		// give the replica time to delete its lease
+		time.Sleep(time.Second)
+	})
+	go func() {
+		if err := membership.Start(shardCtx); err != nil {
+			logger.Errorf("shard membership stopped: %v", err)
+		}
+	}()
+	logger.Infof("Sharding canaries as %s across %v", shard.Identity, shard.Members())
+	return nil
+}
+
 func startControllers() error {
 	scheme := runtime.NewScheme()
 	_ = clientgoscheme.AddToScheme(scheme)
 	_ = canaryv1.AddToScheme(scheme)
+	_ = gatewayv1.Install(scheme)
 
 	logger := logger.GetLogger("operator")
 	logger.SetLogLevel(k8sLogLevel)
@@ -115,7 +183,8 @@ func startControllers() error {
 
 	managerOpt := ctrl.Options{
 		Scheme:                  scheme,
-		LeaderElection:          enableLeaderElection,
+		// with sharding every replica reconciles canaries to schedule the ones it is assigned
+		LeaderElection:          enableLeaderElection && !enableSharding,
 		LeaderElectionNamespace: runner.WatchNamespace,
 		LeaderElectionID:        "fa62cd4d.flanksource.com",
 		Metrics: ctrlMetrics.Options{
@@ -126,6 +195,13 @@ func startControllers() error {
 		},
 	}
 
+	if webhookCertDir != "" {
+		managerOpt.WebhookServer = webhook.NewServer(webhook.Options{
+			Port:    webhookPort,
+			CertDir: webhookCertDir,
+		})
+	}
+
 	if runner.WatchNamespace != "" {
 		if managerOpt.Cache.DefaultNamespaces == nil {
 			managerOpt.Cache.DefaultNamespaces = make(map[string]ctrlCache.Config)
@@ -181,6 +257,34 @@ func startControllers() error {
 		return fmt.Errorf("unable to create topology controller: %v", err)
 	}
 
+	if enableDiscovery {
+		template := controllers.DefaultDiscoveryTemplate
+		if discoveryTemplate != "" {
+			canaries, err := pkg.ParseConfig(discoveryTemplate, "")
+			if err != nil || len(canaries) == 0 {
+				return fmt.Errorf("invalid discovery template %s: %v", discoveryTemplate, err)
+			}
+			template = canaries[0]
+		}
+		discoveryReconciler := &controllers.DiscoveryReconciler{
+			Client:   mgr.GetClient(),
+			Log:      ctrl.Log.WithName("controllers").WithName("discovery"),
+			Scheme:   mgr.GetScheme(),
+			Template: template,
+		}
+		if err = discoveryReconciler.SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "Discovery")
+			return fmt.Errorf("unable to create discovery controller: %v", err)
+		}
+	}
+
+	if webhookCertDir != "" {
+		if err = controllers.SetupWebhooksWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhooks")
+			return fmt.Errorf("unable to create webhooks: %v", err)
+		}
+	}
+
 	setupLog.Info("starting manager")
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
 		setupLog.Error(err, "problem running manager")
@@ -3,9 +3,11 @@ package output
 import (
 	"strconv"
 
+	"github.com/flanksource/canary-checker/checks"
 	"github.com/flanksource/canary-checker/pkg"
 	"github.com/flanksource/commons/console"
 	"github.com/flanksource/commons/logger"
+	"github.com/joshdk/go-junit"
 )
 
 func GetJunitReport(results []*pkg.CheckResult) string {
@@ -14,6 +16,26 @@ func GetJunitReport(results []*pkg.CheckResult) string {
 	var totalTime int64
 	for _, result := range results {
 		totalTime += result.Duration
+		if suites, ok := result.Detail.(checks.JunitTestSuites); ok && len(suites.Suites) > 0 {
+			for _, suite := range suites.Suites {
+				for _, test := range suite.Tests {
+					testCase := console.JUnitTestCase{
+						Classname: test.Classname,
+						Name:      test.Name,
+						Time:      strconv.FormatFloat(test.Duration, 'f', 3, 64),
+					}
+					switch test.Status {
+					case junit.StatusFailed, junit.StatusError:
+						failed++
+						testCase.Failure = &console.JUnitFailure{Message: test.Message}
+					case junit.StatusSkipped:
+						testCase.SkipMessage = &console.JUnitSkipMessage{Message: test.Message}
+					}
+					testCases = append(testCases, testCase)
+				}
+			}
+			continue
+		}
 		testCase := console.JUnitTestCase{
 			Classname: result.Check.GetType(),
 			Name:      result.Check.GetDescription(),
@@ -28,7 +50,7 @@ func GetJunitReport(results []*pkg.CheckResult) string {
 		testCases = append(testCases, testCase)
 	}
 	testSuite := console.JUnitTestSuite{
-		Tests:     len(results),
+		Tests:     len(testCases),
 		Failures:  failed,
 		Time:      strconv.Itoa(int(totalTime)),
 		Name:      "canary-checker-run",
@@ -164,7 +164,7 @@ func ServerFlags(flags *pflag.FlagSet) {
 	flags.StringSliceVar(&v1.AdditionalCheckMetricLabels,
 		"metric-labels-allowlist",
 		nil,
-		"comma-separated list of additional check label keys that should be included in the check metrics",
+		"comma-separated list of additional check label keys that should be included in the check metrics, the cluster label is always included",
 	)
 
 	flags.StringVar(
@@ -253,6 +253,10 @@ func FromV1(canary v1.Canary, check external.Check, statuses ...CheckStatus) Che
 		Canary:      &canary,
 	}
 
+	if cluster := v1.GetCluster(check); cluster != "" {
+		c.Labels[v1.ClusterLabel] = cluster
+	}
+
 	if _, exists := c.Labels["transformed"]; exists {
 		c.Transformed = true
 		delete(c.Labels, "transformed")
@@ -4,15 +4,37 @@ import (
 	"net/http"
 
 	"github.com/flanksource/canary-checker/pkg/cache"
+	"github.com/flanksource/canary-checker/pkg/db"
+	"github.com/flanksource/canary-checker/pkg/runner"
+	"github.com/flanksource/canary-checker/pkg/shard"
 	"github.com/flanksource/commons/logger"
+	"github.com/flanksource/duty/context"
 	"github.com/labstack/echo/v4"
 	"github.com/pkg/errors"
 )
 
+// ShardDetails shows the replica each canary is scheduled on
+type ShardDetails struct {
+	Replica  string             `json:"replica,omitempty"`
+	Replicas []string           `json:"replicas,omitempty"`
+	Canaries []CanaryAssignment `json:"canaries"`
+}
+
+type CanaryAssignment struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Replica   string `json:"replica,omitempty"`
+}
+
+// DetailsHandler returns the details of a check status, or the replica that owns each canary when no check status is requested
 func DetailsHandler(c echo.Context) error {
 	queryParams := c.Request().URL.Query()
 	key := queryParams.Get("key")
 	time := queryParams.Get("time")
+	if key == "" && time == "" {
+		return shardDetails(c)
+	}
 	if key == "" || time == "" {
 		logger.Errorf("key and time are required parameters")
 		return errorResponse(c, errors.New("key and time are required parameters"), http.StatusBadRequest)
@@ -20,3 +42,22 @@ func DetailsHandler(c echo.Context) error {
 	detail := cache.PostgresCache.GetDetails(key, time)
 	return c.JSON(http.StatusOK, detail)
 }
+
+func shardDetails(c echo.Context) error {
+	ctx := c.Request().Context().(context.Context)
+	canaries, err := db.GetAllCanariesForSync(ctx, runner.WatchNamespace)
+	if err != nil {
+		return errorResponse(c, err, http.StatusInternalServerError)
+	}
+
+	details := ShardDetails{Replica: shard.Identity, Replicas: shard.Members(), Canaries: []CanaryAssignment{}}
+	for _, canary := range canaries {
+		details.Canaries = append(details.Canaries, CanaryAssignment{
+			ID:        canary.ID.String(),
+			Name:      canary.Name,
+			Namespace: canary.Namespace,
+			Replica:   shard.Owner(canary.ID.String()),
+		})
+	}
+	return c.JSON(http.StatusOK, details)
+}
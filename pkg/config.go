@@ -1,6 +1,7 @@
 package pkg
 
 import (
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
@@ -9,6 +10,7 @@ import (
 
 	v1 "github.com/flanksource/canary-checker/api/v1"
 	"github.com/flanksource/gomplate/v3"
+	"github.com/samber/lo"
 
 	"gopkg.in/flanksource/yaml.v3"
 	yamlutil "k8s.io/apimachinery/pkg/util/yaml"
@@ -45,6 +47,89 @@ func template(content string, data map[string]any) (string, error) {
 	})
 }
 
+// RenderCanaryTemplate returns the spec of a canary that references the template. The parameters are substituted
+// into the string values of the template, the checks of the canary are appended to the checks of the template
+// and any other field set by the canary overrides the template.
+func RenderCanaryTemplate(tmpl v1.CanaryTemplate, canary v1.Canary) (v1.CanarySpec, error) {
+	var spec v1.CanarySpec
+	values, err := tmpl.Values(canary.Spec.Values)
+	if err != nil {
+		return spec, err
+	}
+
+	rendered := make(map[string]any)
+	if len(tmpl.Spec.Template) > 0 {
+		if err := json.Unmarshal(tmpl.Spec.Template, &rendered); err != nil {
+			return spec, fmt.Errorf("invalid template %s/%s: %w", tmpl.Namespace, tmpl.Name, err)
+		}
+	}
+	if _, err := renderTemplateValue(values, rendered); err != nil {
+		return spec, fmt.Errorf("error rendering template %s/%s: %w", tmpl.Namespace, tmpl.Name, err)
+	}
+
+	overrides, err := toJSONMap(canary.Spec)
+	if err != nil {
+		return spec, err
+	}
+	delete(overrides, "templateRef")
+	delete(overrides, "values")
+	for key, value := range overrides {
+		existing, isList := rendered[key].([]any)
+		if extra, ok := value.([]any); ok && isList {
+			rendered[key] = append(existing, extra...)
+		} else {
+			rendered[key] = value
+		}
+	}
+
+	data, err := json.Marshal(rendered)
+	if err != nil {
+		return spec, err
+	}
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return spec, fmt.Errorf("template %s/%s did not render a valid canary spec: %w", tmpl.Namespace, tmpl.Name, err)
+	}
+	return spec, nil
+}
+
+// renderTemplateValue templates the strings in a decoded json value, only strings with the [[ ]] delimiters are
+// templated so that the templates of the checks are left untouched
+func renderTemplateValue(values map[string]any, value any) (any, error) {
+	switch v := value.(type) {
+	case string:
+		if !strings.Contains(v, "[[") {
+			return v, nil
+		}
+		return gomplate.RunTemplate(values, gomplate.Template{Template: v, LeftDelim: "[[", RightDelim: "]]"})
+	case []any:
+		for i := range v {
+			out, err := renderTemplateValue(values, v[i])
+			if err != nil {
+				return nil, err
+			}
+			v[i] = out
+		}
+	case map[string]any:
+		for key := range v {
+			out, err := renderTemplateValue(values, v[key])
+			if err != nil {
+				return nil, err
+			}
+			v[key] = out
+		}
+	}
+	return value, nil
+}
+
+func toJSONMap(v any) (map[string]any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]any
+	return m, json.Unmarshal(data, &m)
+}
+
 func ParseTopology(configFile, datafile string) ([]*Topology, error) {
 	configs, err := readFile(configFile)
 	if err != nil {
@@ -113,6 +198,7 @@ func ParseConfig(configfile string, datafile string) ([]v1.Canary, error) {
 	}
 
 	var canaries []v1.Canary
+	var templates []v1.CanaryTemplate
 	re := regexp.MustCompile(`(?m)^---\n`)
 	for _, chunk := range re.Split(configs, -1) {
 		if strings.TrimSpace(chunk) == "" {
@@ -125,7 +211,16 @@ func ParseConfig(configfile string, datafile string) ([]v1.Canary, error) {
 			return nil, err
 		}
 
-		if len(config.Spec.GetAllChecks()) == 0 && config.Spec.Webhook == nil {
+		if config.Kind == "CanaryTemplate" {
+			tmpl := v1.CanaryTemplate{}
+			if err := yamlutil.NewYAMLOrJSONDecoder(strings.NewReader(chunk), 1024).Decode(&tmpl); err != nil {
+				return nil, err
+			}
+			templates = append(templates, tmpl)
+			continue
+		}
+
+		if len(config.Spec.GetAllChecks()) == 0 && config.Spec.Webhook == nil && config.Spec.TemplateRef == nil {
 			// try just the specs:
 			spec := v1.CanarySpec{}
 
@@ -137,6 +232,25 @@ func ParseConfig(configfile string, datafile string) ([]v1.Canary, error) {
 		canaries = append(canaries, config)
 	}
 
+	// outside the operator templates can only be referenced from the same file
+	for i, canary := range canaries {
+		ref := canary.Spec.TemplateRef
+		if ref == nil {
+			continue
+		}
+		tmpl, ok := lo.Find(templates, func(t v1.CanaryTemplate) bool {
+			return t.Name == ref.Name && t.Namespace == ref.GetNamespace(canary.Namespace)
+		})
+		if !ok {
+			return nil, fmt.Errorf("canary %s references template %s which is not defined in %s", canary.Name, ref.Name, configfile)
+		}
+		spec, err := RenderCanaryTemplate(tmpl, canary)
+		if err != nil {
+			return nil, err
+		}
+		canaries[i].Spec = spec
+	}
+
 	return canaries, nil
 }
 
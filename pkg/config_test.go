@@ -0,0 +1,123 @@
+package pkg
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	v1 "github.com/flanksource/canary-checker/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRenderCanaryTemplate(t *testing.T) {
+	tmpl := v1.CanaryTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "http", Namespace: "default"},
+		Spec: v1.CanaryTemplateSpec{
+			Parameters: []v1.CanaryTemplateParameter{
+				{Name: "host", Required: true},
+				{Name: "path", Default: "/health"},
+			},
+			Template: []byte(`{
+				"schedule": "@every 5m",
+				"http": [{"name": "[[ .host ]]", "url": "https://[[ .host ]][[ .path ]]", "responseCodes": [200], "display": {"template": "{{ .code }}"}}]
+			}`),
+		},
+	}
+
+	tests := []struct {
+		name   string
+		spec   v1.CanarySpec
+		err    string
+		verify func(t *testing.T, spec v1.CanarySpec)
+	}{
+		{
+			name: "defaults",
+			spec: v1.CanarySpec{Values: map[string]string{"host": "example.com"}},
+			verify: func(t *testing.T, spec v1.CanarySpec) {
+				if spec.Schedule != "@every 5m" || len(spec.HTTP) != 1 {
+					t.Fatalf("unexpected spec: %+v", spec)
+				}
+				if spec.HTTP[0].Name != "example.com" || spec.HTTP[0].URL != "https://example.com/health" {
+					t.Errorf("parameters were not substituted: %s %s", spec.HTTP[0].Name, spec.HTTP[0].URL)
+				}
+				if spec.HTTP[0].Display.Template != "{{ .code }}" {
+					t.Errorf("check template was modified: %s", spec.HTTP[0].Display.Template)
+				}
+			},
+		},
+		{
+			name: "canary overrides and extends the template",
+			spec: v1.CanarySpec{
+				Schedule:    "@every 1m",
+				TemplateRef: &v1.CanaryTemplateRef{Name: "http"},
+				Values:      map[string]string{"host": "example.com", "path": "/ready"},
+				HTTP:        []v1.HTTPCheck{{Connection: v1.Connection{URL: "https://example.org"}}},
+			},
+			verify: func(t *testing.T, spec v1.CanarySpec) {
+				if spec.Schedule != "@every 1m" {
+					t.Errorf("expected the schedule of the canary, got %s", spec.Schedule)
+				}
+				if len(spec.HTTP) != 2 || spec.HTTP[0].URL != "https://example.com/ready" || spec.HTTP[1].URL != "https://example.org" {
+					t.Errorf("unexpected checks: %+v", spec.HTTP)
+				}
+				if spec.TemplateRef != nil || spec.Values != nil {
+					t.Errorf("the rendered spec should not reference the template")
+				}
+			},
+		},
+		{
+			name: "missing required parameter",
+			spec: v1.CanarySpec{},
+			err:  "parameter host is required",
+		},
+		{
+			name: "unknown parameter",
+			spec: v1.CanarySpec{Values: map[string]string{"host": "example.com", "port": "80"}},
+			err:  "has no parameter port",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, err := RenderCanaryTemplate(tmpl, v1.Canary{Spec: tt.spec})
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("expected error containing %q, got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.verify(t, spec)
+		})
+	}
+}
+
+func TestParseConfigRendersTemplates(t *testing.T) {
+	fixture, err := os.ReadFile("../fixtures/minimal/canary_template.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	canaries, err := ParseConfig("../fixtures/minimal/canary_template.yaml", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(canaries) != 1 {
+		t.Fatalf("expected only the canary to be returned, got %d", len(canaries))
+	}
+	spec := canaries[0].Spec
+	if len(spec.HTTP) != 1 || spec.HTTP[0].URL != "https://httpbin.demo.aws.flanksource.com/status/200" || len(spec.DNS) != 1 {
+		t.Errorf("canary was not rendered from the template: %+v", spec)
+	}
+
+	// the canary without its template
+	file := filepath.Join(t.TempDir(), "canary.yaml")
+	if err := os.WriteFile(file, []byte(strings.SplitN(string(fixture), "---\n", 2)[1]), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseConfig(file, ""); err == nil || !strings.Contains(err.Error(), "template http-service which is not defined") {
+		t.Errorf("expected an error for the missing template, got %v", err)
+	}
+}
@@ -43,6 +43,8 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 var json = jsontime.ConfigWithCustomTimeFormat
@@ -63,8 +65,12 @@ type CanaryReconciler struct {
 
 const FinalizerName = "canary.canaries.flanksource.com"
 
+// templateRefIndex indexes canaries by the namespace/name of the template they reference
+const templateRefIndex = "spec.templateRef"
+
 // +kubebuilder:rbac:groups=canaries.flanksource.com,resources=canaries,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=canaries.flanksource.com,resources=canaries/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=canaries.flanksource.com,resources=canarytemplates,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=pods/exec,verbs=*
 // +kubebuilder:rbac:groups="",resources=pods/logs,verbs=*
 func (r *CanaryReconciler) Reconcile(parentCtx gocontext.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -102,8 +108,21 @@ func (r *CanaryReconciler) Reconcile(parentCtx gocontext.Context, req ctrl.Reque
 		return ctrl.Result{}, r.Update(ctx, canary)
 	}
 
-	dbCanary, err := r.updateCanaryInDB(ctx, canary)
+	// canaries rendered from a template are persisted with the rendered spec, the resource itself is left as is
+	rendered := canary
+	if canary.Spec.TemplateRef != nil {
+		rendered, err = r.renderTemplate(ctx, canary)
+		if err != nil {
+			logger.Error(err, "failed to render canary template")
+			r.Events.Event(canary, corev1.EventTypeWarning, "TemplateFailed", err.Error())
+			r.setConditions(ctx, canary, v1.NewCondition(v1.ConditionReady, false, "TemplateFailed", err.Error()))
+			return ctrl.Result{Requeue: true, RequeueAfter: 2 * time.Minute}, nil
+		}
+	}
+
+	dbCanary, err := r.updateCanaryInDB(ctx, rendered)
 	if err != nil {
+		r.setConditions(ctx, canary, v1.NewCondition(v1.ConditionReady, false, "PersistFailed", err.Error()))
 		return ctrl.Result{Requeue: true}, err
 	}
 
@@ -140,8 +159,8 @@ func (r *CanaryReconciler) Reconcile(parentCtx gocontext.Context, req ctrl.Reque
 		}
 	}
 
-	if canary.Spec.Replicas != nil && canaryForStatus.Status.Replicas != *canary.Spec.Replicas {
-		if *canary.Spec.Replicas == 0 {
+	if rendered.Spec.Replicas != nil && canaryForStatus.Status.Replicas != *rendered.Spec.Replicas {
+		if *rendered.Spec.Replicas == 0 {
 			canaryJobs.Unschedule(canary.GetPersistedID())
 		} else {
 			if err := canaryJobs.SyncCanaryJob(ctx, *dbCanary); err != nil {
@@ -149,11 +168,19 @@ func (r *CanaryReconciler) Reconcile(parentCtx gocontext.Context, req ctrl.Reque
 			}
 		}
 
-		canaryForStatus.Status.Replicas = *canary.Spec.Replicas
+		canaryForStatus.Status.Replicas = *rendered.Spec.Replicas
 	}
 
 	canaryForStatus.Status.Checks = dbCanary.Checks
 	canaryForStatus.Status.ObservedGeneration = canary.Generation
+	suspended := v1.NewCondition(v1.ConditionSuspended, false, "Scheduled", "")
+	if runner.IsCanarySuspended(*rendered) {
+		suspended = v1.NewCondition(v1.ConditionSuspended, true, "Suspended", "the canary has 0 replicas or is annotated with suspend")
+	}
+	for _, condition := range []v1.Condition{v1.NewCondition(v1.ConditionReady, true, "Scheduled", ""), suspended} {
+		condition.ObservedGeneration = canary.Generation
+		v1.SetCondition(&canaryForStatus.Status.Conditions, condition)
+	}
 	if err = r.Status().Patch(ctx, &canaryForStatus, patch); err != nil {
 		logger.Error(err, "failed to update status for canary")
 		return ctrl.Result{Requeue: true, RequeueAfter: 2 * time.Minute}, err
@@ -164,11 +191,66 @@ func (r *CanaryReconciler) Reconcile(parentCtx gocontext.Context, req ctrl.Reque
 
 func (r *CanaryReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	r.Events = mgr.GetEventRecorderFor("canary-checker")
+	if err := mgr.GetFieldIndexer().IndexField(gocontext.Background(), &v1.Canary{}, templateRefIndex, func(obj client.Object) []string {
+		canary := obj.(*v1.Canary)
+		if canary.Spec.TemplateRef == nil {
+			return nil
+		}
+		return []string{canary.Spec.TemplateRef.GetNamespace(canary.Namespace) + "/" + canary.Spec.TemplateRef.Name}
+	}); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1.Canary{}).
+		Watches(&v1.CanaryTemplate{}, handler.EnqueueRequestsFromMapFunc(r.canariesForTemplate)).
 		Complete(r)
 }
 
+// setConditions updates the conditions of the canary when reconciling fails before the status is updated
+func (r *CanaryReconciler) setConditions(ctx gocontext.Context, canary *v1.Canary, conditions ...v1.Condition) {
+	patch := client.MergeFrom(canary.DeepCopy())
+	for _, condition := range conditions {
+		condition.ObservedGeneration = canary.Generation
+		v1.SetCondition(&canary.Status.Conditions, condition)
+	}
+	if err := r.Status().Patch(ctx, canary, patch); err != nil {
+		r.Log.Error(err, "failed to update conditions", "canary", canary.Name)
+	}
+}
+
+// renderTemplate returns a copy of the canary with the spec rendered from the template it references
+func (r *CanaryReconciler) renderTemplate(ctx gocontext.Context, canary *v1.Canary) (*v1.Canary, error) {
+	var tmpl v1.CanaryTemplate
+	key := client.ObjectKey{Namespace: canary.Spec.TemplateRef.GetNamespace(canary.Namespace), Name: canary.Spec.TemplateRef.Name}
+	if err := r.Get(ctx, key, &tmpl); err != nil {
+		return nil, fmt.Errorf("failed to get canary template %s: %w", key, err)
+	}
+
+	spec, err := pkg.RenderCanaryTemplate(tmpl, *canary)
+	if err != nil {
+		return nil, err
+	}
+	rendered := canary.DeepCopy()
+	rendered.Spec = spec
+	return rendered, nil
+}
+
+// canariesForTemplate re-renders every canary that references the template when it changes
+func (r *CanaryReconciler) canariesForTemplate(ctx gocontext.Context, obj client.Object) []reconcile.Request {
+	var canaries v1.CanaryList
+	if err := r.List(ctx, &canaries, client.MatchingFields{templateRefIndex: obj.GetNamespace() + "/" + obj.GetName()}); err != nil {
+		r.Log.Error(err, "failed to list canaries referencing template", "template", obj.GetNamespace()+"/"+obj.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, canary := range canaries.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&canary)})
+	}
+	return requests
+}
+
 func (r *CanaryReconciler) persistAndCacheCanary(ctx dutyContext.Context, canary *v1.Canary) (*pkg.Canary, error) {
 	dbCanary, changed, err := db.PersistCanary(ctx, *canary, "kubernetes/"+canary.GetPersistedID())
 	if err != nil {
@@ -255,6 +337,10 @@ func (r *CanaryReconciler) Report() {
 		canary.Status.LastCheck = &metav1.Time{Time: time.Now()}
 		canary.Status.ChecksStatus = payload.CheckStatus
 		canary.Status.Status = &payload.Status
+		for _, condition := range payload.Conditions {
+			condition.ObservedGeneration = canary.Generation
+			v1.SetCondition(&canary.Status.Conditions, condition)
+		}
 
 		for _, eventMsg := range payload.FailEvents {
 			r.Events.Event(&canary, corev1.EventTypeWarning, "Failed", eventMsg)
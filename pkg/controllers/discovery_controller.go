@@ -0,0 +1,295 @@
+package controllers
+
+import (
+	gocontext "context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	v1 "github.com/flanksource/canary-checker/api/v1"
+	"github.com/flanksource/canary-checker/pkg/runner"
+	"github.com/go-logr/logr"
+	"github.com/samber/lo"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const (
+	// DiscoveryAnnotation disables discovery of an Ingress or HTTPRoute when "false", Services are only discovered when "true"
+	DiscoveryAnnotation = "canaries.flanksource.com/discovery"
+	// DiscoveryPathAnnotation overrides the path that is checked
+	DiscoveryPathAnnotation = "canaries.flanksource.com/path"
+	// DiscoveryResponseCodesAnnotation is a comma separated list of the expected response codes
+	DiscoveryResponseCodesAnnotation = "canaries.flanksource.com/response-codes"
+	// DiscoveryMaxSSLExpiryAnnotation is the minimum number of days until the certificate expires
+	DiscoveryMaxSSLExpiryAnnotation = "canaries.flanksource.com/max-ssl-expiry"
+	// DiscoverySchemeAnnotation overrides the scheme, HTTPRoutes default to https and Services to http
+	DiscoverySchemeAnnotation = "canaries.flanksource.com/scheme"
+	// DiscoveryPortAnnotation is the port of a Service to check, defaults to the first port
+	DiscoveryPortAnnotation = "canaries.flanksource.com/port"
+	// DiscoveredFromLabel is set on generated canaries to the kind of the source
+	DiscoveredFromLabel = "canaries.flanksource.com/discovered-from"
+)
+
+// DefaultDiscoveryTemplate is used when no template is configured
+var DefaultDiscoveryTemplate = v1.Canary{
+	Spec: v1.CanarySpec{
+		Schedule: "@every 5m",
+		HTTP:     []v1.HTTPCheck{{ResponseCodes: []int{200}, MaxSSLExpiry: 7}},
+	},
+}
+
+// DiscoveryReconciler generates canaries that check the URLs of Ingresses, HTTPRoutes and annotated Services.
+// The generated canaries are owned by their source, so they are deleted with it.
+type DiscoveryReconciler struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+	// Template is the canary the generated canaries are based on, the first http check is used
+	// as the prototype of the check generated for each URL
+	Template v1.Canary
+}
+
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch
+func (r *DiscoveryReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := ctrl.NewControllerManagedBy(mgr).
+		Named("discovery-ingress").
+		For(&networkingv1.Ingress{}).
+		Owns(&v1.Canary{}).
+		Complete(discover(r, &networkingv1.Ingress{}, ingressURLs)); err != nil {
+		return err
+	}
+
+	if err := ctrl.NewControllerManagedBy(mgr).
+		Named("discovery-service").
+		For(&corev1.Service{}).
+		Owns(&v1.Canary{}).
+		Complete(discover(r, &corev1.Service{}, serviceURLs)); err != nil {
+		return err
+	}
+
+	// the gateway api is optional
+	if _, err := mgr.GetRESTMapper().RESTMapping(schema.GroupKind{Group: gatewayv1.GroupName, Kind: "HTTPRoute"}, gatewayv1.GroupVersion.Version); err != nil {
+		r.Log.Info("HTTPRoutes will not be discovered", "reason", err.Error())
+		return nil
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("discovery-httproute").
+		For(&gatewayv1.HTTPRoute{}).
+		Owns(&v1.Canary{}).
+		Complete(discover(r, &gatewayv1.HTTPRoute{}, httpRouteURLs))
+}
+
+func discover[T client.Object](r *DiscoveryReconciler, obj T, urls func(T) []string) reconcile.Func {
+	return func(ctx gocontext.Context, req ctrl.Request) (ctrl.Result, error) {
+		source := obj.DeepCopyObject().(T)
+		if err := r.Get(ctx, req.NamespacedName, source); err != nil {
+			// owned canaries are garbage collected when the source is deleted
+			return ctrl.Result{}, client.IgnoreNotFound(err)
+		}
+		if !source.GetDeletionTimestamp().IsZero() {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, r.sync(ctx, source, urls(source))
+	}
+}
+
+// sync creates or updates the canary generated from the source, or deletes it when there is nothing to check
+func (r *DiscoveryReconciler) sync(ctx gocontext.Context, source client.Object, urls []string) error {
+	gvk, err := apiutil.GVKForObject(source, r.Scheme)
+	if err != nil {
+		return err
+	}
+	canary := r.generateCanary(source, gvk.Kind, urls)
+	logger := r.Log.WithValues("source", fmt.Sprintf("%s/%s/%s", gvk.Kind, source.GetNamespace(), source.GetName()))
+
+	existing := &v1.Canary{}
+	err = r.Get(ctx, client.ObjectKeyFromObject(canary), existing)
+	if client.IgnoreNotFound(err) != nil {
+		return err
+	}
+	exists := err == nil
+	if exists && !metav1.IsControlledBy(existing, source) {
+		logger.Info("canary already exists and was not generated from the source", "canary", canary.Name)
+		return nil
+	}
+
+	if len(canary.Spec.HTTP) == 0 || runner.IsCanaryIgnored(&canary.ObjectMeta) {
+		if exists {
+			logger.Info("deleting generated canary", "canary", canary.Name)
+			return client.IgnoreNotFound(r.Delete(ctx, existing))
+		}
+		return nil
+	}
+
+	if !exists {
+		existing = &v1.Canary{ObjectMeta: metav1.ObjectMeta{Name: canary.Name, Namespace: canary.Namespace}}
+	}
+	result, err := controllerutil.CreateOrUpdate(ctx, r.Client, existing, func() error {
+		existing.Labels = canary.Labels
+		existing.Annotations = canary.Annotations
+		existing.Spec = canary.Spec
+		return controllerutil.SetControllerReference(source, existing, r.Scheme)
+	})
+	if err != nil {
+		return err
+	}
+	if result != controllerutil.OperationResultNone {
+		logger.Info("synced generated canary", "canary", canary.Name, "operation", result)
+	}
+	return nil
+}
+
+// generateCanary returns the canary that checks the urls of the source, it has no checks when there are no urls
+func (r *DiscoveryReconciler) generateCanary(source client.Object, kind string, urls []string) *v1.Canary {
+	template := r.Template.DeepCopy()
+	canary := &v1.Canary{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        strings.ToLower(kind) + "-" + source.GetName(),
+			Namespace:   source.GetNamespace(),
+			Labels:      lo.Assign(source.GetLabels(), template.Labels, map[string]string{DiscoveredFromLabel: strings.ToLower(kind)}),
+			Annotations: template.Annotations,
+		},
+		Spec: template.Spec,
+	}
+
+	var prototype v1.HTTPCheck
+	if len(template.Spec.HTTP) > 0 {
+		prototype = template.Spec.HTTP[0]
+	}
+	annotations := source.GetAnnotations()
+	if codes := annotations[DiscoveryResponseCodesAnnotation]; codes != "" {
+		prototype.ResponseCodes = nil
+		for _, code := range strings.Split(codes, ",") {
+			if c, err := strconv.Atoi(strings.TrimSpace(code)); err == nil {
+				prototype.ResponseCodes = append(prototype.ResponseCodes, c)
+			}
+		}
+	}
+	if days, err := strconv.Atoi(annotations[DiscoveryMaxSSLExpiryAnnotation]); err == nil {
+		prototype.MaxSSLExpiry = days
+	}
+
+	canary.Spec.HTTP = nil
+	for _, u := range urls {
+		check := *prototype.DeepCopy()
+		check.Name = u
+		check.URL = u
+		if !strings.HasPrefix(u, "https://") {
+			check.MaxSSLExpiry = 0
+		}
+		canary.Spec.HTTP = append(canary.Spec.HTTP, check)
+	}
+	return canary
+}
+
+func ingressURLs(ingress *networkingv1.Ingress) []string {
+	if ingress.Annotations[DiscoveryAnnotation] == "false" {
+		return nil
+	}
+
+	tlsHosts := make(map[string]bool)
+	for _, tls := range ingress.Spec.TLS {
+		for _, host := range tls.Hosts {
+			tlsHosts[host] = true
+		}
+	}
+
+	var urls []string
+	for _, rule := range ingress.Spec.Rules {
+		if rule.Host == "" || strings.Contains(rule.Host, "*") {
+			continue
+		}
+		scheme := lo.Ternary(tlsHosts[rule.Host], "https", "http")
+		paths := []string{"/"}
+		if rule.HTTP != nil && len(rule.HTTP.Paths) > 0 {
+			paths = lo.Map(rule.HTTP.Paths, func(p networkingv1.HTTPIngressPath, _ int) string { return p.Path })
+		}
+		for _, path := range paths {
+			urls = append(urls, discoveredURL(ingress, scheme, rule.Host, path))
+		}
+	}
+	return uniqueURLs(urls)
+}
+
+func httpRouteURLs(route *gatewayv1.HTTPRoute) []string {
+	if route.Annotations[DiscoveryAnnotation] == "false" {
+		return nil
+	}
+
+	var paths []string
+	for _, rule := range route.Spec.Rules {
+		for _, match := range rule.Matches {
+			if match.Path == nil || match.Path.Value == nil {
+				continue
+			}
+			if match.Path.Type != nil && *match.Path.Type == gatewayv1.PathMatchRegularExpression {
+				continue
+			}
+			paths = append(paths, *match.Path.Value)
+		}
+	}
+	if len(paths) == 0 {
+		paths = []string{"/"}
+	}
+
+	var urls []string
+	for _, hostname := range route.Spec.Hostnames {
+		if strings.Contains(string(hostname), "*") {
+			continue
+		}
+		for _, path := range paths {
+			urls = append(urls, discoveredURL(route, "https", string(hostname), path))
+		}
+	}
+	return uniqueURLs(urls)
+}
+
+func serviceURLs(svc *corev1.Service) []string {
+	if svc.Annotations[DiscoveryAnnotation] != "true" || len(svc.Spec.Ports) == 0 {
+		return nil
+	}
+	port := svc.Spec.Ports[0].Port
+	if p, err := strconv.Atoi(svc.Annotations[DiscoveryPortAnnotation]); err == nil {
+		port = int32(p)
+	}
+	host := fmt.Sprintf("%s.%s.svc.cluster.local:%d", svc.Name, svc.Namespace, port)
+	return []string{discoveredURL(svc, "http", host, "/")}
+}
+
+// discoveredURL returns the url of the host and path, the annotations of the source override the scheme and path.
+// Paths that are regular expressions are checked at the longest literal prefix, e.g. /api(/|$)(.*) at /api
+func discoveredURL(source client.Object, scheme, host, path string) string {
+	annotations := source.GetAnnotations()
+	scheme = lo.CoalesceOrEmpty(annotations[DiscoverySchemeAnnotation], scheme)
+	path = lo.CoalesceOrEmpty(annotations[DiscoveryPathAnnotation], path, "/")
+	if i := strings.IndexAny(path, "*()[]^$?+|\\"); i >= 0 {
+		prefix := strings.TrimSuffix(path[:i], ".")
+		if path[i] != '(' {
+			// the regular expression is part of the last segment
+			prefix = prefix[:strings.LastIndex(prefix, "/")+1]
+		}
+		path = lo.CoalesceOrEmpty(prefix, "/")
+	}
+	u := url.URL{Scheme: scheme, Host: host, Path: path}
+	return u.String()
+}
+
+func uniqueURLs(urls []string) []string {
+	urls = lo.Uniq(urls)
+	sort.Strings(urls)
+	return urls
+}
@@ -0,0 +1,111 @@
+package controllers
+
+import (
+	gocontext "context"
+	"testing"
+
+	v1 "github.com/flanksource/canary-checker/api/v1"
+	"github.com/flanksource/canary-checker/pkg/runner"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	"github.com/samber/lo"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func newTestIngress(annotations map[string]string) *networkingv1.Ingress {
+	return &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "apps", UID: "ingress-uid", Annotations: annotations, Labels: map[string]string{"team": "web"}},
+		Spec: networkingv1.IngressSpec{
+			TLS: []networkingv1.IngressTLS{{Hosts: []string{"web.example.com"}}},
+			Rules: []networkingv1.IngressRule{
+				{Host: "web.example.com", IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{
+					Paths: []networkingv1.HTTPIngressPath{{Path: "/"}, {Path: "/api(/|$)(.*)"}},
+				}}},
+				{Host: "internal.example.com"},
+				{Host: "*.example.com"},
+			},
+		},
+	}
+}
+
+func TestDiscoveryURLs(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(ingressURLs(newTestIngress(nil))).To(Equal([]string{
+		"http://internal.example.com/",
+		"https://web.example.com/",
+		"https://web.example.com/api",
+	}))
+	Expect(ingressURLs(newTestIngress(map[string]string{DiscoveryPathAnnotation: "/healthz"}))).To(Equal([]string{
+		"http://internal.example.com/healthz",
+		"https://web.example.com/healthz",
+	}))
+	Expect(ingressURLs(newTestIngress(map[string]string{DiscoveryAnnotation: "false"}))).To(BeEmpty())
+
+	route := &gatewayv1.HTTPRoute{
+		Spec: gatewayv1.HTTPRouteSpec{
+			Hostnames: []gatewayv1.Hostname{"shop.example.com"},
+			Rules: []gatewayv1.HTTPRouteRule{{Matches: []gatewayv1.HTTPRouteMatch{
+				{Path: &gatewayv1.HTTPPathMatch{Value: lo.ToPtr("/cart")}},
+				{Path: &gatewayv1.HTTPPathMatch{Type: lo.ToPtr(gatewayv1.PathMatchRegularExpression), Value: lo.ToPtr("/item/.*")}},
+			}}},
+		},
+	}
+	Expect(httpRouteURLs(route)).To(Equal([]string{"https://shop.example.com/cart"}))
+
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "apps"},
+		Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Port: 80}, {Port: 9090}}},
+	}
+	Expect(serviceURLs(svc)).To(BeEmpty())
+	svc.Annotations = map[string]string{DiscoveryAnnotation: "true", DiscoveryPortAnnotation: "9090", DiscoveryPathAnnotation: "/metrics"}
+	Expect(serviceURLs(svc)).To(Equal([]string{"http://api.apps.svc.cluster.local:9090/metrics"}))
+}
+
+func TestDiscoverySync(t *testing.T) {
+	RegisterTestingT(t)
+	ctx := gocontext.Background()
+
+	scheme := runtime.NewScheme()
+	Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+	Expect(v1.AddToScheme(scheme)).To(Succeed())
+
+	ingress := newTestIngress(map[string]string{DiscoveryResponseCodesAnnotation: "200, 401"})
+	k8s := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ingress).Build()
+	r := &DiscoveryReconciler{Client: k8s, Log: logr.Discard(), Scheme: scheme, Template: DefaultDiscoveryTemplate}
+
+	Expect(r.sync(ctx, ingress, ingressURLs(ingress))).To(Succeed())
+	var canary v1.Canary
+	Expect(k8s.Get(ctx, client.ObjectKey{Namespace: "apps", Name: "ingress-web"}, &canary)).To(Succeed())
+	Expect(metav1.IsControlledBy(&canary, ingress)).To(BeTrue())
+	Expect(canary.Labels).To(Equal(map[string]string{"team": "web", DiscoveredFromLabel: "ingress"}))
+	Expect(canary.Spec.Schedule).To(Equal("@every 5m"))
+	Expect(canary.Spec.HTTP).To(HaveLen(3))
+	Expect(canary.Spec.HTTP[0].URL).To(Equal("http://internal.example.com/"))
+	Expect(canary.Spec.HTTP[0].MaxSSLExpiry).To(Equal(0))
+	Expect(canary.Spec.HTTP[1].Name).To(Equal("https://web.example.com/"))
+	Expect(canary.Spec.HTTP[1].MaxSSLExpiry).To(Equal(7))
+	Expect(canary.Spec.HTTP[1].ResponseCodes).To(Equal([]int{200, 401}))
+
+	// canaries outside the included namespaces are removed
+	runner.IncludeNamespaces = []string{"default"}
+	defer func() { runner.IncludeNamespaces = nil }()
+	Expect(r.sync(ctx, ingress, ingressURLs(ingress))).To(Succeed())
+	Expect(k8s.Get(ctx, client.ObjectKey{Namespace: "apps", Name: "ingress-web"}, &canary)).ToNot(Succeed())
+	runner.IncludeNamespaces = nil
+
+	// canaries that were not generated are left alone
+	handWritten := &v1.Canary{ObjectMeta: metav1.ObjectMeta{Name: "ingress-web", Namespace: "apps"}, Spec: v1.CanarySpec{Schedule: "@every 1m"}}
+	Expect(k8s.Create(ctx, handWritten)).To(Succeed())
+	Expect(r.sync(ctx, ingress, ingressURLs(ingress))).To(Succeed())
+	Expect(k8s.Get(ctx, client.ObjectKey{Namespace: "apps", Name: "ingress-web"}, &canary)).To(Succeed())
+	Expect(canary.Spec.HTTP).To(BeEmpty())
+	Expect(canary.Spec.Schedule).To(Equal("@every 1m"))
+}
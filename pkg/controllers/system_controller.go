@@ -22,6 +22,7 @@ import (
 	v1 "github.com/flanksource/canary-checker/api/v1"
 	"github.com/flanksource/canary-checker/pkg/db"
 	systemJobs "github.com/flanksource/canary-checker/pkg/jobs/topology"
+	"github.com/flanksource/canary-checker/pkg/validation"
 	dutyContext "github.com/flanksource/duty/context"
 	"github.com/flanksource/duty/query"
 	"github.com/go-logr/logr"
@@ -75,9 +76,15 @@ func (r *TopologyReconciler) Reconcile(ctx gocontext.Context, req ctrl.Request)
 		return ctrl.Result{}, r.Update(ctx, topology)
 	}
 
+	invalid := v1.NewCondition(v1.ConditionInvalid, false, "Valid", "")
+	if errs := validation.ValidateTopology(*topology); len(errs) > 0 {
+		invalid = v1.NewCondition(v1.ConditionInvalid, true, "ValidationFailed", errs.ToAggregate().Error())
+	}
+
 	dbModel, changed, err := db.PersistV1Topology(dCtx, topology)
 	if err != nil {
 		logger.Error(err, "failed to persist topology", "id", topology.GetPersistedID(), "name", topology.GetName())
+		r.setConditions(ctx, topology, invalid, v1.NewCondition(v1.ConditionReady, false, "PersistFailed", err.Error()))
 		return ctrl.Result{}, err
 	}
 
@@ -85,12 +92,28 @@ func (r *TopologyReconciler) Reconcile(ctx gocontext.Context, req ctrl.Request)
 	if changed || topology.Generation == 1 {
 		if err := systemJobs.SyncTopologyJob(dCtx, dbModel); err != nil {
 			logger.Error(err, "failed to sync topology job")
+			r.setConditions(ctx, topology, invalid, v1.NewCondition(v1.ConditionReady, false, "ScheduleFailed", err.Error()))
 			return ctrl.Result{Requeue: true, RequeueAfter: 2 * time.Minute}, err
 		}
 	}
+
+	r.setConditions(ctx, topology, invalid, v1.NewCondition(v1.ConditionReady, true, "Scheduled", ""))
 	return ctrl.Result{}, nil
 }
 
+// setConditions updates the conditions and observed generation of the topology status
+func (r *TopologyReconciler) setConditions(ctx gocontext.Context, topology *v1.Topology, conditions ...v1.Condition) {
+	patch := client.MergeFrom(topology.DeepCopy())
+	topology.Status.ObservedGeneration = topology.Generation
+	for _, condition := range conditions {
+		condition.ObservedGeneration = topology.Generation
+		v1.SetCondition(&topology.Status.Conditions, condition)
+	}
+	if err := r.Status().Patch(ctx, topology, patch); err != nil {
+		r.Log.Error(err, "failed to update status for topology", "topology", topology.Name)
+	}
+}
+
 func (r *TopologyReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	r.Events = mgr.GetEventRecorderFor("canary-checker")
 	return ctrl.NewControllerManagedBy(mgr).
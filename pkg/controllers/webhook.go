@@ -0,0 +1,90 @@
+package controllers
+
+import (
+	gocontext "context"
+	"fmt"
+
+	v1 "github.com/flanksource/canary-checker/api/v1"
+	"github.com/flanksource/canary-checker/pkg/validation"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// CanaryValidator rejects canaries with invalid schedules, expressions or check specs
+type CanaryValidator struct{}
+
+// +kubebuilder:webhook:path=/validate-canaries-flanksource-com-v1-canary,mutating=false,failurePolicy=fail,sideEffects=None,groups=canaries.flanksource.com,resources=canaries,verbs=create;update,versions=v1,name=vcanary.flanksource.com,admissionReviewVersions=v1
+
+func (v *CanaryValidator) ValidateCreate(_ gocontext.Context, obj runtime.Object) (admission.Warnings, error) {
+	canary, ok := obj.(*v1.Canary)
+	if !ok {
+		return nil, fmt.Errorf("expected a Canary but got %T", obj)
+	}
+	return nil, invalid("Canary", canary.Name, validation.ValidateCanary(*canary))
+}
+
+// ValidateUpdate only validates changes to the spec, so that existing canaries can still be deleted and
+// have their finalizers and status updated
+func (v *CanaryValidator) ValidateUpdate(ctx gocontext.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	canary, ok := newObj.(*v1.Canary)
+	if !ok {
+		return nil, fmt.Errorf("expected a Canary but got %T", newObj)
+	}
+	if old, ok := oldObj.(*v1.Canary); ok && (canary.DeletionTimestamp != nil || equality.Semantic.DeepEqual(old.Spec, canary.Spec)) {
+		return nil, nil
+	}
+	return v.ValidateCreate(ctx, newObj)
+}
+
+func (v *CanaryValidator) ValidateDelete(_ gocontext.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// TopologyValidator rejects topologies with invalid schedules, expressions or lookups
+type TopologyValidator struct{}
+
+// +kubebuilder:webhook:path=/validate-canaries-flanksource-com-v1-topology,mutating=false,failurePolicy=fail,sideEffects=None,groups=canaries.flanksource.com,resources=topologies,verbs=create;update,versions=v1,name=vtopology.flanksource.com,admissionReviewVersions=v1
+
+func (v *TopologyValidator) ValidateCreate(_ gocontext.Context, obj runtime.Object) (admission.Warnings, error) {
+	topology, ok := obj.(*v1.Topology)
+	if !ok {
+		return nil, fmt.Errorf("expected a Topology but got %T", obj)
+	}
+	return nil, invalid("Topology", topology.Name, validation.ValidateTopology(*topology))
+}
+
+// ValidateUpdate only validates changes to the spec, so that existing topologies can still be deleted and
+// have their finalizers and status updated
+func (v *TopologyValidator) ValidateUpdate(ctx gocontext.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	topology, ok := newObj.(*v1.Topology)
+	if !ok {
+		return nil, fmt.Errorf("expected a Topology but got %T", newObj)
+	}
+	if old, ok := oldObj.(*v1.Topology); ok && (topology.DeletionTimestamp != nil || equality.Semantic.DeepEqual(old.Spec, topology.Spec)) {
+		return nil, nil
+	}
+	return v.ValidateCreate(ctx, newObj)
+}
+
+func (v *TopologyValidator) ValidateDelete(_ gocontext.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// SetupWebhooksWithManager registers the validating webhooks for canaries and topologies
+func SetupWebhooksWithManager(mgr ctrl.Manager) error {
+	if err := ctrl.NewWebhookManagedBy(mgr).For(&v1.Canary{}).WithValidator(&CanaryValidator{}).Complete(); err != nil {
+		return err
+	}
+	return ctrl.NewWebhookManagedBy(mgr).For(&v1.Topology{}).WithValidator(&TopologyValidator{}).Complete()
+}
+
+func invalid(kind, name string, errs field.ErrorList) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(v1.GroupVersion.WithKind(kind).GroupKind(), name, errs)
+}
@@ -0,0 +1,67 @@
+package controllers
+
+import (
+	gocontext "context"
+	"testing"
+
+	v1 "github.com/flanksource/canary-checker/api/v1"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCanaryValidatorUpdate(t *testing.T) {
+	RegisterTestingT(t)
+	validator := &CanaryValidator{}
+
+	// created before the webhook was installed
+	invalid := &v1.Canary{
+		ObjectMeta: metav1.ObjectMeta{Name: "dns", Finalizers: []string{FinalizerName}},
+		Spec:       v1.CanarySpec{DNS: []v1.DNSCheck{{QueryType: "AAA"}}},
+	}
+	_, err := validator.ValidateCreate(gocontext.TODO(), invalid)
+	Expect(err).To(HaveOccurred())
+
+	deleting := invalid.DeepCopy()
+	deleting.DeletionTimestamp = &metav1.Time{}
+	_, err = validator.ValidateUpdate(gocontext.TODO(), invalid, deleting)
+	Expect(err).ToNot(HaveOccurred())
+
+	removed := deleting.DeepCopy()
+	removed.Finalizers = nil
+	_, err = validator.ValidateUpdate(gocontext.TODO(), deleting, removed)
+	Expect(err).ToNot(HaveOccurred())
+
+	// metadata only changes are allowed, while changes to the spec are validated
+	labelled := invalid.DeepCopy()
+	labelled.Finalizers = nil
+	labelled.Labels = map[string]string{"team": "platform"}
+	_, err = validator.ValidateUpdate(gocontext.TODO(), invalid, labelled)
+	Expect(err).ToNot(HaveOccurred())
+
+	changed := labelled.DeepCopy()
+	changed.Spec.DNS[0].Query = "example.com"
+	_, err = validator.ValidateUpdate(gocontext.TODO(), labelled, changed)
+	Expect(err).To(HaveOccurred())
+}
+
+func TestTopologyValidatorUpdate(t *testing.T) {
+	RegisterTestingT(t)
+	validator := &TopologyValidator{}
+
+	invalid := &v1.Topology{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster", Finalizers: []string{TopologyFinalizerName}},
+		Spec:       v1.TopologySpec{Schedule: "every now and then"},
+	}
+	_, err := validator.ValidateCreate(gocontext.TODO(), invalid)
+	Expect(err).To(HaveOccurred())
+
+	removed := invalid.DeepCopy()
+	removed.Finalizers = nil
+	_, err = validator.ValidateUpdate(gocontext.TODO(), invalid, removed)
+	Expect(err).ToNot(HaveOccurred())
+
+	changed := removed.DeepCopy()
+	changed.Spec.Schedule = "@every 1y"
+	_, err = validator.ValidateUpdate(gocontext.TODO(), removed, changed)
+	Expect(err).To(HaveOccurred())
+}
@@ -34,6 +34,7 @@ func UpdateCanaryStatusAndEvent(ctx context.Context, canary v1.Canary, results [
 	var lastTransitionedTime *metav1.Time
 	var highestLatency float64
 	var uptimeAgg dutyTypes.Uptime
+	var passed, failed, invalid int
 
 	transitioned := false
 	for _, result := range results {
@@ -89,13 +90,16 @@ func UpdateCanaryStatusAndEvent(ctx context.Context, canary v1.Canary, results [
 
 		if result.Pass {
 			status = v1.Passed
+			passed++
 		} else {
 			failEvents = append(failEvents, fmt.Sprintf("%s-%s: %s", result.Check.GetType(), result.Check.GetEndpoint(), result.Message))
 			status = v1.Failed
+			failed++
 		}
 
 		if result.Invalid {
 			status = v1.Invalid
+			invalid++
 		}
 	}
 
@@ -123,6 +127,7 @@ func UpdateCanaryStatusAndEvent(ctx context.Context, canary v1.Canary, results [
 		Uptime:               uptimeAgg.String(),
 		Latency:              utils.Age(time.Duration(highestLatency) * time.Millisecond),
 		NamespacedName:       canary.GetNamespacedName(),
+		Conditions:           checkConditions(passed, failed, invalid, errMsg),
 	}
 
 	CanaryStatusChannel <- payload
@@ -138,4 +143,27 @@ type CanaryStatusPayload struct {
 	Uptime               string
 	Latency              string
 	NamespacedName       types.NamespacedName
+	Conditions           []v1.Condition
+}
+
+// checkConditions returns the Healthy, Degraded and Invalid conditions for the results of a run
+func checkConditions(passed, failed, invalid int, errMsg string) []v1.Condition {
+	healthy := v1.NewCondition(v1.ConditionHealthy, true, "ChecksPassed", fmt.Sprintf("%d checks passed", passed))
+	degraded := v1.NewCondition(v1.ConditionDegraded, false, "ChecksPassed", "")
+	switch {
+	case failed > 0 && passed == 0:
+		healthy = v1.NewCondition(v1.ConditionHealthy, false, "ChecksFailed", fmt.Sprintf("%d checks failed: %s", failed, errMsg))
+		degraded = v1.NewCondition(v1.ConditionDegraded, false, "ChecksFailed", "all checks failed")
+	case failed > 0:
+		healthy = v1.NewCondition(v1.ConditionHealthy, false, "ChecksFailed", fmt.Sprintf("%d of %d checks failed: %s", failed, passed+failed, errMsg))
+		degraded = v1.NewCondition(v1.ConditionDegraded, true, "ChecksFailed", fmt.Sprintf("%d of %d checks failed", failed, passed+failed))
+	case passed == 0:
+		healthy = v1.NewCondition(v1.ConditionHealthy, false, "NoResults", "no checks were run")
+	}
+
+	invalidCondition := v1.NewCondition(v1.ConditionInvalid, false, "ChecksValid", "")
+	if invalid > 0 {
+		invalidCondition = v1.NewCondition(v1.ConditionInvalid, true, "InvalidChecks", fmt.Sprintf("%d checks are invalid: %s", invalid, errMsg))
+	}
+	return []v1.Condition{healthy, degraded, invalidCondition}
 }
@@ -0,0 +1,36 @@
+package canary
+
+import (
+	"testing"
+
+	v1 "github.com/flanksource/canary-checker/api/v1"
+)
+
+func TestCheckConditions(t *testing.T) {
+	tests := []struct {
+		name                             string
+		passed, failed, invalid          int
+		healthy, degraded, invalidStatus v1.ConditionStatus
+	}{
+		{name: "all passed", passed: 2, healthy: "True", degraded: "False", invalidStatus: "False"},
+		{name: "some failed", passed: 1, failed: 1, healthy: "False", degraded: "True", invalidStatus: "False"},
+		{name: "all failed", failed: 2, healthy: "False", degraded: "False", invalidStatus: "False"},
+		{name: "invalid", failed: 1, invalid: 1, healthy: "False", degraded: "False", invalidStatus: "True"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conditions := checkConditions(tt.passed, tt.failed, tt.invalid, "error")
+			for conditionType, expected := range map[string]v1.ConditionStatus{
+				v1.ConditionHealthy:  tt.healthy,
+				v1.ConditionDegraded: tt.degraded,
+				v1.ConditionInvalid:  tt.invalidStatus,
+			} {
+				condition := v1.FindCondition(conditions, conditionType)
+				if condition == nil || condition.Status != expected {
+					t.Errorf("expected %s to be %s, got %+v", conditionType, expected, condition)
+				}
+			}
+		})
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/flanksource/canary-checker/pkg/cache"
 	"github.com/flanksource/canary-checker/pkg/db"
 	"github.com/flanksource/canary-checker/pkg/runner"
+	"github.com/flanksource/canary-checker/pkg/shard"
 	"github.com/flanksource/canary-checker/pkg/utils"
 	"github.com/flanksource/commons/logger"
 	"github.com/flanksource/duty/context"
@@ -99,6 +100,12 @@ func SyncCanaryJob(ctx context.Context, dbCanary pkg.Canary) error {
 		return nil
 	}
 
+	// canaries are scheduled on the replica they are assigned to
+	if !shard.Owns(id) {
+		Unschedule(id)
+		return nil
+	}
+
 	canaryJob := CanaryJob{
 		Canary:   *canary,
 		DBCanary: dbCanary,
@@ -7,12 +7,14 @@ import (
 	canaryJobs "github.com/flanksource/canary-checker/pkg/jobs/canary"
 	topologyJobs "github.com/flanksource/canary-checker/pkg/jobs/topology"
 	"github.com/flanksource/canary-checker/pkg/runner"
+	"github.com/flanksource/canary-checker/pkg/shard"
 	"github.com/flanksource/canary-checker/pkg/topology"
 	"github.com/flanksource/commons/logger"
 	dutyEcho "github.com/flanksource/duty/echo"
 	"github.com/flanksource/duty/job"
 	dutyQuery "github.com/flanksource/duty/query"
 	"github.com/robfig/cron/v3"
+	"github.com/samber/lo"
 )
 
 var FuncScheduler = cron.New()
@@ -49,8 +51,8 @@ func Start() {
 
 	miscJobs := []*job.Job{
 		topologyJobs.CleanupDeletedTopologyComponents, topologyJobs.SyncTopology,
-		topologyJobs.TopologyCRDReconcile, canaryJobs.SyncCanaryJobs,
-		canaryJobs.CleanupDeletedCanaryChecks, dutyQuery.SyncComponentCacheJob,
+		topologyJobs.TopologyCRDReconcile, canaryJobs.CleanupDeletedCanaryChecks,
+		dutyQuery.SyncComponentCacheJob,
 	}
 	for _, j := range miscJobs {
 		job := j
@@ -60,6 +62,13 @@ func Start() {
 		}
 	}
 
+	// when sharded every replica syncs the canaries it is assigned, even if it is not the leader
+	syncJob := canaryJobs.SyncCanaryJobs
+	syncJob.Context = context.DefaultContext
+	if err := syncJob.AddToScheduler(lo.Ternary(shard.Identity != "", canaryJobs.CanaryScheduler, FuncScheduler)); err != nil {
+		logger.Errorf(err.Error())
+	}
+
 	if runner.OperatorExecutor {
 		job := canaryJobs.CleanupCRDDeleteCanaries
 		job.Context = context.DefaultContext
@@ -24,6 +24,8 @@ func init() {
 
 // SetupMetrics is called from cmd/root PreRun since we take v1.AdditionalCheckMetricLabels from CLI
 func SetupMetrics() {
+	// the cluster label is always present, allowing it again would register a duplicate label name
+	v1.AdditionalCheckMetricLabels = lo.Without(lo.Uniq(v1.AdditionalCheckMetricLabels), v1.ClusterLabel)
 	slices.Sort(v1.AdditionalCheckMetricLabels)
 
 	RequestLatency = prometheus.NewHistogramVec(
@@ -33,7 +35,7 @@ func SetupMetrics() {
 			Buckets: []float64{5, 10, 25, 50, 200, 500, 1000, 3000, 10000, 30000},
 		},
 		append(
-			[]string{"type", "endpoint", "canary_name", "canary_namespace", "owner", "severity", "key", "name"},
+			[]string{"type", "endpoint", "canary_name", "canary_namespace", "owner", "severity", "key", "name", v1.ClusterLabel},
 			v1.AdditionalCheckMetricLabels...,
 		),
 	)
@@ -43,10 +45,10 @@ func SetupMetrics() {
 			Name: "canary_check",
 			Help: "A gauge representing the canaries success (0) or failure (1)",
 		},
-		append([]string{"key", "type", "canary_name", "canary_namespace", "name"}, v1.AdditionalCheckMetricLabels...),
+		append([]string{"key", "type", "canary_name", "canary_namespace", "name", v1.ClusterLabel}, v1.AdditionalCheckMetricLabels...),
 	)
 
-	checkLabels := []string{"type", "endpoint", "canary_name", "canary_namespace", "owner", "severity", "key", "name"}
+	checkLabels := []string{"type", "endpoint", "canary_name", "canary_namespace", "owner", "severity", "key", "name", v1.ClusterLabel}
 	checkLabels = append(checkLabels, v1.AdditionalCheckMetricLabels...)
 
 	OpsCount = prometheus.NewCounterVec(
@@ -62,7 +64,7 @@ func SetupMetrics() {
 			Name: "canary_check_invalid_count",
 			Help: "The total number of invalid checks",
 		},
-		[]string{"type", "endpoint", "canary_name", "canary_namespace", "owner", "severity", "key", "name"},
+		[]string{"type", "endpoint", "canary_name", "canary_namespace", "owner", "severity", "key", "name", v1.ClusterLabel},
 	)
 
 	CanaryCheckInfo = prometheus.NewGaugeVec(
@@ -192,6 +194,7 @@ func Record(
 	endpoint := canary.GetDescription(result.Check)
 	owner := canary.Spec.Owner
 	severity := canary.Spec.Severity
+	cluster := result.Labels[v1.ClusterLabel]
 	// We are recording aggreated metrics at the canary level, not the individual check level
 	key := canary.GetCheckID(result.Check.GetName())
 	var fail, pass, latency *rolling.TimePolicy
@@ -231,7 +234,7 @@ func Record(
 	}
 
 	checkMetricLabels := append(
-		[]string{checkType, endpoint, canaryName, canaryNamespace, owner, severity, key, name},
+		[]string{checkType, endpoint, canaryName, canaryNamespace, owner, severity, key, name, cluster},
 		additionalLabels...)
 
 	OpsCount.WithLabelValues(checkMetricLabels...).Inc()
@@ -241,7 +244,7 @@ func Record(
 		latency.Append(float64(result.Duration))
 	}
 
-	gaugeLabels := append([]string{key, checkType, canaryName, canaryNamespace, name}, v1.AdditionalCheckMetricLabels...)
+	gaugeLabels := append([]string{key, checkType, canaryName, canaryNamespace, name, cluster}, v1.AdditionalCheckMetricLabels...)
 
 	if result.Pass {
 		pass.Append(1)
@@ -404,9 +407,31 @@ func FillUptime(checkKey, duration string, uptime *types.Uptime) error {
 	return nil
 }
 
+// checkMetrics are the metrics of individual check types that are removed together with the check
+var checkMetrics []interface {
+	DeletePartialMatch(labels prometheus.Labels) int
+}
+
+// RegisterCheckGauge registers a gauge whose series are removed when the check is unregistered,
+// the gauge must have a "key" label with the check id
+func RegisterCheckGauge(gauge *prometheus.GaugeVec) {
+	prometheus.MustRegister(gauge)
+	checkMetrics = append(checkMetrics, gauge)
+}
+
+// RegisterCheckHistogram registers a histogram whose series are removed when the check is unregistered,
+// the histogram must have a "key" label with the check id
+func RegisterCheckHistogram(histogram *prometheus.HistogramVec) {
+	prometheus.MustRegister(histogram)
+	checkMetrics = append(checkMetrics, histogram)
+}
+
 func UnregisterGauge(ctx context.Context, checkIDs []string) {
 	for _, checkID := range checkIDs {
 		ctx.Debugf("Unregistering gauge for checkID %s", checkID)
 		Gauge.DeletePartialMatch(prometheus.Labels{"key": checkID})
+		for _, metric := range checkMetrics {
+			metric.DeletePartialMatch(prometheus.Labels{"key": checkID})
+		}
 	}
 }
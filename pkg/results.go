@@ -47,7 +47,7 @@ func Success(check external.Check, canary v1.Canary) *CheckResult {
 }
 
 func New(check external.Check, canary v1.Canary) *CheckResult {
-	return &CheckResult{
+	result := &CheckResult{
 		Start: time.Now(),
 		Check: check,
 		Data: map[string]interface{}{
@@ -55,6 +55,10 @@ func New(check external.Check, canary v1.Canary) *CheckResult {
 		},
 		Canary: canary,
 	}
+	if cluster := v1.GetCluster(check); cluster != "" {
+		result.Labels = map[string]string{v1.ClusterLabel: cluster}
+	}
+	return result
 }
 
 func (result *CheckResult) ErrorMessage(err error) *CheckResult {
@@ -0,0 +1,62 @@
+package shard
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+
+	"github.com/samber/lo"
+)
+
+// virtualNodes is the number of points each member has on the ring, more points spread the keys more evenly
+const virtualNodes = 128
+
+// Ring assigns keys to members with consistent hashing, so that only the keys of a member that joins
+// or leaves are moved to another member
+type Ring struct {
+	members []string
+	hashes  []uint32
+	owners  map[uint32]string
+}
+
+// NewRing returns a ring of the members, the order of the members does not matter
+func NewRing(members ...string) *Ring {
+	r := &Ring{members: lo.Uniq(members), owners: make(map[uint32]string)}
+	sort.Strings(r.members)
+	for _, member := range r.members {
+		for i := 0; i < virtualNodes; i++ {
+			h := hash(fmt.Sprintf("%s#%d", member, i))
+			if _, exists := r.owners[h]; exists {
+				continue
+			}
+			r.owners[h] = member
+			r.hashes = append(r.hashes, h)
+		}
+	}
+	sort.Slice(r.hashes, func(i, j int) bool { return r.hashes[i] < r.hashes[j] })
+	return r
+}
+
+// Owner returns the member the key is assigned to, or an empty string when the ring has no members
+func (r *Ring) Owner(key string) string {
+	if len(r.hashes) == 0 {
+		return ""
+	}
+	h := hash(key)
+	i := sort.Search(len(r.hashes), func(i int) bool { return r.hashes[i] >= h })
+	if i == len(r.hashes) {
+		i = 0
+	}
+	return r.owners[r.hashes[i]]
+}
+
+// Members returns the sorted members of the ring
+func (r *Ring) Members() []string {
+	return r.members
+}
+
+func hash(key string) uint32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return h.Sum32()
+}
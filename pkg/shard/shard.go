@@ -0,0 +1,167 @@
+package shard
+
+import (
+	gocontext "context"
+	"fmt"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/flanksource/commons/logger"
+	"github.com/samber/lo"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// LeaseLabel is set on the leases of the replicas to the name of the group they belong to
+	LeaseLabel = "canaries.flanksource.com/shard"
+
+	leaseDuration = 30 * time.Second
+	renewInterval = 10 * time.Second
+)
+
+// Identity of this replica, sharding is disabled when it is empty
+var Identity string
+
+var ring atomic.Pointer[Ring]
+
+// Owns returns true when the canary is assigned to this replica, every canary is owned when sharding is disabled
+func Owns(id string) bool {
+	r := ring.Load()
+	if Identity == "" || r == nil {
+		return true
+	}
+	return r.Owner(id) == Identity
+}
+
+// Owner returns the replica the canary is assigned to
+func Owner(id string) string {
+	r := ring.Load()
+	if Identity == "" || r == nil {
+		return Identity
+	}
+	return r.Owner(id)
+}
+
+// Members returns the live replicas
+func Members() []string {
+	if r := ring.Load(); r != nil {
+		return r.Members()
+	}
+	return nil
+}
+
+// Membership tracks the live replicas of a group with a Lease per replica that is renewed while the replica is running
+type Membership struct {
+	Client    kubernetes.Interface
+	Namespace string
+	// Group is the value of the LeaseLabel, replicas of the same group share the canaries
+	Group string
+	// OnChange is called after the replicas have changed, so that the canaries can be rebalanced
+	OnChange func(members []string)
+}
+
+func (m Membership) leaseName() string {
+	return fmt.Sprintf("%s-%s", m.Group, Identity)
+}
+
+// Start renews the lease of this replica and refreshes the members until the context is cancelled,
+// the lease is deleted on exit so that the other replicas take over its canaries immediately
+func (m Membership) Start(ctx gocontext.Context) error {
+	if Identity == "" {
+		return fmt.Errorf("the identity of the replica is required for sharding")
+	}
+	if err := m.Sync(ctx); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(renewInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			err := m.Client.CoordinationV1().Leases(m.Namespace).Delete(gocontext.Background(), m.leaseName(), metav1.DeleteOptions{})
+			if errors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		case <-ticker.C:
+			if err := m.Sync(ctx); err != nil {
+				logger.Errorf("failed to sync shard membership: %v", err)
+			}
+		}
+	}
+}
+
+// Sync renews the lease of this replica and updates the ring with the replicas whose lease has not expired
+func (m Membership) Sync(ctx gocontext.Context) error {
+	if err := m.renew(ctx); err != nil {
+		return err
+	}
+
+	leases, err := m.Client.CoordinationV1().Leases(m.Namespace).List(ctx, metav1.ListOptions{LabelSelector: LeaseLabel + "=" + m.Group})
+	if err != nil {
+		return err
+	}
+
+	members := []string{Identity}
+	now := time.Now()
+	for _, lease := range leases.Items {
+		if lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity == Identity || lease.Spec.RenewTime == nil {
+			continue
+		}
+		duration := leaseDuration
+		if lease.Spec.LeaseDurationSeconds != nil {
+			duration = time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second
+		}
+		if lease.Spec.RenewTime.Add(duration).After(now) {
+			members = append(members, *lease.Spec.HolderIdentity)
+		}
+	}
+
+	next := NewRing(members...)
+	if current := ring.Load(); current != nil && slices.Equal(current.Members(), next.Members()) {
+		return nil
+	}
+	ring.Store(next)
+	logger.Infof("shard members changed: %v", next.Members())
+	if m.OnChange != nil {
+		m.OnChange(next.Members())
+	}
+	return nil
+}
+
+func (m Membership) renew(ctx gocontext.Context) error {
+	leases := m.Client.CoordinationV1().Leases(m.Namespace)
+	now := metav1.NewMicroTime(time.Now())
+	seconds := int32(leaseDuration.Seconds())
+
+	lease, err := leases.Get(ctx, m.leaseName(), metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		_, err = leases.Create(ctx, &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      m.leaseName(),
+				Namespace: m.Namespace,
+				Labels:    map[string]string{LeaseLabel: m.Group},
+			},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       lo.ToPtr(Identity),
+				LeaseDurationSeconds: &seconds,
+				AcquireTime:          &now,
+				RenewTime:            &now,
+			},
+		}, metav1.CreateOptions{})
+		return err
+	} else if err != nil {
+		return err
+	}
+
+	lease.Spec.HolderIdentity = lo.ToPtr(Identity)
+	lease.Spec.LeaseDurationSeconds = &seconds
+	lease.Spec.RenewTime = &now
+	_, err = leases.Update(ctx, lease, metav1.UpdateOptions{})
+	return err
+}
@@ -0,0 +1,108 @@
+package shard
+
+import (
+	gocontext "context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestRing(t *testing.T) {
+	var keys []string
+	for i := 0; i < 3000; i++ {
+		keys = append(keys, fmt.Sprintf("canary-%d", i))
+	}
+
+	ring := NewRing("c", "a", "b", "a")
+	if !slices.Equal(ring.Members(), []string{"a", "b", "c"}) {
+		t.Fatalf("unexpected members %v", ring.Members())
+	}
+
+	counts := make(map[string]int)
+	before := make(map[string]string)
+	for _, key := range keys {
+		owner := ring.Owner(key)
+		counts[owner]++
+		before[key] = owner
+	}
+	for member, count := range counts {
+		if count < 600 || count > 1400 {
+			t.Errorf("%s owns %d of %d keys", member, count, len(keys))
+		}
+	}
+
+	// only the keys of the member that left are moved
+	ring = NewRing("a", "c")
+	for _, key := range keys {
+		if before[key] != "b" && ring.Owner(key) != before[key] {
+			t.Fatalf("%s moved from %s to %s", key, before[key], ring.Owner(key))
+		}
+	}
+
+	if NewRing().Owner("canary") != "" {
+		t.Errorf("an empty ring should not have owners")
+	}
+}
+
+func TestMembership(t *testing.T) {
+	defer func() {
+		Identity = ""
+		ring.Store(nil)
+	}()
+	if !Owns("canary") {
+		t.Fatal("every canary should be owned when sharding is disabled")
+	}
+
+	ctx := gocontext.Background()
+	renewed := metav1.NewMicroTime(time.Now())
+	expired := metav1.NewMicroTime(time.Now().Add(-time.Minute))
+	lease := func(name string, renewTime metav1.MicroTime) *coordinationv1.Lease {
+		return &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{Name: "canary-checker-" + name, Namespace: "default", Labels: map[string]string{LeaseLabel: "canary-checker"}},
+			Spec:       coordinationv1.LeaseSpec{HolderIdentity: lo.ToPtr(name), LeaseDurationSeconds: lo.ToPtr(int32(30)), RenewTime: &renewTime},
+		}
+	}
+	client := fake.NewSimpleClientset(lease("b", renewed), lease("c", expired))
+
+	var changes [][]string
+	Identity = "a"
+	m := Membership{Client: client, Namespace: "default", Group: "canary-checker", OnChange: func(members []string) {
+		changes = append(changes, members)
+	}}
+	if err := m.Sync(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(Members(), []string{"a", "b"}) {
+		t.Fatalf("expected the replicas with a live lease, got %v", Members())
+	}
+	if _, err := client.CoordinationV1().Leases("default").Get(ctx, "canary-checker-a", metav1.GetOptions{}); err != nil {
+		t.Fatalf("expected the lease of the replica to be created: %v", err)
+	}
+
+	owned := 0
+	for i := 0; i < 100; i++ {
+		id := fmt.Sprintf("canary-%d", i)
+		if Owns(id) {
+			owned++
+		} else if Owner(id) != "b" {
+			t.Fatalf("unexpected owner %s", Owner(id))
+		}
+	}
+	if owned == 0 || owned == 100 {
+		t.Errorf("expected the canaries to be shared, a owns %d", owned)
+	}
+
+	// the members are unchanged
+	if err := m.Sync(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 {
+		t.Errorf("expected 1 change, got %v", changes)
+	}
+}
@@ -0,0 +1,240 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"text/template/parse"
+
+	"github.com/flanksource/canary-checker/api/external"
+	v1 "github.com/flanksource/canary-checker/api/v1"
+	"github.com/flanksource/gomplate/v3"
+	"github.com/google/cel-go/cel"
+	"github.com/robfig/cron/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/yaml"
+)
+
+// templateHeader changes the delimiters of a go template, see gomplate
+const templateHeader = "# gotemplate: "
+
+// Validator is implemented by checks that have rules beyond what the CRD schema enforces
+type Validator interface {
+	Validate(path *field.Path) field.ErrorList
+}
+
+var celEnv = sync.OnceValues(func() (*cel.Env, error) {
+	return cel.NewEnv(gomplate.GetCelEnv(nil)...)
+})
+
+// ValidateCanary returns the errors in a canary that would otherwise only be reported when its checks run
+func ValidateCanary(canary v1.Canary) field.ErrorList {
+	return ValidateCanarySpec(canary.Spec, field.NewPath("spec"))
+}
+
+func ValidateCanarySpec(spec v1.CanarySpec, path *field.Path) field.ErrorList {
+	errs := ValidateSchedule(spec.Schedule, path.Child("schedule"))
+
+	value := reflect.ValueOf(spec)
+	for i := 0; i < value.NumField(); i++ {
+		name := jsonName(value.Type().Field(i))
+		if name == "" {
+			continue
+		}
+		switch f := value.Field(i); f.Kind() {
+		case reflect.Slice:
+			for j := 0; j < f.Len(); j++ {
+				if check, ok := f.Index(j).Interface().(external.Check); ok {
+					errs = append(errs, ValidateCheck(check, path.Child(name).Index(j))...)
+				}
+			}
+		case reflect.Ptr:
+			if f.IsNil() {
+				continue
+			}
+			if check, ok := f.Elem().Interface().(external.Check); ok {
+				errs = append(errs, ValidateCheck(check, path.Child(name))...)
+			}
+		}
+	}
+	return errs
+}
+
+// ValidateCheck compiles the templates and expressions of a check and runs its own validation
+func ValidateCheck(check external.Check, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if t, ok := check.(v1.TestFunction); ok {
+		errs = append(errs, ValidateTemplate(t.GetTestFunction(), path.Child("test"))...)
+	}
+	if t, ok := check.(v1.DisplayTemplate); ok {
+		errs = append(errs, ValidateTemplate(t.GetDisplayTemplate(), path.Child("display"))...)
+	}
+	if t, ok := check.(v1.Transformer); ok {
+		errs = append(errs, ValidateTemplate(t.GetTransformer(), path.Child("transform"))...)
+	}
+	for i, metric := range check.GetMetricsSpec() {
+		metricPath := path.Child("metrics").Index(i)
+		errs = append(errs, ValidateExpression(metric.Value, metricPath.Child("value"))...)
+		for j, label := range metric.Labels {
+			errs = append(errs, ValidateExpression(label.ValueExpr, metricPath.Child("labels").Index(j).Child("valueExpr"))...)
+		}
+	}
+	if v, ok := check.(Validator); ok {
+		errs = append(errs, v.Validate(path)...)
+	}
+	return errs
+}
+
+// ValidateTopology returns the errors in the schedule, expressions and lookups of a topology
+func ValidateTopology(topology v1.Topology) field.ErrorList {
+	path := field.NewPath("spec")
+	spec := topology.Spec
+
+	errs := ValidateSchedule(spec.Schedule, path.Child("schedule"))
+	if spec.Id != nil {
+		errs = append(errs, ValidateTemplate(*spec.Id, path.Child("id"))...)
+	}
+	errs = append(errs, ValidateExpression(spec.HealthExpr, path.Child("healthExpr"))...)
+	errs = append(errs, ValidateExpression(spec.StatusExpr, path.Child("statusExpr"))...)
+	errs = append(errs, validateProperties(spec.Properties, path.Child("properties"))...)
+
+	for i, component := range spec.Components {
+		componentPath := path.Child("components").Index(i)
+		if component.Id != nil {
+			errs = append(errs, ValidateTemplate(*component.Id, componentPath.Child("id"))...)
+		}
+		if component.Lookup != nil {
+			errs = append(errs, ValidateCanarySpec(*component.Lookup, componentPath.Child("lookup"))...)
+		}
+		var properties v1.Properties
+		for _, p := range component.Properties {
+			if p != nil {
+				properties = append(properties, *p)
+			}
+		}
+		errs = append(errs, validateProperties(properties, componentPath.Child("properties"))...)
+	}
+	return errs
+}
+
+func validateProperties(properties v1.Properties, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	for i, property := range properties {
+		if property.Summary != nil {
+			errs = append(errs, ValidateTemplate(*property.Summary, path.Index(i).Child("summary"))...)
+		}
+		if property.Lookup != nil {
+			errs = append(errs, ValidateCanarySpec(*property.Lookup, path.Index(i).Child("lookup"))...)
+		}
+	}
+	return errs
+}
+
+// ValidateSchedule parses a cron schedule, an empty schedule or @never is valid
+func ValidateSchedule(schedule string, path *field.Path) field.ErrorList {
+	if schedule == "" || schedule == "@never" {
+		return nil
+	}
+	if _, err := cron.ParseStandard(schedule); err != nil {
+		return field.ErrorList{field.Invalid(path, schedule, err.Error())}
+	}
+	return nil
+}
+
+// ValidateTemplate parses the go template and cel expression of a template. The variables
+// available to a template depend on the check, so only the syntax is validated
+func ValidateTemplate(t v1.Template, path *field.Path) field.ErrorList {
+	errs := ValidateExpression(t.Expression, path.Child("expr"))
+	if t.Template != "" && !strings.Contains(t.Template, templateHeader) {
+		tree := parse.New("template")
+		tree.Mode = parse.SkipFuncCheck
+		if _, err := tree.Parse(t.Template, "", "", map[string]*parse.Tree{}); err != nil {
+			errs = append(errs, field.Invalid(path.Child("template"), t.Template, err.Error()))
+		}
+	}
+	return errs
+}
+
+// ValidateExpression parses a cel expression
+func ValidateExpression(expr string, path *field.Path) field.ErrorList {
+	if strings.TrimSpace(expr) == "" {
+		return nil
+	}
+	env, err := celEnv()
+	if err != nil {
+		return field.ErrorList{field.InternalError(path, fmt.Errorf("failed to create cel environment: %w", err))}
+	}
+	if _, issues := env.Parse(strings.ReplaceAll(expr, "\n", " ")); issues != nil && issues.Err() != nil {
+		return field.ErrorList{field.Invalid(path, expr, issues.Err().Error())}
+	}
+	return nil
+}
+
+func jsonName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	if name == "-" {
+		return ""
+	}
+	return name
+}
+
+// Document is the result of validating a single document of a yaml file
+type Document struct {
+	Kind   string
+	Name   string
+	Errors field.ErrorList
+	// ParseError is set when the document could not be decoded, e.g. because of an unknown field
+	ParseError error
+}
+
+func (d Document) Valid() bool {
+	return d.ParseError == nil && len(d.Errors) == 0
+}
+
+var documentSeparator = regexp.MustCompile(`(?m)^---\n`)
+
+// ValidateYAML validates every Canary and Topology in a multi-document yaml file, documents
+// without a kind are validated as a canary spec
+func ValidateYAML(data string) []Document {
+	var documents []Document
+	for _, chunk := range documentSeparator.Split(data, -1) {
+		if strings.TrimSpace(chunk) == "" {
+			continue
+		}
+		var meta struct {
+			metav1.TypeMeta   `json:",inline"`
+			metav1.ObjectMeta `json:"metadata,omitempty"`
+		}
+		if err := yaml.Unmarshal([]byte(chunk), &meta); err != nil {
+			documents = append(documents, Document{ParseError: err})
+			continue
+		}
+
+		doc := Document{Kind: meta.Kind, Name: meta.Name}
+		switch meta.Kind {
+		case "Topology":
+			var topology v1.Topology
+			if doc.ParseError = yaml.UnmarshalStrict([]byte(chunk), &topology); doc.ParseError == nil {
+				doc.Errors = ValidateTopology(topology)
+			}
+		case "Canary":
+			var canary v1.Canary
+			if doc.ParseError = yaml.UnmarshalStrict([]byte(chunk), &canary); doc.ParseError == nil {
+				doc.Errors = ValidateCanary(canary)
+			}
+		case "":
+			var spec v1.CanarySpec
+			if doc.ParseError = yaml.UnmarshalStrict([]byte(chunk), &spec); doc.ParseError == nil {
+				doc.Errors = ValidateCanarySpec(spec, nil)
+			}
+		default:
+			// other resources, e.g. secrets used by the canaries, are not validated
+			continue
+		}
+		documents = append(documents, doc)
+	}
+	return documents
+}
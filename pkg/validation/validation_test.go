@@ -0,0 +1,162 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/flanksource/canary-checker/api/external"
+	v1 "github.com/flanksource/canary-checker/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestValidateCanary(t *testing.T) {
+	tests := []struct {
+		name   string
+		spec   v1.CanarySpec
+		errors []string
+	}{
+		{
+			name: "valid",
+			spec: v1.CanarySpec{
+				Schedule: "@every 5m",
+				HTTP: []v1.HTTPCheck{{
+					Connection:  v1.Connection{URL: "https://example.com"},
+					Templatable: v1.Templatable{Test: v1.Template{Expression: "code == 200"}, Display: v1.Template{Template: "{{ .code }}"}},
+				}},
+			},
+		},
+		{
+			name:   "invalid schedule",
+			spec:   v1.CanarySpec{Schedule: "every 5 minutes"},
+			errors: []string{"spec.schedule: Invalid value"},
+		},
+		{
+			name: "cel syntax error",
+			spec: v1.CanarySpec{
+				HTTP: []v1.HTTPCheck{{}, {Templatable: v1.Templatable{Test: v1.Template{Expression: "code == "}}}},
+			},
+			errors: []string{"spec.http[1].test.expr: Invalid value"},
+		},
+		{
+			name: "go template syntax error",
+			spec: v1.CanarySpec{
+				HTTP: []v1.HTTPCheck{{Templatable: v1.Templatable{Display: v1.Template{Template: "{{ .code "}}}},
+			},
+			errors: []string{"spec.http[0].display.template: Invalid value"},
+		},
+		{
+			name:   "unknown dns query type",
+			spec:   v1.CanarySpec{DNS: []v1.DNSCheck{{QueryType: "AAA"}}},
+			errors: []string{`spec.dns[0].querytype: Unsupported value: "AAA"`},
+		},
+		{
+			name:   "unknown junit format",
+			spec:   v1.CanarySpec{Junit: []v1.JunitCheck{{Formats: []string{"tap", "xunit"}}}},
+			errors: []string{`spec.junit[0].formats[1]: Unsupported value: "xunit"`},
+		},
+		{
+			name: "endpoint and url",
+			spec: v1.CanarySpec{HTTP: []v1.HTTPCheck{{
+				Endpoint:   "https://example.com",
+				Connection: v1.Connection{URL: "https://example.com"},
+			}}},
+			errors: []string{"spec.http[0].endpoint: Forbidden: cannot specify both endpoint and url"},
+		},
+		{
+			name:   "metric expression",
+			spec:   v1.CanarySpec{TCP: []v1.TCPCheck{{Description: v1.Description{Metrics: []external.Metrics{{Name: "latency", Value: "duration +"}}}}}},
+			errors: []string{"spec.tcp[0].metrics[0].value: Invalid value"},
+		},
+		{
+			name: "kubernetes resource dry-run",
+			spec: v1.CanarySpec{KubernetesResource: []v1.KubernetesResourceCheck{{
+				DryRun:    true,
+				Resources: []unstructured.Unstructured{{Object: map[string]any{"apiVersion": "v1", "kind": "Pod"}}},
+				Checks:    []v1.KubernetesResourceChecks{{}},
+			}}},
+			errors: []string{
+				"spec.kubernetesResource[0].checks: Forbidden: checks cannot run against dry-run resources",
+				"spec.kubernetesResource[0].resources[0].metadata.name: Required value",
+			},
+		},
+		{
+			name:   "mongodb filter without collection",
+			spec:   v1.CanarySpec{MongoDB: []v1.MongoDBCheck{{Database: "app", Filter: `{"status": "active"}`}}},
+			errors: []string{"spec.mongodb[0].collection: Required value"},
+		},
+		{
+			name: "github actions and gitlab",
+			spec: v1.CanarySpec{GitHub: []v1.GitHubCheck{{
+				Actions: &v1.CIPipelineCheck{Project: "org/repo"},
+				GitLab:  &v1.CIPipelineCheck{Project: "group/project"},
+			}}},
+			errors: []string{"spec.github[0].gitlab: Forbidden: actions and gitlab are mutually exclusive"},
+		},
+		{
+			name: "github actions with variables",
+			spec: v1.CanarySpec{GitHub: []v1.GitHubCheck{{
+				Actions: &v1.CIPipelineCheck{Project: "org/repo", Variables: map[string]string{"DEPLOY": "true"}},
+			}}},
+			errors: []string{"spec.github[0].actions.variables: Invalid value"},
+		},
+		{
+			name:   "webhook",
+			spec:   v1.CanarySpec{Webhook: &v1.WebhookCheck{Templatable: v1.Templatable{Transform: v1.Template{Expression: "[}"}}}},
+			errors: []string{"spec.webhook.transform.expr: Invalid value"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := ValidateCanary(v1.Canary{ObjectMeta: metav1.ObjectMeta{Name: "test"}, Spec: tt.spec})
+			if len(errs) != len(tt.errors) {
+				t.Fatalf("expected %d errors, got %v", len(tt.errors), errs)
+			}
+			for i, err := range errs {
+				if !strings.HasPrefix(err.Error(), tt.errors[i]) {
+					t.Errorf("expected error starting with %q, got %q", tt.errors[i], err.Error())
+				}
+			}
+		})
+	}
+}
+
+func TestValidateYAML(t *testing.T) {
+	docs := ValidateYAML(`apiVersion: canaries.flanksource.com/v1
+kind: Canary
+metadata:
+  name: dns
+spec:
+  schedule: "@every 1m"
+  dns:
+    - name: google
+      query: google.com
+      querytyp: A
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: ignored
+---
+apiVersion: canaries.flanksource.com/v1
+kind: Topology
+metadata:
+  name: cluster
+spec:
+  schedule: "61 * * * *"
+  healthExpr: "summary.healthy >"
+`)
+	if len(docs) != 2 {
+		t.Fatalf("expected 2 documents, got %d", len(docs))
+	}
+	if docs[0].Name != "dns" || docs[0].ParseError == nil || !strings.Contains(docs[0].ParseError.Error(), `unknown field "querytyp"`) {
+		t.Errorf("expected an unknown field error, got %v", docs[0].ParseError)
+	}
+	if docs[1].Kind != "Topology" || len(docs[1].Errors) != 2 {
+		t.Fatalf("expected 2 topology errors, got %v", docs[1].Errors)
+	}
+	if docs[1].Errors[0].Field != "spec.schedule" || docs[1].Errors[1].Field != "spec.healthExpr" {
+		t.Errorf("unexpected errors: %v", docs[1].Errors)
+	}
+}
@@ -33,6 +33,7 @@ echo "Waiting for server to accept connections"
 echo "::group::Deploying Base"
 ## applying CRD and a sample fixture for the operator
 kubectl apply -f config/deploy/Canary.yml
+kubectl apply -f config/deploy/CanaryTemplate.yml
 kubectl apply -f config/deploy/Topology.yml
 kubectl apply -f config/deploy/Component.yml
 